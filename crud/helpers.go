@@ -4,6 +4,7 @@ package crud
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
 	"strconv"
@@ -13,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 var (
@@ -29,7 +32,7 @@ var (
 
 type BaseCrud struct {
 	D      *schema.ResourceData
-	Client *baremetal.Client
+	Client *sdk.Client
 }
 
 func (s *BaseCrud) VoidState() {
@@ -143,7 +146,7 @@ func LoadBalancerResourceGet(s BaseCrud, workReq *baremetal.WorkRequest) (id str
 	return id, false, nil
 }
 
-func LoadBalancerWaitForWorkRequest(client *baremetal.Client, d *schema.ResourceData, wr *baremetal.WorkRequest) error {
+func LoadBalancerWaitForWorkRequest(client *sdk.Client, d *schema.ResourceData, wr *baremetal.WorkRequest) error {
 	var e error
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{
@@ -157,8 +160,12 @@ func LoadBalancerWaitForWorkRequest(client *baremetal.Client, d *schema.Resource
 			baremetal.ResourceFailed,
 		},
 		Refresh: func() (interface{}, string, error) {
-			wr, e = client.GetWorkRequest(wr.ID, nil)
-			return wr, wr.State, e
+			updated, e := client.GetWorkRequest(wr.ID, nil)
+			if e != nil {
+				return nil, "", e
+			}
+			wr = updated
+			return wr, wr.State, nil
 		},
 		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
@@ -167,11 +174,27 @@ func LoadBalancerWaitForWorkRequest(client *baremetal.Client, d *schema.Resource
 		return e
 	}
 	if wr.State == baremetal.ResourceFailed {
-		return errors.New("Resource creation failed, state FAILED")
+		return loadBalancerWorkRequestError(wr)
 	}
 	return nil
 }
 
+// loadBalancerWorkRequestError describes a failed work request, including
+// the error details reported by the service.
+func loadBalancerWorkRequestError(wr *baremetal.WorkRequest) error {
+	msgs := []string{}
+	for _, detail := range wr.ErrorDetails {
+		msgs = append(msgs, detail.ErrorCode+": "+detail.Message)
+	}
+	if len(msgs) == 0 && wr.Message != "" {
+		msgs = append(msgs, wr.Message)
+	}
+	if len(msgs) == 0 {
+		return fmt.Errorf("Work request %s failed, state FAILED", wr.ID)
+	}
+	return fmt.Errorf("Work request %s failed, state FAILED: %s", wr.ID, strings.Join(msgs, "; "))
+}
+
 func CreateDBSystemResource(d *schema.ResourceData, sync ResourceCreator) (e error) {
 	if e = sync.Create(); e != nil {
 		return e
@@ -248,17 +271,33 @@ func ReadResource(sync ResourceReader) (e error) {
 	return
 }
 
+// UpdateResource requests an Update(). If the resource updates
+// statefully, poll State to ensure:
+// () -> Pending -> Updated.
 func UpdateResource(d *schema.ResourceData, sync ResourceUpdater) (e error) {
 	d.Partial(true)
 	if e = sync.Update(); e != nil {
 		return
 	}
+
+	if stateful, ok := sync.(StatefullyUpdatedResource); ok {
+		if e = WaitForUpdatedState(d, stateful); e != nil {
+			return
+		}
+	}
 	d.Partial(false)
 	sync.SetData()
 
 	return
 }
 
+// WaitForUpdatedState polls State until it leaves UpdatedPending for one of
+// UpdatedTarget, within the update timeout. It is used by UpdateResource and
+// by resources which apply part of their configuration after creation.
+func WaitForUpdatedState(d *schema.ResourceData, sync StatefullyUpdatedResource) error {
+	return waitForStateRefresh(sync, d.Timeout(schema.TimeoutUpdate), sync.UpdatedPending(), sync.UpdatedTarget())
+}
+
 // DeleteResource requests a Delete(). If the resource deletes
 // statefully (not immediately), poll State to ensure:
 // () -> Pending -> Deleted.
@@ -0,0 +1,231 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/fakeoci"
+	"github.com/oracle/terraform-provider-oci/provider"
+)
+
+// CrudHelpersTestSuite runs resource lifecycles through the provider against
+// an in-memory fake, so CreateResource, ReadResource, UpdateResource and
+// DeleteResource are exercised without cloud credentials.
+type CrudHelpersTestSuite struct {
+	suite.Suite
+	Server    *fakeoci.Server
+	Client    *baremetal.Client
+	Providers map[string]terraform.ResourceProvider
+	Config    string
+}
+
+func (s *CrudHelpersTestSuite) SetupTest() {
+	s.Server = fakeoci.NewServer()
+	os.Setenv("OCI_url_template", s.Server.URLTemplate())
+	os.Setenv("OCI_allow_insecure_tls", "true")
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	s.Client, err = baremetal.NewClient("ocid1.user.oc1..fakeuser", s.Server.TenancyID, "aa:bb",
+		baremetal.PrivateKeyBytes(keyPEM),
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.DisableAutoRetries(true),
+		baremetal.CustomTransport(&http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}),
+	)
+	s.Require().NoError(err)
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"oci": provider.Provider(provider.ProviderConfig),
+	}
+	s.Config = fmt.Sprintf(`
+	provider "oci" {
+		tenancy_ocid = "%s"
+		user_ocid = "ocid1.user.oc1..fakeuser"
+		fingerprint = "aa:bb"
+		private_key = <<EOF
+%sEOF
+		region = "%s"
+		disable_auto_retries = true
+	}
+
+	variable "compartment_id" {
+		default = "ocid1.compartment.oc1..fake"
+	}
+	`, s.Server.TenancyID, keyPEM, s.Server.Region)
+}
+
+func (s *CrudHelpersTestSuite) TearDownTest() {
+	os.Unsetenv("OCI_url_template")
+	os.Unsetenv("OCI_allow_insecure_tls")
+	s.Server.Close()
+}
+
+func (s *CrudHelpersTestSuite) TestResourceLifecycle() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+					resource "oci_core_virtual_network" "t" {
+						cidr_block = "10.0.0.0/16"
+						compartment_id = "${var.compartment_id}"
+						display_name = "vcn"
+					}
+					resource "oci_core_internet_gateway" "t" {
+						compartment_id = "${var.compartment_id}"
+						vcn_id = "${oci_core_virtual_network.t.id}"
+						enabled = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_virtual_network.t", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("oci_core_internet_gateway.t", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("oci_core_internet_gateway.t", "enabled", "true"),
+				),
+			},
+			{
+				Config: s.Config + `
+					resource "oci_core_virtual_network" "t" {
+						cidr_block = "10.0.0.0/16"
+						compartment_id = "${var.compartment_id}"
+						display_name = "vcn-renamed"
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_virtual_network.t", "display_name", "vcn-renamed"),
+				),
+			},
+		},
+	})
+}
+
+func (s *CrudHelpersTestSuite) TestCreateResourceError() {
+	s.Server.InjectError("POST", "/vcns", 400, "InvalidParameter")
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+					resource "oci_core_virtual_network" "t" {
+						cidr_block = "10.0.0.0/16"
+						compartment_id = "${var.compartment_id}"
+					}`,
+				ExpectError: regexp.MustCompile("InvalidParameter"),
+			},
+		},
+	})
+}
+
+// createSubnet creates a subnet directly through the SDK, skipping the extra
+// wait the subnet resource adds after creation.
+func (s *CrudHelpersTestSuite) createSubnet() *baremetal.Subnet {
+	vcn, err := s.Client.CreateVirtualNetwork("10.0.0.0/16", "ocid1.compartment.oc1..fake", nil)
+	s.Require().NoError(err)
+	subnet, err := s.Client.CreateSubnet("Uocm:PHX-AD-1", "10.0.1.0/24", "ocid1.compartment.oc1..fake", vcn.ID, nil)
+	s.Require().NoError(err)
+	return subnet
+}
+
+func (s *CrudHelpersTestSuite) TestUpdateResourceWaitsForState() {
+	subnet := s.createSubnet()
+	instance := func(state string) string {
+		return s.Config + fmt.Sprintf(`
+			data "oci_core_images" "t" {
+				compartment_id = "${var.compartment_id}"
+				display_name = "Oracle-Linux-7.4-2017.10.25-0"
+			}
+			resource "oci_core_instance" "t" {
+				availability_domain = "Uocm:PHX-AD-1"
+				compartment_id = "${var.compartment_id}"
+				image = "${data.oci_core_images.t.images.0.id}"
+				shape = "VM.Standard1.1"
+				subnet_id = "%s"
+				%s
+			}`, subnet.ID, state)
+	}
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: instance(`state = "STOPPED"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_instance.t", "state", baremetal.ResourceStopped),
+					resource.TestCheckResourceAttrSet("oci_core_instance.t", "private_ip"),
+				),
+			},
+			// A stopped instance is not drift when state is no longer set.
+			{
+				Config:             instance(""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: instance(`state = "RUNNING"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_instance.t", "state", baremetal.ResourceRunning),
+				),
+			},
+		},
+	})
+}
+
+// waitForWorkRequest polls a load balancer work request until it finishes.
+func (s *CrudHelpersTestSuite) waitForWorkRequest(id string) {
+	for i := 0; i < 10; i++ {
+		wr, err := s.Client.GetWorkRequest(id, nil)
+		s.Require().NoError(err)
+		if wr.State == baremetal.WorkRequestSucceeded {
+			return
+		}
+	}
+	s.FailNow("work request never succeeded: " + id)
+}
+
+func (s *CrudHelpersTestSuite) TestLoadBalancerWaitForWorkRequestFailure() {
+	wrID, err := s.Client.CreateLoadBalancer(nil, nil, "ocid1.compartment.oc1..fake", nil, "100Mbps", []string{"ocid1.subnet.oc1..fake"}, nil)
+	s.Require().NoError(err)
+	s.waitForWorkRequest(wrID)
+	wr, err := s.Client.GetWorkRequest(wrID, nil)
+	s.Require().NoError(err)
+	wrID, err = s.Client.CreateBackendSet(wr.LoadBalancerID, "bs", "ROUND_ROBIN", nil, &baremetal.HealthChecker{Protocol: "TCP"}, nil, nil, nil)
+	s.Require().NoError(err)
+	s.waitForWorkRequest(wrID)
+
+	s.Server.FailWorkRequests(1, "backend capacity exceeded")
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + fmt.Sprintf(`
+					resource "oci_load_balancer_health_checker" "t" {
+						load_balancer_id = "%s"
+						backendset_name = "bs"
+						protocol = "HTTP"
+						url_path = "/"
+					}`, wr.LoadBalancerID),
+				ExpectError: regexp.MustCompile("state FAILED: INTERNAL_SERVER_ERROR: backend capacity exceeded"),
+			},
+		},
+	})
+}
+
+func TestCrudHelpersTestSuite(t *testing.T) {
+	suite.Run(t, new(CrudHelpersTestSuite))
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// instanceIdentity is the certificate authority and instance certificate
+// served by the fake metadata service.
+type instanceIdentity struct {
+	caKey   *rsa.PrivateKey
+	ca      *x509.Certificate
+	key     *rsa.PrivateKey
+	cert    *x509.Certificate
+	tokens  map[string]*securityToken
+	counter int
+}
+
+// securityToken is a token issued by the federation endpoint, bound to the
+// session key it was requested for.
+type securityToken struct {
+	publicKey *rsa.PublicKey
+	expires   time.Time
+}
+
+// MetadataURL returns the instance metadata endpoint of s, for the
+// metadata_url setting used by instance principals.
+func (s *Server) MetadataURL() string {
+	return s.URL + "/opc/v1"
+}
+
+// identity returns the instance identity, generating it on first use as
+// RSA keys are slow to generate.
+func (s *Server) identity() (*instanceIdentity, error) {
+	if s.instanceIdentity != nil {
+		return s.instanceIdentity, nil
+	}
+
+	id := &instanceIdentity{tokens: map[string]*securityToken{}}
+	var err error
+	if id.caKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return nil, err
+	}
+	if id.key, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return nil, err
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake OCI Instance Intermediate CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if id.ca, err = createCertificate(caTemplate, caTemplate, &id.caKey.PublicKey, id.caKey); err != nil {
+		return nil, err
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject: pkix.Name{
+			CommonName:         "ocid1.instance.oc1..fakeinstance",
+			OrganizationalUnit: []string{"opc-certtype:instance", "opc-instance:ocid1.instance.oc1..fakeinstance", "opc-tenant:" + s.TenancyID},
+		},
+		NotBefore:   notBefore,
+		NotAfter:    notBefore.Add(24 * time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if id.cert, err = createCertificate(leafTemplate, id.ca, &id.key.PublicKey, id.caKey); err != nil {
+		return nil, err
+	}
+
+	s.instanceIdentity = id
+	return id, nil
+}
+
+func createCertificate(template, parent *x509.Certificate, pub *rsa.PublicKey, signer *rsa.PrivateKey) (*x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// serveMetadata implements the identity documents and the region of the
+// instance metadata service, below /opc/v1.
+func (s *Server) serveMetadata(w http.ResponseWriter, path []string) {
+	if strings.Join(path, "/") == "instance/region" {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(s.Region))
+		return
+	}
+
+	id, err := s.identity()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", err.Error())
+		return
+	}
+
+	var block *pem.Block
+	switch strings.Join(path, "/") {
+	case "identity/cert.pem":
+		block = &pem.Block{Type: "CERTIFICATE", Bytes: id.cert.Raw}
+	case "identity/intermediate.pem":
+		block = &pem.Block{Type: "CERTIFICATE", Bytes: id.ca.Raw}
+	case "identity/key.pem":
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(id.key)}
+	default:
+		writeNotFound(w, "/opc/v1/"+strings.Join(path, "/"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(pem.EncodeToMemory(block))
+}
+
+// serveFederation exchanges an instance certificate, signed by the fake
+// certificate authority, for a security token bound to the session key in
+// the request. The request must be signed with the certificate's key.
+func (s *Server) serveFederation(w http.ResponseWriter, req *request) {
+	if len(req.parts) != 2 || req.parts[0] != "v1" || req.parts[1] != "x509" {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	if req.Method != http.MethodPost {
+		writeMethodNotAllowed(w, req)
+		return
+	}
+	id, err := s.identity()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", err.Error())
+		return
+	}
+
+	body := struct {
+		Certificate              string   `json:"certificate"`
+		PublicKey                string   `json:"publicKey"`
+		IntermediateCertificates []string `json:"intermediateCertificates"`
+	}{}
+	if err = json.Unmarshal(req.body, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	der, err := base64.StdEncoding.DecodeString(body.Certificate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	if err = cert.CheckSignatureFrom(id.ca); err != nil {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", "The certificate is not issued by the instance certificate authority")
+		return
+	}
+
+	sum := sha1.Sum(cert.Raw)
+	fingerprint := make([]string, len(sum))
+	for i, b := range sum {
+		fingerprint[i] = fmt.Sprintf("%02X", b)
+	}
+	certKey, _ := cert.PublicKey.(*rsa.PublicKey)
+	if err = verifySignature(req.Request, s.TenancyID+"/fed-x509/"+strings.Join(fingerprint, ":"), certKey); err != nil {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", err.Error())
+		return
+	}
+
+	der, err = base64.StdEncoding.DecodeString(body.PublicKey)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	sessionKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", "publicKey is not an RSA key")
+		return
+	}
+
+	token, err := s.issueToken(id, cert, sessionKey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, object{"token": token}, "")
+}
+
+// issueToken creates a JWT for the instance, signed by the certificate
+// authority, which expires after TokenLifetime.
+func (s *Server) issueToken(id *instanceIdentity, cert *x509.Certificate, sessionKey *rsa.PublicKey) (string, error) {
+	id.counter++
+	expires := time.Now().Add(s.TokenLifetime)
+	header, _ := json.Marshal(object{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(object{
+		"sub":    cert.Subject.CommonName,
+		"tenant": s.TenancyID,
+		"jti":    strconv.Itoa(id.counter),
+		"exp":    expires.Unix(),
+	})
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hashed := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, id.caKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+
+	token := signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+	id.tokens[token] = &securityToken{publicKey: sessionKey, expires: expires}
+	return token, nil
+}
+
+// authenticate verifies requests signed with a security token. Requests
+// signed with API keys are not authenticated.
+func (s *Server) authenticate(r *http.Request) error {
+	keyID := authorizationParams(r)["keyId"]
+	if !strings.HasPrefix(keyID, "ST$") {
+		return nil
+	}
+
+	var token *securityToken
+	if s.instanceIdentity != nil {
+		token = s.instanceIdentity.tokens[strings.TrimPrefix(keyID, "ST$")]
+	}
+	switch {
+	case token == nil:
+		return fmt.Errorf("The security token is not valid")
+	case time.Now().After(token.expires):
+		return fmt.Errorf("The security token has expired")
+	}
+	return verifySignature(r, keyID, token.publicKey)
+}
+
+// verifySignature checks a request is signed with key under keyID.
+func verifySignature(r *http.Request, keyID string, key *rsa.PublicKey) error {
+	params := authorizationParams(r)
+	if params["keyId"] != keyID || key == nil {
+		return fmt.Errorf("The request is not signed with key %s", keyID)
+	}
+	sig, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("The request signature is malformed")
+	}
+
+	lines := []string{}
+	for _, header := range strings.Fields(params["headers"]) {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(r.Method) + " " + r.RequestURI
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			value = r.Header.Get(header)
+		}
+		lines = append(lines, header+": "+value)
+	}
+	hashed := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], sig); err != nil {
+		return fmt.Errorf("The request signature does not match key %s", keyID)
+	}
+	return nil
+}
+
+// authorizationParams parses the Signature authorization header.
+func authorizationParams(r *http.Request) map[string]string {
+	params := map[string]string{}
+	value := strings.TrimPrefix(r.Header.Get("Authorization"), "Signature ")
+	for _, param := range strings.Split(value, ",") {
+		if i := strings.Index(param, "="); i > 0 {
+			params[strings.TrimSpace(param[:i])] = strings.Trim(param[i+1:], `"`)
+		}
+	}
+	return params
+}
@@ -0,0 +1,1117 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	consoleHistoryLifecycle = lifecycle{"REQUESTED", "SUCCEEDED", "", ""}
+	imageLifecycle          = lifecycle{"PROVISIONING", "AVAILABLE", "DISABLED", "DELETED"}
+	volumeBackupLifecycle   = lifecycle{"CREATING", "AVAILABLE", "TERMINATING", "TERMINATED"}
+	vnicLifecycle           = lifecycle{"", "AVAILABLE", "", ""}
+)
+
+// Shapes reported by the shapes API.
+var coreShapes = []string{"VM.Standard1.1", "VM.Standard1.2", "VM.Standard1.8", "BM.Standard1.36"}
+
+func (s *Server) registerCore() {
+	s.register("bootVolumeAttachments", "bootvolumeattachment", attachmentLifecycle)
+	s.register("bootVolumes", "bootvolume", provisioningLifecycle)
+	s.register("cpes", "cpe", lifecycle{})
+	s.register("dhcps", "dhcpoptions", provisioningLifecycle)
+	s.register("drgAttachments", "drgattachment", attachmentLifecycle)
+	s.register("drgs", "drg", provisioningLifecycle)
+	s.register("images", "image", imageLifecycle)
+	s.register("instanceConsoleHistories", "consolehistory", consoleHistoryLifecycle)
+	s.register("instances", "instance", instanceLifecycle)
+	s.register("internetGateways", "internetgateway", provisioningLifecycle)
+	s.register("ipsecConnections", "ipsecconnection", provisioningLifecycle)
+	s.register("privateIps", "privateip", lifecycle{})
+	s.register("routeTables", "routetable", provisioningLifecycle)
+	s.register("securityLists", "securitylist", provisioningLifecycle)
+	s.register("subnets", "subnet", provisioningLifecycle)
+	s.register("vcns", "vcn", provisioningLifecycle)
+	s.register("vnicAttachments", "vnicattachment", attachmentLifecycle)
+	s.register("vnics", "vnic", vnicLifecycle)
+	s.register("volumeAttachments", "volumeattachment", attachmentLifecycle)
+	s.register("volumeBackupPolicies", "volumebackuppolicy", lifecycle{})
+	s.register("volumeBackupPolicyAssignments", "volumebackuppolicyassignment", lifecycle{})
+	s.register("volumeBackups", "volumebackup", volumeBackupLifecycle)
+	s.register("volumes", "volume", provisioningLifecycle)
+
+	// Platform images are visible from every compartment.
+	s.create(s.coll("images"), object{
+		"compartmentId":          nil,
+		"displayName":            "Oracle-Linux-7.4-2017.10.25-0",
+		"operatingSystem":        "Oracle Linux",
+		"operatingSystemVersion": "7.4",
+		"createImageAllowed":     true,
+		"lifecycleState":         "AVAILABLE",
+	}, nil).transition(nil, "AVAILABLE")
+
+	// Oracle-defined backup policies belong to no compartment.
+	for _, policy := range oracleBackupPolicies {
+		s.create(s.coll("volumeBackupPolicies"), object{
+			"compartmentId": nil,
+			"displayName":   policy.name,
+			"schedules":     policy.schedules,
+		}, nil)
+	}
+}
+
+const (
+	daySeconds  = 24 * 60 * 60
+	weekSeconds = 7 * daySeconds
+	yearSeconds = 365 * daySeconds
+)
+
+// oracleBackupPolicies are the policies OCI defines for every tenancy.
+var oracleBackupPolicies = []struct {
+	name      string
+	schedules []object
+}{
+	{"gold", []object{
+		{"backupType": "INCREMENTAL", "period": "ONE_DAY", "offsetSeconds": 0, "retentionSeconds": 7 * daySeconds},
+		{"backupType": "INCREMENTAL", "period": "ONE_WEEK", "offsetSeconds": 0, "retentionSeconds": 4 * weekSeconds},
+		{"backupType": "INCREMENTAL", "period": "ONE_MONTH", "offsetSeconds": 0, "retentionSeconds": yearSeconds},
+		{"backupType": "FULL", "period": "ONE_YEAR", "offsetSeconds": 0, "retentionSeconds": 5 * yearSeconds},
+	}},
+	{"silver", []object{
+		{"backupType": "INCREMENTAL", "period": "ONE_WEEK", "offsetSeconds": 0, "retentionSeconds": 4 * weekSeconds},
+		{"backupType": "INCREMENTAL", "period": "ONE_MONTH", "offsetSeconds": 0, "retentionSeconds": yearSeconds},
+		{"backupType": "FULL", "period": "ONE_YEAR", "offsetSeconds": 0, "retentionSeconds": 5 * yearSeconds},
+	}},
+	{"bronze", []object{
+		{"backupType": "INCREMENTAL", "period": "ONE_MONTH", "offsetSeconds": 0, "retentionSeconds": yearSeconds},
+		{"backupType": "FULL", "period": "ONE_YEAR", "offsetSeconds": 0, "retentionSeconds": 5 * yearSeconds},
+	}},
+}
+
+func (s *Server) serveCore(w http.ResponseWriter, req *request) {
+	name := req.parts[0]
+	switch name {
+	case "shapes":
+		shapes := make([]object, len(coreShapes))
+		for i, shape := range coreShapes {
+			shapes[i] = object{"shape": shape}
+		}
+		s.writePage(w, req, shapes)
+	case "instances":
+		s.serveInstances(w, req)
+	case "bootVolumes":
+		s.serveBootVolumes(w, req)
+	case "bootVolumeAttachments":
+		s.serveBootVolumeAttachments(w, req)
+	case "vcns":
+		s.serveCollection(w, req, name, s.onCreateVcn)
+	case "subnets":
+		s.serveCollection(w, req, name, s.onCreateSubnet)
+	case "vnicAttachments":
+		s.serveVnicAttachments(w, req)
+	case "privateIps":
+		s.servePrivateIPs(w, req)
+	case "volumes":
+		s.serveVolumes(w, req)
+	case "volumeAttachments":
+		s.serveCollection(w, req, name, s.onCreateVolumeAttachment)
+	case "volumeBackups":
+		s.serveCollection(w, req, name, s.onCreateVolumeBackup)
+	case "volumeBackupPolicies":
+		s.serveVolumeBackupPolicies(w, req)
+	case "volumeBackupPolicyAssignments":
+		s.serveCollection(w, req, name, s.onCreateVolumeBackupPolicyAssignment)
+	case "images":
+		s.serveImages(w, req)
+	case "drgAttachments":
+		s.serveCollection(w, req, name, s.inheritFrom("drgs", "drgId"))
+	case "instanceConsoleHistories":
+		if len(req.parts) == 3 && req.parts[2] == "data" {
+			s.serveConsoleHistoryData(w, req)
+			return
+		}
+		s.serveCollection(w, req, name, s.inheritFrom("instances", "instanceId"))
+	case "ipsecConnections":
+		if len(req.parts) == 3 {
+			s.serveIPSecDevice(w, req)
+			return
+		}
+		s.serveCollection(w, req, name, nil)
+	case "cpes", "dhcps", "drgs", "internetGateways", "routeTables", "securityLists", "vnics":
+		s.serveCollection(w, req, name, nil)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// inheritFrom returns an onCreate hook that copies the compartment and
+// availability domain of the parent resource referenced by field.
+func (s *Server) inheritFrom(parent, field string) func(object) error {
+	return func(obj object) error {
+		id, _ := obj[field].(string)
+		p, ok := s.coll(parent).records[id]
+		if !ok {
+			return fmt.Errorf("%s %q not found", field, id)
+		}
+		for _, k := range []string{"compartmentId", "availabilityDomain"} {
+			if _, set := obj[k]; !set {
+				if v, has := p.obj[k]; has {
+					obj[k] = v
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func (s *Server) onCreateVcn(obj object) error {
+	if _, _, err := net.ParseCIDR(fmt.Sprint(obj["cidrBlock"])); err != nil {
+		return err
+	}
+	compartmentID := obj["compartmentId"]
+	defaults := func(name string, fields object) string {
+		fields["compartmentId"] = compartmentID
+		fields["displayName"] = fmt.Sprintf("Default %s for %v", name, obj["displayName"])
+		r := s.create(s.coll(name), fields, nil)
+		r.transition(nil, "AVAILABLE")
+		return r.obj["id"].(string)
+	}
+	vcnID := s.newID("vcn")
+	obj["id"] = vcnID
+	obj["defaultRouteTableId"] = defaults("routeTables", object{"vcnId": vcnID, "routeRules": []interface{}{}})
+	obj["defaultSecurityListId"] = defaults("securityLists", object{
+		"vcnId": vcnID,
+		"egressSecurityRules": []interface{}{
+			map[string]interface{}{"destination": "0.0.0.0/0", "protocol": "all"},
+		},
+		"ingressSecurityRules": []interface{}{
+			map[string]interface{}{"source": "0.0.0.0/0", "protocol": "6", "tcpOptions": map[string]interface{}{"destinationPortRange": map[string]interface{}{"min": 22, "max": 22}}},
+		},
+	})
+	obj["defaultDhcpOptionsId"] = defaults("dhcps", object{
+		"vcnId": vcnID,
+		"options": []interface{}{
+			map[string]interface{}{"type": "DomainNameServer", "serverType": "VcnLocalPlusInternet"},
+		},
+	})
+	if label, ok := obj["dnsLabel"].(string); ok && label != "" {
+		obj["vcnDomainName"] = label + ".oraclevcn.com"
+	}
+	return nil
+}
+
+func (s *Server) onCreateSubnet(obj object) error {
+	vcnID, _ := obj["vcnId"].(string)
+	vcn, ok := s.coll("vcns").records[vcnID]
+	if !ok {
+		return fmt.Errorf("vcnId %q not found", vcnID)
+	}
+	_, ipnet, err := net.ParseCIDR(fmt.Sprint(obj["cidrBlock"]))
+	if err != nil {
+		return err
+	}
+	if _, vcnNet, _ := net.ParseCIDR(fmt.Sprint(vcn.obj["cidrBlock"])); vcnNet != nil && !vcnNet.Contains(ipnet.IP) {
+		return fmt.Errorf("cidrBlock %v is not within the VCN %v", obj["cidrBlock"], vcn.obj["cidrBlock"])
+	}
+	if _, ok := obj["routeTableId"]; !ok {
+		obj["routeTableId"] = vcn.obj["defaultRouteTableId"]
+	}
+	if _, ok := obj["dhcpOptionsId"]; !ok {
+		obj["dhcpOptionsId"] = vcn.obj["defaultDhcpOptionsId"]
+	}
+	if _, ok := obj["securityListIds"]; !ok {
+		obj["securityListIds"] = []interface{}{vcn.obj["defaultSecurityListId"]}
+	}
+	obj["virtualRouterIp"] = hostIP(ipnet, 1)
+	obj["virtualRouterMac"] = "00:00:17:00:00:01"
+	if label, ok := obj["dnsLabel"].(string); ok && label != "" {
+		if domain, ok := vcn.obj["vcnDomainName"].(string); ok {
+			obj["subnetDomainName"] = label + "." + domain
+		}
+	}
+	return nil
+}
+
+// hostIP returns the n-th address of a network.
+func hostIP(ipnet *net.IPNet, n uint32) string {
+	ip := ipnet.IP.To4()
+	if ip == nil {
+		return ""
+	}
+	v := binary.BigEndian.Uint32(ip) + n
+	res := make(net.IP, 4)
+	binary.BigEndian.PutUint32(res, v)
+	return res.String()
+}
+
+// allocateIP returns the lowest address in the subnet which is not used by
+// a private IP. The first two addresses are reserved, as they are in OCI.
+func (s *Server) allocateIP(subnetID string) (string, error) {
+	subnet, ok := s.coll("subnets").records[subnetID]
+	if !ok {
+		return "", fmt.Errorf("subnetId %q not found", subnetID)
+	}
+	_, ipnet, err := net.ParseCIDR(fmt.Sprint(subnet.obj["cidrBlock"]))
+	if err != nil {
+		return "", err
+	}
+	used := map[string]bool{}
+	for _, r := range s.coll("privateIps").all() {
+		if r.obj["subnetId"] == subnetID {
+			used[fmt.Sprint(r.obj["ipAddress"])] = true
+		}
+	}
+	ones, bits := ipnet.Mask.Size()
+	size := uint32(1) << uint(bits-ones)
+	for n := uint32(2); n < size-1; n++ {
+		if ip := hostIP(ipnet, n); !used[ip] {
+			return ip, nil
+		}
+	}
+	return "", errors.New("subnet has no free addresses")
+}
+
+// createVnic creates an AVAILABLE VNIC in a subnet together with its
+// primary private IP.
+func (s *Server) createVnic(details map[string]interface{}, compartmentID, availabilityDomain interface{}, isPrimary bool) (*record, error) {
+	subnetID, _ := details["subnetId"].(string)
+	ip, _ := details["privateIp"].(string)
+	if ip == "" {
+		var err error
+		if ip, err = s.allocateIP(subnetID); err != nil {
+			return nil, err
+		}
+	}
+	vnic := object{
+		"availabilityDomain":  availabilityDomain,
+		"compartmentId":       compartmentID,
+		"displayName":         details["displayName"],
+		"hostnameLabel":       details["hostnameLabel"],
+		"isPrimary":           isPrimary,
+		"macAddress":          fmt.Sprintf("00:00:17:00:%02X:%02X", (s.counter>>8)&0xff, s.counter&0xff),
+		"privateIp":           ip,
+		"skipSourceDestCheck": details["skipSourceDestCheck"] == true,
+		"subnetId":            subnetID,
+	}
+	if assign, ok := details["assignPublicIp"].(bool); !ok || assign {
+		vnic["publicIp"] = fmt.Sprintf("129.146.%d.%d", (s.counter>>8)&0xff, s.counter&0xff)
+	}
+	r := s.create(s.coll("vnics"), vnic, nil)
+	s.create(s.coll("privateIps"), object{
+		"availabilityDomain": availabilityDomain,
+		"compartmentId":      compartmentID,
+		"displayName":        details["displayName"],
+		"hostnameLabel":      details["hostnameLabel"],
+		"ipAddress":          ip,
+		"isPrimary":          true,
+		"subnetId":           subnetID,
+		"vnicId":             r.obj["id"],
+	}, nil)
+	return r, nil
+}
+
+// attachVnic creates a VNIC and attaches it to an instance.
+func (s *Server) attachVnic(instance object, details map[string]interface{}, displayName interface{}, isPrimary bool) (*record, error) {
+	vnic, err := s.createVnic(details, instance["compartmentId"], instance["availabilityDomain"], isPrimary)
+	if err != nil {
+		return nil, err
+	}
+	r := s.create(s.coll("vnicAttachments"), object{
+		"availabilityDomain": instance["availabilityDomain"],
+		"compartmentId":      instance["compartmentId"],
+		"displayName":        displayName,
+		"instanceId":         instance["id"],
+		"subnetId":           vnic.obj["subnetId"],
+		"vnicId":             vnic.obj["id"],
+	}, nil)
+	// The primary VNIC is attached by the time its instance is RUNNING.
+	if isPrimary {
+		r.transition(nil, "ATTACHED")
+	}
+	return r, nil
+}
+
+// detachVnic removes a VNIC and all of its private IPs.
+func (s *Server) detachVnic(vnicID string) {
+	ips := s.coll("privateIps")
+	for _, r := range ips.all() {
+		if r.obj["vnicId"] == vnicID {
+			ips.remove(r.obj["id"].(string))
+		}
+	}
+	s.coll("vnics").remove(vnicID)
+}
+
+func (s *Server) serveInstances(w http.ResponseWriter, req *request) {
+	c := s.coll("instances")
+
+	if len(req.parts) == 1 && req.Method == http.MethodPost {
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		vnicDetails, _ := obj["createVnicDetails"].(map[string]interface{})
+		if vnicDetails == nil {
+			vnicDetails = map[string]interface{}{}
+		}
+		if subnetID, ok := obj["subnetId"]; ok {
+			vnicDetails["subnetId"] = subnetID
+		}
+		if label, ok := obj["hostnameLabel"]; ok {
+			vnicDetails["hostnameLabel"] = label
+		}
+		if _, ok := s.coll("subnets").records[fmt.Sprint(vnicDetails["subnetId"])]; !ok {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("subnetId %q not found", vnicDetails["subnetId"]))
+			return
+		}
+		bootVolume, err := s.launchSource(obj)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		for _, k := range []string{"createVnicDetails", "subnetId", "hostnameLabel"} {
+			delete(obj, k)
+		}
+		obj["region"] = s.currentRegion()
+
+		// The boot volume and its attachment become available with the
+		// instance.
+		obj["id"] = s.newID(c.prefix)
+		name := obj["displayName"]
+		if name == nil {
+			name = obj["id"]
+		}
+		if bootVolume == nil {
+			bootVolume = s.create(s.coll("bootVolumes"), object{
+				"availabilityDomain": obj["availabilityDomain"],
+				"compartmentId":      obj["compartmentId"],
+				"displayName":        fmt.Sprintf("%v (Boot Volume)", name),
+				"imageId":            obj["imageId"],
+				"sizeInGBs":          bootVolumeSizeInGBs,
+				"sizeInMBs":          bootVolumeSizeInGBs * 1024,
+			}, nil)
+		}
+		bootVolumeAttachment := s.create(s.coll("bootVolumeAttachments"), object{
+			"availabilityDomain": obj["availabilityDomain"],
+			"bootVolumeId":       bootVolume.obj["id"],
+			"compartmentId":      obj["compartmentId"],
+			"displayName":        fmt.Sprintf("%v (Boot Volume Attachment)", name),
+			"instanceId":         obj["id"],
+		}, nil)
+
+		var r *record
+		r = s.create(c, obj, func() {
+			s.attachVnic(r.obj, vnicDetails, nil, true)
+			bootVolume.transition(nil, "AVAILABLE")
+			bootVolumeAttachment.transition(nil, "ATTACHED")
+		})
+		writeRecord(w, http.StatusOK, r)
+		return
+	}
+
+	if len(req.parts) == 2 && req.Method == http.MethodPost {
+		s.instanceAction(w, req)
+		return
+	}
+
+	if len(req.parts) == 3 && req.parts[2] == "initialCredentials" {
+		if _, ok := s.lookup("instances", req.parts[1]); !ok {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		writeJSON(w, http.StatusOK, object{"username": "opc", "password": "fake-password"}, "")
+		return
+	}
+
+	if len(req.parts) == 2 && req.Method == http.MethodDelete {
+		r, ok := s.lookup("instances", req.parts[1])
+		if !ok || c.isDeleted(r) {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		instanceID := req.parts[1]
+		preserveBootVolume := req.query.Get("preserveBootVolume") == "true"
+		s.destroy(c, r, func() {
+			if a := s.activeBootVolumeAttachment("instanceId", instanceID); a != nil {
+				a.transition(nil, "DETACHED")
+				if bootVolume, ok := s.coll("bootVolumes").records[fmt.Sprint(a.obj["bootVolumeId"])]; ok && !preserveBootVolume {
+					s.destroy(s.coll("bootVolumes"), bootVolume, nil)
+				}
+			}
+			for _, a := range s.coll("vnicAttachments").all() {
+				if a.obj["instanceId"] == instanceID && a.state() == "ATTACHED" {
+					a.transition(nil, "DETACHED")
+					s.detachVnic(fmt.Sprint(a.obj["vnicId"]))
+				}
+			}
+			for _, a := range s.coll("volumeAttachments").all() {
+				if a.obj["instanceId"] == instanceID && a.state() == "ATTACHED" {
+					a.transition(nil, "DETACHED")
+				}
+			}
+		})
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.serveCollection(w, req, "instances", nil)
+}
+
+// bootVolumeSizeInGBs is the size of the boot volumes created on launch.
+const bootVolumeSizeInGBs = 47
+
+// launchSource resolves the image or boot volume an instance is launched
+// from, filling in imageId and sourceDetails. It returns the boot volume when
+// the instance boots from an existing one.
+func (s *Server) launchSource(obj object) (*record, error) {
+	details, _ := obj["sourceDetails"].(map[string]interface{})
+	if details == nil {
+		if obj["imageId"] == nil {
+			return nil, errors.New("one of imageId or sourceDetails is required")
+		}
+		obj["sourceDetails"] = object{"sourceType": "image", "imageId": obj["imageId"]}
+		return nil, nil
+	}
+
+	switch details["sourceType"] {
+	case "image":
+		obj["imageId"] = details["imageId"]
+		return nil, nil
+	case "bootVolume":
+		bootVolume, ok := s.coll("bootVolumes").records[fmt.Sprint(details["bootVolumeId"])]
+		if !ok {
+			return nil, fmt.Errorf("sourceDetails.bootVolumeId %q not found", details["bootVolumeId"])
+		}
+		if bootVolume.obj["availabilityDomain"] != obj["availabilityDomain"] {
+			return nil, fmt.Errorf("Boot volume %v is in %v, the instance must be in the same availability domain", details["bootVolumeId"], bootVolume.obj["availabilityDomain"])
+		}
+		if err := s.checkBootVolumeDetached(bootVolume); err != nil {
+			return nil, err
+		}
+		obj["imageId"] = bootVolume.obj["imageId"]
+		return bootVolume, nil
+	default:
+		return nil, fmt.Errorf("sourceDetails.sourceType %q is not supported", details["sourceType"])
+	}
+}
+
+// activeBootVolumeAttachment returns the attachment whose field matches id
+// and which has not been detached, or nil.
+func (s *Server) activeBootVolumeAttachment(field, id string) *record {
+	for _, a := range s.coll("bootVolumeAttachments").all() {
+		if a.obj[field] == id && (a.state() == "ATTACHING" || a.state() == "ATTACHED") {
+			return a
+		}
+	}
+	return nil
+}
+
+// checkBootVolumeDetached reports a conflict unless the boot volume is
+// available and neither booting an instance nor attached as a block volume.
+func (s *Server) checkBootVolumeDetached(bootVolume *record) error {
+	id := bootVolume.obj["id"].(string)
+	if bootVolume.state() != "AVAILABLE" {
+		return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Boot volume %s is %s", id, bootVolume.state())}
+	}
+	if a := s.activeBootVolumeAttachment("bootVolumeId", id); a != nil {
+		return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Boot volume %s is attached to instance %v", id, a.obj["instanceId"])}
+	}
+	for _, a := range s.coll("volumeAttachments").all() {
+		if a.obj["volumeId"] == id && (a.state() == "ATTACHING" || a.state() == "ATTACHED") {
+			return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Boot volume %s is attached as a block volume to instance %v", id, a.obj["instanceId"])}
+		}
+	}
+	return nil
+}
+
+// serveBootVolumes keeps attached boot volumes from being deleted.
+func (s *Server) serveBootVolumes(w http.ResponseWriter, req *request) {
+	if len(req.parts) == 2 && req.Method == http.MethodDelete {
+		if r, ok := s.coll("bootVolumes").records[req.parts[1]]; ok && r.state() == "AVAILABLE" {
+			if err := s.checkBootVolumeDetached(r); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+		}
+	}
+	s.serveCollection(w, req, "bootVolumes", s.onCreateBootVolume)
+}
+
+// onCreateBootVolume clones an existing boot volume.
+func (s *Server) onCreateBootVolume(obj object) error {
+	details, _ := obj["sourceDetails"].(map[string]interface{})
+	if details == nil || details["type"] != "bootVolume" {
+		return errors.New("sourceDetails of type bootVolume is required")
+	}
+	source, ok := s.coll("bootVolumes").records[fmt.Sprint(details["id"])]
+	if !ok {
+		return fmt.Errorf("sourceDetails.id %q not found", details["id"])
+	}
+	if source.obj["availabilityDomain"] != obj["availabilityDomain"] {
+		return fmt.Errorf("Boot volume %v is in %v, a clone must be in the same availability domain", details["id"], source.obj["availabilityDomain"])
+	}
+	if source.state() != "AVAILABLE" {
+		return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Boot volume %v is %s", details["id"], source.state())}
+	}
+	if _, ok := obj["sizeInGBs"]; !ok {
+		obj["sizeInGBs"] = source.obj["sizeInGBs"]
+	} else if toInt(obj["sizeInGBs"]) < toInt(source.obj["sizeInGBs"]) {
+		return fmt.Errorf("sizeInGBs %v is smaller than the %v GB source", obj["sizeInGBs"], source.obj["sizeInGBs"])
+	}
+	obj["sizeInMBs"] = toInt(obj["sizeInGBs"]) * 1024
+	obj["imageId"] = source.obj["imageId"]
+	return nil
+}
+
+// serveBootVolumeAttachments only attaches and detaches boot volumes of
+// stopped instances.
+func (s *Server) serveBootVolumeAttachments(w http.ResponseWriter, req *request) {
+	if len(req.parts) == 2 && req.Method == http.MethodDelete {
+		if a, ok := s.coll("bootVolumeAttachments").records[req.parts[1]]; ok && a.state() == "ATTACHED" {
+			if err := s.checkInstanceStopped(fmt.Sprint(a.obj["instanceId"])); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+		}
+	}
+	s.serveCollection(w, req, "bootVolumeAttachments", s.onCreateBootVolumeAttachment)
+}
+
+func (s *Server) onCreateBootVolumeAttachment(obj object) error {
+	if err := s.inheritFrom("instances", "instanceId")(obj); err != nil {
+		return err
+	}
+	bootVolume, ok := s.coll("bootVolumes").records[fmt.Sprint(obj["bootVolumeId"])]
+	if !ok {
+		return fmt.Errorf("bootVolumeId %q not found", obj["bootVolumeId"])
+	}
+	if bootVolume.obj["availabilityDomain"] != obj["availabilityDomain"] {
+		return fmt.Errorf("Boot volume %v is in %v, the instance is in %v", obj["bootVolumeId"], bootVolume.obj["availabilityDomain"], obj["availabilityDomain"])
+	}
+	if err := s.checkInstanceStopped(fmt.Sprint(obj["instanceId"])); err != nil {
+		return err
+	}
+	if a := s.activeBootVolumeAttachment("instanceId", fmt.Sprint(obj["instanceId"])); a != nil {
+		return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Instance %v already has boot volume %v attached", obj["instanceId"], a.obj["bootVolumeId"])}
+	}
+	return s.checkBootVolumeDetached(bootVolume)
+}
+
+func (s *Server) checkInstanceStopped(instanceID string) error {
+	instance, ok := s.coll("instances").records[instanceID]
+	if !ok {
+		return fmt.Errorf("instanceId %q not found", instanceID)
+	}
+	if instance.state() != "STOPPED" {
+		return &apiError{http.StatusConflict, "IncorrectState", fmt.Sprintf("Instance %s is %s, boot volumes can only be attached to or detached from stopped instances", instanceID, instance.state())}
+	}
+	return nil
+}
+
+// instanceActions maps an InstanceAction to the states it moves through
+// and the states it may be requested from.
+var instanceActions = map[string]struct {
+	from   []string
+	states []string
+}{
+	"START":     {[]string{"STOPPED", "RUNNING"}, []string{"STARTING", "RUNNING"}},
+	"STOP":      {[]string{"RUNNING", "STOPPED"}, []string{"STOPPING", "STOPPED"}},
+	"SOFTRESET": {[]string{"RUNNING"}, []string{"STOPPING", "STARTING", "RUNNING"}},
+	"RESET":     {[]string{"RUNNING"}, []string{"STOPPING", "STARTING", "RUNNING"}},
+}
+
+func (s *Server) instanceAction(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("instances", req.parts[1])
+	if !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	action, ok := instanceActions[strings.ToUpper(req.query.Get("action"))]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("Unknown action %q", req.query.Get("action")))
+		return
+	}
+	state := r.state()
+	allowed := false
+	for _, from := range action.from {
+		allowed = allowed || from == state
+	}
+	if !allowed {
+		writeError(w, http.StatusConflict, "IncorrectState", fmt.Sprintf("Instance is %s", state))
+		return
+	}
+	if state != action.states[len(action.states)-1] {
+		r.transition(nil, action.states...)
+		r.etag++
+	}
+	writeRecord(w, http.StatusOK, r)
+}
+
+func (s *Server) serveVnicAttachments(w http.ResponseWriter, req *request) {
+	c := s.coll("vnicAttachments")
+	switch {
+	case len(req.parts) == 1 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		instance, ok := s.coll("instances").records[fmt.Sprint(obj["instanceId"])]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("instanceId %q not found", obj["instanceId"]))
+			return
+		}
+		details, _ := obj["createVnicDetails"].(map[string]interface{})
+		if details == nil {
+			details = map[string]interface{}{}
+		}
+		r, err := s.attachVnic(instance.obj, details, obj["displayName"], false)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		writeRecord(w, http.StatusOK, r)
+	case len(req.parts) == 2 && req.Method == http.MethodDelete:
+		r, ok := s.lookup("vnicAttachments", req.parts[1])
+		if !ok || c.isDeleted(r) {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		vnicID := fmt.Sprint(r.obj["vnicId"])
+		s.destroy(c, r, func() { s.detachVnic(vnicID) })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.serveCollection(w, req, "vnicAttachments", nil)
+	}
+}
+
+// servePrivateIPs only lets a secondary private IP move to another VNIC in
+// its subnet, as OCI does.
+func (s *Server) servePrivateIPs(w http.ResponseWriter, req *request) {
+	if len(req.parts) == 2 && req.Method == http.MethodPut {
+		r, ok := s.lookup("privateIps", req.parts[1])
+		patch, err := req.decode()
+		if ok && err == nil && patch["vnicId"] != nil && patch["vnicId"] != r.obj["vnicId"] {
+			vnic, found := s.coll("vnics").records[fmt.Sprint(patch["vnicId"])]
+			switch {
+			case !found:
+				writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("vnicId %q not found", patch["vnicId"]))
+				return
+			case r.obj["isPrimary"] == true:
+				writeError(w, http.StatusBadRequest, "InvalidParameter", "A primary private IP cannot be moved")
+				return
+			case vnic.obj["subnetId"] != r.obj["subnetId"]:
+				writeError(w, http.StatusBadRequest, "InvalidParameter", "A private IP can only be moved to a VNIC in the same subnet")
+				return
+			}
+		}
+	}
+	s.serveCollection(w, req, "privateIps", s.onCreatePrivateIP)
+}
+
+func (s *Server) onCreatePrivateIP(obj object) error {
+	vnic, ok := s.coll("vnics").records[fmt.Sprint(obj["vnicId"])]
+	if !ok {
+		return fmt.Errorf("vnicId %q not found", obj["vnicId"])
+	}
+	obj["subnetId"] = vnic.obj["subnetId"]
+	obj["availabilityDomain"] = vnic.obj["availabilityDomain"]
+	obj["compartmentId"] = vnic.obj["compartmentId"]
+	obj["isPrimary"] = false
+	for _, r := range s.coll("privateIps").all() {
+		if r.obj["subnetId"] == obj["subnetId"] && obj["ipAddress"] != nil && r.obj["ipAddress"] == obj["ipAddress"] {
+			return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Private IP %v is already assigned in the subnet", obj["ipAddress"])}
+		}
+	}
+	if ip, _ := obj["ipAddress"].(string); ip == "" {
+		ip, err := s.allocateIP(fmt.Sprint(vnic.obj["subnetId"]))
+		if err != nil {
+			return err
+		}
+		obj["ipAddress"] = ip
+	}
+	return nil
+}
+
+// serveVolumes resizes a volume in place when an update grows it. Volumes
+// cannot shrink.
+func (s *Server) serveVolumes(w http.ResponseWriter, req *request) {
+	if len(req.parts) == 2 && req.Method == http.MethodPut {
+		r, ok := s.lookup("volumes", req.parts[1])
+		patch, err := req.decode()
+		if ok && err == nil && patch["sizeInGBs"] != nil {
+			size, current := toInt(patch["sizeInGBs"]), toInt(r.obj["sizeInGBs"])
+			switch {
+			case size < current:
+				writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("sizeInGBs cannot be reduced from %d to %d", current, size))
+				return
+			case r.state() != "AVAILABLE":
+				writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Volume %s is %s", req.parts[1], r.state()))
+				return
+			case size > current:
+				r.obj["sizeInMBs"] = size * 1024
+				r.transition(nil, "PROVISIONING", "AVAILABLE")
+			}
+		}
+	}
+	s.serveCollection(w, req, "volumes", s.onCreateVolume)
+}
+
+func (s *Server) onCreateVolume(obj object) error {
+	if details, ok := obj["sourceDetails"].(map[string]interface{}); ok {
+		var source *record
+		switch details["type"] {
+		case "volume":
+			source, ok = s.coll("volumes").records[fmt.Sprint(details["id"])]
+			if ok && source.obj["availabilityDomain"] != obj["availabilityDomain"] {
+				return fmt.Errorf("Volume %v is in %v, a clone must be in the same availability domain", details["id"], source.obj["availabilityDomain"])
+			}
+		case "volumeBackup":
+			source, ok = s.coll("volumeBackups").records[fmt.Sprint(details["id"])]
+		default:
+			return fmt.Errorf("sourceDetails.type %q is not supported", details["type"])
+		}
+		if !ok {
+			return fmt.Errorf("sourceDetails.id %q not found", details["id"])
+		}
+		if source.state() != "AVAILABLE" {
+			return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("%v %v is %s", details["type"], details["id"], source.state())}
+		}
+		if _, ok := obj["sizeInGBs"]; !ok {
+			obj["sizeInGBs"] = source.obj["sizeInGBs"]
+		} else if toInt(obj["sizeInGBs"]) < toInt(source.obj["sizeInGBs"]) {
+			return fmt.Errorf("sizeInGBs %v is smaller than the %v GB source", obj["sizeInGBs"], source.obj["sizeInGBs"])
+		}
+	}
+	if _, ok := obj["sizeInMBs"]; !ok {
+		obj["sizeInMBs"] = 51200
+	}
+	if _, ok := obj["sizeInGBs"]; !ok {
+		obj["sizeInGBs"] = toInt(obj["sizeInMBs"]) / 1024
+	}
+	obj["sizeInMBs"] = toInt(obj["sizeInGBs"]) * 1024
+	obj["isHydrated"] = true
+	return nil
+}
+
+func (s *Server) onCreateVolumeAttachment(obj object) error {
+	if err := s.inheritFrom("instances", "instanceId")(obj); err != nil {
+		return err
+	}
+	// A detached boot volume can be attached to another instance as a block
+	// volume, for example to repair it.
+	if bootVolume, ok := s.coll("bootVolumes").records[fmt.Sprint(obj["volumeId"])]; ok {
+		if err := s.checkBootVolumeDetached(bootVolume); err != nil {
+			return err
+		}
+	} else if _, ok := s.coll("volumes").records[fmt.Sprint(obj["volumeId"])]; !ok {
+		return fmt.Errorf("volumeId %q not found", obj["volumeId"])
+	}
+	obj["attachmentType"] = obj["type"]
+	delete(obj, "type")
+	if obj["attachmentType"] == "iscsi" {
+		obj["iqn"] = fmt.Sprintf("iqn.2015-12.com.oracleiaas:fake%06d", s.counter)
+		obj["ipv4"] = "169.254.2.2"
+		obj["port"] = 3260
+		obj["chapUsername"] = ""
+		obj["chapSecret"] = ""
+	}
+	return nil
+}
+
+func (s *Server) onCreateVolumeBackup(obj object) error {
+	volume, ok := s.coll("volumes").records[fmt.Sprint(obj["volumeId"])]
+	if !ok {
+		return fmt.Errorf("volumeId %q not found", obj["volumeId"])
+	}
+	obj["compartmentId"] = volume.obj["compartmentId"]
+	obj["sizeInMBs"] = volume.obj["sizeInMBs"]
+	obj["sizeInGBs"] = volume.obj["sizeInGBs"]
+	obj["uniqueSizeInMBs"] = volume.obj["sizeInMBs"]
+	obj["uniqueSizeInGBs"] = volume.obj["sizeInGBs"]
+	obj["timeRequestReceived"] = now()
+	return nil
+}
+
+// serveVolumeBackupPolicies lists the Oracle-defined policies when no
+// compartment is given, and keeps them from being changed.
+func (s *Server) serveVolumeBackupPolicies(w http.ResponseWriter, req *request) {
+	c := s.coll("volumeBackupPolicies")
+	switch {
+	case len(req.parts) == 1 && req.Method == http.MethodGet:
+		compartmentID := req.query.Get("compartmentId")
+		var items []object
+		for _, r := range c.all() {
+			if owner, _ := r.obj["compartmentId"].(string); owner == compartmentID && matches(r.obj, req.query) {
+				items = append(items, r.obj)
+			}
+		}
+		s.writePage(w, req, items)
+		return
+	case len(req.parts) == 2 && req.Method != http.MethodGet:
+		r, ok := s.lookup("volumeBackupPolicies", req.parts[1])
+		if !ok {
+			break
+		}
+		if r.obj["compartmentId"] == nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "Oracle-defined volume backup policies cannot be changed")
+			return
+		}
+		if req.Method != http.MethodDelete {
+			break
+		}
+		for _, a := range s.coll("volumeBackupPolicyAssignments").all() {
+			if a.obj["policyId"] == r.obj["id"] {
+				writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Volume backup policy %s is assigned to %v", r.obj["id"], a.obj["assetId"]))
+				return
+			}
+		}
+	}
+	s.serveCollection(w, req, "volumeBackupPolicies", s.onCreateVolumeBackupPolicy)
+}
+
+func (s *Server) onCreateVolumeBackupPolicy(obj object) error {
+	if _, ok := obj["schedules"].([]interface{}); !ok {
+		return errors.New("schedules is required")
+	}
+	return nil
+}
+
+// onCreateVolumeBackupPolicyAssignment allows at most one policy per volume.
+func (s *Server) onCreateVolumeBackupPolicyAssignment(obj object) error {
+	if _, ok := s.coll("volumes").records[fmt.Sprint(obj["assetId"])]; !ok {
+		return fmt.Errorf("assetId %q not found", obj["assetId"])
+	}
+	if _, ok := s.coll("volumeBackupPolicies").records[fmt.Sprint(obj["policyId"])]; !ok {
+		return fmt.Errorf("policyId %q not found", obj["policyId"])
+	}
+	for _, r := range s.coll("volumeBackupPolicyAssignments").all() {
+		if r.obj["assetId"] == obj["assetId"] {
+			return &apiError{http.StatusConflict, "Conflict", fmt.Sprintf("Volume %v already has backup policy %v assigned", obj["assetId"], r.obj["policyId"])}
+		}
+	}
+	return nil
+}
+
+// serveImages creates images from an instance or by importing an image file
+// from object storage, and exports images to object storage.
+func (s *Server) serveImages(w http.ResponseWriter, req *request) {
+	switch {
+	case len(req.parts) == 4 && req.parts[2] == "actions" && req.parts[3] == "export" && req.Method == http.MethodPost:
+		s.exportImage(w, req)
+		return
+	case len(req.parts) == 1 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		if obj["instanceId"] == nil {
+			s.importImage(w, obj)
+			return
+		}
+	}
+	s.serveCollection(w, req, "images", s.onCreateImage)
+}
+
+// importImage creates an image from the object named by imageSourceDetails.
+// The import fails, leaving the image DELETED, if the object does not exist.
+func (s *Server) importImage(w http.ResponseWriter, obj object) {
+	details, _ := obj["imageSourceDetails"].(map[string]interface{})
+	if details == nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", "one of instanceId or imageSourceDetails is required")
+		return
+	}
+	switch details["sourceImageType"] {
+	case nil, "QCOW2", "VMDK":
+	default:
+		writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("sourceImageType %q is not supported", details["sourceImageType"]))
+		return
+	}
+	b, name, err := s.imageObject(details, "source")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	delete(obj, "imageSourceDetails")
+	obj["createImageAllowed"] = true
+
+	// Images exported by this server carry their operating system.
+	o, ok := b.objects[name]
+	if ok {
+		var exported object
+		if json.Unmarshal(o.body, &exported) == nil {
+			obj["operatingSystem"] = exported["operatingSystem"]
+			obj["operatingSystemVersion"] = exported["operatingSystemVersion"]
+		}
+	}
+	r := s.create(s.coll("images"), obj, nil)
+	if ok {
+		r.transition(nil, "IMPORTING", "AVAILABLE")
+	} else {
+		r.transition(nil, "IMPORTING", "DELETED")
+	}
+	writeRecord(w, http.StatusOK, r)
+}
+
+// exportImage writes an image to the object named by the export details. The
+// image is EXPORTING until the object has been written.
+func (s *Server) exportImage(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("images", req.parts[1])
+	if !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	if !checkIfMatch(w, req, r) {
+		return
+	}
+	if r.state() != "AVAILABLE" {
+		writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Image %s is %s", req.parts[1], r.state()))
+		return
+	}
+	details, err := req.decode()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	b, name, err := s.imageObject(details, "destination")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	body, _ := json.Marshal(object{
+		"displayName":            r.obj["displayName"],
+		"operatingSystem":        r.obj["operatingSystem"],
+		"operatingSystemVersion": r.obj["operatingSystemVersion"],
+	})
+	r.transition(func() { s.putObject(b, name, body) }, "EXPORTING", "AVAILABLE")
+	r.etag++
+	writeRecord(w, http.StatusOK, r)
+}
+
+// imageObject resolves the bucket and object name of an image import source
+// or export destination, given either as a namespace, bucket and object
+// name tuple or as a URI, which may be a pre-authenticated request. kind is
+// the prefix of the type and URI fields.
+func (s *Server) imageObject(details map[string]interface{}, kind string) (*bucket, string, error) {
+	field := func(k string) string {
+		v, _ := details[k].(string)
+		return v
+	}
+
+	var namespace, bucketName, name, parID string
+	switch details[kind+"Type"] {
+	case "objectStorageTuple":
+		namespace, bucketName, name = field("namespaceName"), field("bucketName"), field("objectName")
+		if namespace == "" || bucketName == "" || name == "" {
+			return nil, "", errors.New("namespaceName, bucketName and objectName are required")
+		}
+	case "objectStorageUri":
+		u, err := url.Parse(field(kind + "Uri"))
+		if err != nil {
+			return nil, "", err
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) > 2 && parts[0] == "p" {
+			parID, parts = parts[1], parts[2:]
+		}
+		if len(parts) < 6 || parts[0] != "n" || parts[2] != "b" || parts[4] != "o" {
+			return nil, "", fmt.Errorf("%sUri %q is not an object storage object URI", kind, field(kind+"Uri"))
+		}
+		namespace, bucketName, name = parts[1], parts[3], strings.Join(parts[5:], "/")
+	default:
+		return nil, "", fmt.Errorf("%sType %q is not supported", kind, details[kind+"Type"])
+	}
+
+	if namespace != s.Namespace {
+		return nil, "", &apiError{http.StatusNotFound, "NamespaceNotFound", fmt.Sprintf("Namespace %s does not exist", namespace)}
+	}
+	b, ok := s.objectStorage.buckets[bucketName]
+	if !ok {
+		return nil, "", &apiError{http.StatusNotFound, "BucketNotFound", fmt.Sprintf("Bucket %s does not exist", bucketName)}
+	}
+	if _, ok := b.pars[parID]; parID != "" && !ok {
+		return nil, "", &apiError{http.StatusNotFound, "NotAuthorizedOrNotFound", fmt.Sprintf("Pre-authenticated request %s does not exist", parID)}
+	}
+	return b, name, nil
+}
+
+func (s *Server) onCreateImage(obj object) error {
+	instance, ok := s.coll("instances").records[fmt.Sprint(obj["instanceId"])]
+	if !ok {
+		return fmt.Errorf("instanceId %q not found", obj["instanceId"])
+	}
+	obj["baseImageId"] = instance.obj["imageId"]
+	if base, ok := s.coll("images").records[fmt.Sprint(instance.obj["imageId"])]; ok {
+		obj["operatingSystem"] = base.obj["operatingSystem"]
+		obj["operatingSystemVersion"] = base.obj["operatingSystemVersion"]
+	}
+	obj["createImageAllowed"] = true
+	delete(obj, "instanceId")
+	return nil
+}
+
+func (s *Server) serveConsoleHistoryData(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("instanceConsoleHistories", req.parts[1])
+	if !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("opc-bytes-remaining", "0")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "Console history for %v\n", r.obj["instanceId"])
+}
+
+func (s *Server) serveIPSecDevice(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("ipsecConnections", req.parts[1])
+	if !ok || req.Method != http.MethodGet {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	tunnels := []object{}
+	for i := 1; i <= 2; i++ {
+		tunnel := object{
+			"ipAddress":   fmt.Sprintf("129.146.0.%d", i),
+			"timeCreated": r.obj["timeCreated"],
+		}
+		switch req.parts[2] {
+		case "deviceConfig":
+			tunnel["sharedSecret"] = fmt.Sprintf("fakesecret%d", i)
+		case "deviceStatus":
+			tunnel["lifecycleState"] = "UP"
+			tunnel["timeStateModified"] = r.obj["timeCreated"]
+		default:
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		tunnels = append(tunnels, tunnel)
+	}
+	writeJSON(w, http.StatusOK, object{
+		"id":            r.obj["id"],
+		"compartmentId": r.obj["compartmentId"],
+		"timeCreated":   r.obj["timeCreated"],
+		"tunnels":       tunnels,
+	}, "")
+}
+
+// toInt converts a decoded JSON number to an int.
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	dbNodeLifecycle = lifecycle{"PROVISIONING", "AVAILABLE", "TERMINATING", "TERMINATED"}
+
+	// dbSystemShapes maps each shape to its available core count. The shapes
+	// data source test expects at least four shapes, and Exadata shapes
+	// have two nodes.
+	dbSystemShapes = []object{
+		{"name": "VM.Standard1.1", "shape": "VM.Standard1.1", "availableCoreCount": 1},
+		{"name": "VM.Standard1.2", "shape": "VM.Standard1.2", "availableCoreCount": 2},
+		{"name": "BM.DenseIO1.36", "shape": "BM.DenseIO1.36", "availableCoreCount": 36},
+		{"name": "BM.HighIO1.36", "shape": "BM.HighIO1.36", "availableCoreCount": 36},
+		{"name": "Exadata.Quarter1.84", "shape": "Exadata.Quarter1.84", "availableCoreCount": 22},
+	}
+
+	dbVersions = []object{
+		{"version": "11.2.0.4", "supportsPdb": false},
+		{"version": "12.1.0.2", "supportsPdb": true},
+		{"version": "12.2.0.1", "supportsPdb": true},
+	}
+)
+
+func (s *Server) registerDatabase() {
+	s.register("dbSystems", "dbsystem", provisioningLifecycle)
+	s.register("dbHomes", "dbhome", provisioningLifecycle)
+	s.register("databases", "database", provisioningLifecycle)
+	s.register("dbNodes", "dbnode", dbNodeLifecycle)
+}
+
+func (s *Server) serveDatabase(w http.ResponseWriter, req *request) {
+	name := req.parts[0]
+	switch name {
+	case "dbSystemShapes":
+		s.writePage(w, req, dbSystemShapes)
+	case "dbVersions":
+		s.writePage(w, req, dbVersions)
+	case "dbSystems":
+		s.serveDBSystems(w, req)
+	case "dbNodes":
+		if len(req.parts) == 2 && req.Method == http.MethodPost {
+			s.dbNodeAction(w, req)
+			return
+		}
+		s.serveCollection(w, req, name, nil)
+	case "dbHomes", "databases":
+		s.serveCollection(w, req, name, nil)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// serveDBSystems launches a DB system together with its DB home, database
+// and nodes, all of which follow the lifecycle of the system.
+func (s *Server) serveDBSystems(w http.ResponseWriter, req *request) {
+	c := s.coll("dbSystems")
+
+	switch {
+	case len(req.parts) == 1 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		if _, ok := s.coll("subnets").records[fmt.Sprint(obj["subnetId"])]; !ok {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("subnetId %q not found", obj["subnetId"]))
+			return
+		}
+		home, _ := obj["dbHome"].(map[string]interface{})
+		delete(obj, "dbHome")
+		if home == nil {
+			home = map[string]interface{}{}
+		}
+		database, _ := home["database"].(map[string]interface{})
+		if database == nil {
+			database = map[string]interface{}{}
+		}
+		delete(database, "adminPassword")
+
+		nodeCount := 1
+		if strings.HasPrefix(fmt.Sprint(obj["shape"]), "Exadata") {
+			nodeCount = 2
+		}
+		// Storage is requested as an initial size and reported as the
+		// current size; without this, data_storage_size_in_gb of DB systems
+		// never matches their configuration.
+		if size, ok := obj["initialDataStorageSizeInGB"]; ok {
+			obj["dataStorageSizeInGBs"] = size
+			delete(obj, "initialDataStorageSizeInGB")
+		}
+		obj["nodeCount"] = nodeCount
+		obj["version"] = home["dbVersion"]
+		obj["listenerPort"] = 1521
+		obj["lifecycleDetails"] = ""
+
+		systemID := s.newID("dbsystem")
+		obj["id"] = systemID
+		homeRecord := s.create(s.coll("dbHomes"), object{
+			"compartmentId": obj["compartmentId"],
+			"dbSystemId":    systemID,
+			"dbVersion":     home["dbVersion"],
+			"displayName":   home["displayName"],
+		}, nil)
+		dbRecord := s.create(s.coll("databases"), object{
+			"compartmentId":    obj["compartmentId"],
+			"dbHomeId":         homeRecord.obj["id"],
+			"dbName":           database["dbName"],
+			"dbUniqueName":     fmt.Sprintf("%v_fake", database["dbName"]),
+			"dbWorkload":       database["dbWorkload"],
+			"characterSet":     database["characterSet"],
+			"ncharacterSet":    database["ncharacterSet"],
+			"pdbName":          database["pdbName"],
+			"lifecycleDetails": "",
+		}, nil)
+		children := []*record{homeRecord, dbRecord}
+		for i := 1; i <= nodeCount; i++ {
+			vnic, err := s.createVnic(map[string]interface{}{"subnetId": obj["subnetId"]}, obj["compartmentId"], obj["availabilityDomain"], true)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			children = append(children, s.create(s.coll("dbNodes"), object{
+				"compartmentId": obj["compartmentId"],
+				"dbSystemId":    systemID,
+				"hostname":      fmt.Sprintf("%v%d", obj["hostname"], i),
+				"vnicId":        vnic.obj["id"],
+			}, nil))
+		}
+
+		r := s.create(c, obj, func() {
+			for _, child := range children {
+				child.transition(nil, "AVAILABLE")
+			}
+		})
+		writeRecord(w, http.StatusOK, r)
+	case len(req.parts) == 2 && req.Method == http.MethodDelete:
+		r, ok := s.lookup("dbSystems", req.parts[1])
+		if !ok || c.isDeleted(r) {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		systemID := req.parts[1]
+		s.destroy(c, r, func() {
+			for _, name := range []string{"dbNodes", "dbHomes"} {
+				for _, child := range s.coll(name).all() {
+					if child.obj["dbSystemId"] == systemID {
+						child.transition(nil, "TERMINATED")
+						if home := child.obj["id"]; name == "dbHomes" {
+							for _, db := range s.coll("databases").all() {
+								if db.obj["dbHomeId"] == home {
+									db.transition(nil, "TERMINATED")
+								}
+							}
+						}
+					}
+				}
+			}
+		})
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.serveCollection(w, req, "dbSystems", nil)
+	}
+}
+
+// dbNodeActions maps a DBNodeAction to the states it moves through and the
+// states it may be requested from.
+var dbNodeActions = map[string]struct {
+	from   []string
+	states []string
+}{
+	"START":     {[]string{"STOPPED", "AVAILABLE"}, []string{"STARTING", "AVAILABLE"}},
+	"STOP":      {[]string{"AVAILABLE", "STOPPED"}, []string{"STOPPING", "STOPPED"}},
+	"SOFTRESET": {[]string{"AVAILABLE"}, []string{"STOPPING", "STARTING", "AVAILABLE"}},
+	"RESET":     {[]string{"AVAILABLE"}, []string{"STOPPING", "STARTING", "AVAILABLE"}},
+}
+
+func (s *Server) dbNodeAction(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("dbNodes", req.parts[1])
+	if !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	action, ok := dbNodeActions[strings.ToUpper(req.query.Get("action"))]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("Unknown action %q", req.query.Get("action")))
+		return
+	}
+	state := r.state()
+	allowed := false
+	for _, from := range action.from {
+		allowed = allowed || from == state
+	}
+	if !allowed {
+		writeError(w, http.StatusConflict, "IncorrectState", fmt.Sprintf("DB node is %s", state))
+		return
+	}
+	// Starting an available node or stopping a stopped one does nothing;
+	// resets always cycle the node.
+	if len(action.states) > 2 || state != action.states[len(action.states)-1] {
+		r.transition(nil, action.states...)
+		r.etag++
+	}
+	writeRecord(w, http.StatusOK, r)
+}
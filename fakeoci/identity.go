@@ -0,0 +1,411 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"crypto/md5"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/oracle/terraform-provider-oci/policy"
+)
+
+// Regions reported by the regions API, keyed by region name.
+var identityRegions = map[string]string{
+	"us-phoenix-1":   "PHX",
+	"us-ashburn-1":   "IAD",
+	"eu-frankfurt-1": "FRA",
+}
+
+func (s *Server) registerIdentity() {
+	s.register("compartments", "compartment", identityLifecycle)
+	s.register("dynamicGroups", "dynamicgroup", identityLifecycle)
+	s.register("groups", "group", identityLifecycle)
+	s.register("policies", "policy", identityLifecycle)
+	s.register("userGroupMemberships", "groupmembership", identityLifecycle)
+	s.register("users", "user", identityLifecycle)
+	s.register("apiKeys", "apikey", identityLifecycle)
+	s.register("swiftPasswords", "credential", identityLifecycle)
+}
+
+func (s *Server) serveIdentity(w http.ResponseWriter, req *request) {
+	name := req.parts[0]
+	switch name {
+	case "availabilityDomains":
+		ads := []object{}
+		for i := 1; i <= 3; i++ {
+			ads = append(ads, object{
+				"name":          fmt.Sprintf("Uocm:%s-AD-%d", strings.ToUpper(identityRegions[s.currentRegion()]), i),
+				"compartmentId": req.query.Get("compartmentId"),
+			})
+		}
+		s.writePage(w, req, ads)
+	case "regions":
+		regions := []object{}
+		for _, name := range []string{"eu-frankfurt-1", "us-ashburn-1", "us-phoenix-1"} {
+			regions = append(regions, object{"key": identityRegions[name], "name": name})
+		}
+		writeJSON(w, http.StatusOK, regions, "")
+	case "tenancies":
+		if len(req.parts) != 2 || req.parts[1] != s.TenancyID {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		writeJSON(w, http.StatusOK, object{
+			"id":          s.TenancyID,
+			"name":        "faketenancy",
+			"description": "Fake tenancy",
+			"homeRegion":  identityRegions[s.Region],
+		}, "")
+	case "users":
+		if len(req.parts) >= 3 {
+			s.serveUserCredentials(w, req)
+			return
+		}
+		s.serveCollection(w, req, name, s.identityCreator(name, "UserAlreadyExists"))
+	case "policies":
+		if len(req.parts) == 2 && req.Method == http.MethodPut {
+			patch, err := req.decode()
+			if err == nil {
+				err = normalizePolicyStatements(patch)
+			}
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			req.body, _ = json.Marshal(patch)
+		}
+		s.serveCollection(w, req, name, s.identityCreator(name, "PolicyAlreadyExists"))
+	case "compartments":
+		s.serveCompartments(w, req)
+	case "groups":
+		s.serveCollection(w, req, name, s.identityCreator(name, "GroupAlreadyExists"))
+	case "dynamicGroups":
+		s.serveCollection(w, req, name, s.identityCreator(name, "DynamicGroupAlreadyExists"))
+	case "userGroupMemberships":
+		s.serveCollection(w, req, name, s.identityCreator(name, ""))
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// identityCreator returns an onCreate hook which rejects duplicate names
+// with conflictCode, as identity names are unique within a tenancy.
+// Compartment names are only unique within their parent compartment.
+func (s *Server) identityCreator(name, conflictCode string) func(object) error {
+	return func(obj object) error {
+		obj["inactiveStatus"] = nil
+		if _, ok := obj["compartmentId"]; !ok {
+			obj["compartmentId"] = s.TenancyID
+		}
+		if name == "policies" {
+			if _, ok := obj["statements"].([]interface{}); !ok {
+				return fmt.Errorf("statements is required")
+			}
+			if err := normalizePolicyStatements(obj); err != nil {
+				return err
+			}
+		}
+		if rule, _ := obj["matchingRule"].(string); name == "dynamicGroups" && rule == "" {
+			return fmt.Errorf("matchingRule is required")
+		}
+		if conflictCode == "" {
+			return nil
+		}
+		if name == "compartments" {
+			if err := s.checkParentCompartment(obj["compartmentId"]); err != nil {
+				return err
+			}
+		}
+		c := s.coll(name)
+		for _, r := range c.all() {
+			if r.obj["name"] != obj["name"] || c.isDeleted(r) {
+				continue
+			}
+			if name != "compartments" || r.obj["compartmentId"] == obj["compartmentId"] {
+				return &apiError{http.StatusConflict, conflictCode, fmt.Sprintf("%v already exists", obj["name"])}
+			}
+		}
+		return nil
+	}
+}
+
+// serveCompartments handles compartment moves, and refuses to delete
+// compartments which still contain resources.
+func (s *Server) serveCompartments(w http.ResponseWriter, req *request) {
+	switch {
+	case len(req.parts) == 4 && req.parts[2] == "actions" && req.parts[3] == "moveCompartment" && req.Method == http.MethodPost:
+		s.moveCompartment(w, req)
+		return
+	case len(req.parts) == 2 && req.Method == http.MethodDelete:
+		if r, ok := s.coll("compartments").records[req.parts[1]]; ok && r.state() == "ACTIVE" {
+			if contents := s.compartmentContents(req.parts[1]); contents != "" {
+				writeError(w, http.StatusConflict, "CompartmentNotEmpty", fmt.Sprintf("Compartment %s still contains %s", req.parts[1], contents))
+				return
+			}
+		}
+	}
+	s.serveCollection(w, req, "compartments", s.identityCreator("compartments", "CompartmentAlreadyExists"))
+}
+
+// moveCompartment moves a compartment below a new parent once its work
+// request completes, which happens after the compartment has been read.
+func (s *Server) moveCompartment(w http.ResponseWriter, req *request) {
+	r, ok := s.lookup("compartments", req.parts[1])
+	if !ok || r.state() != "ACTIVE" {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	if !checkIfMatch(w, req, r) {
+		return
+	}
+	body, err := req.decode()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	target := body["targetCompartmentId"]
+	if err = s.checkParentCompartment(target); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	// A compartment cannot be moved below itself.
+	for id := target; id != s.TenancyID; id = s.coll("compartments").records[fmt.Sprint(id)].obj["compartmentId"] {
+		if id == r.obj["id"] {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("Compartment %s cannot be moved below itself", req.parts[1]))
+			return
+		}
+	}
+	for _, other := range s.coll("compartments").all() {
+		if other.obj["compartmentId"] == target && other.obj["name"] == r.obj["name"] && other.state() == "ACTIVE" {
+			writeError(w, http.StatusConflict, "CompartmentAlreadyExists", fmt.Sprintf("%v already exists", r.obj["name"]))
+			return
+		}
+	}
+
+	r.transition(func() {
+		r.obj["compartmentId"] = target
+	}, "ACTIVE", "ACTIVE")
+	w.Header().Set("opc-work-request-id", s.newID("workrequest"))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// checkParentCompartment checks id names the tenancy or an active
+// compartment.
+func (s *Server) checkParentCompartment(id interface{}) error {
+	if id == s.TenancyID {
+		return nil
+	}
+	if r, ok := s.coll("compartments").records[fmt.Sprint(id)]; ok && r.state() == "ACTIVE" {
+		return nil
+	}
+	return &apiError{http.StatusNotFound, "NotAuthorizedOrNotFound", fmt.Sprintf("Compartment %v does not exist", id)}
+}
+
+// compartmentContents describes a resource which has not been deleted from
+// the compartment, or returns "" if the compartment is empty.
+func (s *Server) compartmentContents(id string) string {
+	names := make([]string, 0, len(s.colls))
+	for name := range s.colls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := s.colls[name]
+		for _, r := range c.all() {
+			if r.obj["compartmentId"] == id && !c.isDeleted(r) {
+				return fmt.Sprintf("%s %v", c.prefix, r.obj["id"])
+			}
+		}
+	}
+	for name, b := range s.objectStorage.buckets {
+		if b.obj["compartmentId"] == id {
+			return "bucket " + name
+		}
+	}
+	return ""
+}
+
+// serveUserCredentials handles the API key, swift password and console
+// password sub-resources of a user.
+func (s *Server) serveUserCredentials(w http.ResponseWriter, req *request) {
+	userID := req.parts[1]
+	if _, ok := s.lookup("users", userID); !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+
+	switch req.parts[2] {
+	case "uiPassword":
+		if req.Method != http.MethodPost {
+			writeMethodNotAllowed(w, req)
+			return
+		}
+		writeJSON(w, http.StatusOK, object{
+			"userId":         userID,
+			"password":       "fake-ui-password",
+			"lifecycleState": "ACTIVE",
+			"timeCreated":    now(),
+		}, "")
+	case "apiKeys":
+		s.serveAPIKeys(w, req, userID)
+	case "swiftPasswords":
+		s.serveSwiftPasswords(w, req, userID)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+func (s *Server) serveAPIKeys(w http.ResponseWriter, req *request, userID string) {
+	c := s.coll("apiKeys")
+	switch {
+	case len(req.parts) == 3 && req.Method == http.MethodGet:
+		keys := []object{}
+		for _, r := range c.all() {
+			s.advance(r)
+			if r.obj["userId"] == userID {
+				keys = append(keys, r.obj)
+			}
+		}
+		writeJSON(w, http.StatusOK, keys, "")
+	case len(req.parts) == 3 && req.Method == http.MethodPost:
+		body, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		key, _ := body["key"].(string)
+		fingerprint, err := publicKeyFingerprint(key)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		id := userID + "/" + fingerprint
+		if _, exists := c.records[id]; exists {
+			writeError(w, http.StatusConflict, "ApiKeyAlreadyExists", "The API key already exists")
+			return
+		}
+		r := s.create(c, object{
+			"id":           id,
+			"keyId":        s.TenancyID + "/" + userID + "/" + fingerprint,
+			"keyValue":     key,
+			"fingerprint":  fingerprint,
+			"userId":       userID,
+			"timeModified": now(),
+		}, nil)
+		writeJSON(w, http.StatusOK, apiKeyView(r.obj), "")
+	case len(req.parts) == 4 && req.Method == http.MethodDelete:
+		r, ok := c.records[userID+"/"+req.parts[3]]
+		if !ok || c.isDeleted(r) {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		s.destroy(c, r, nil)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+// apiKeyView strips the internal record ID from an API key.
+func apiKeyView(obj object) object {
+	view := object{}
+	for k, v := range obj {
+		if k != "id" {
+			view[k] = v
+		}
+	}
+	return view
+}
+
+func publicKeyFingerprint(key string) (string, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return "", fmt.Errorf("key is not PEM encoded")
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return "", err
+	}
+	sum := md5.Sum(block.Bytes)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+func (s *Server) serveSwiftPasswords(w http.ResponseWriter, req *request, userID string) {
+	c := s.coll("swiftPasswords")
+	switch {
+	case len(req.parts) == 3 && req.Method == http.MethodGet:
+		passwords := []object{}
+		for _, r := range c.all() {
+			s.advance(r)
+			if r.obj["userId"] == userID {
+				passwords = append(passwords, r.obj)
+			}
+		}
+		writeJSON(w, http.StatusOK, passwords, "")
+	case len(req.parts) == 3 && req.Method == http.MethodPost:
+		body, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		r := s.create(c, object{
+			"userId":         userID,
+			"description":    body["description"],
+			"password":       "fake-swift-password",
+			"inactiveStatus": nil,
+		}, nil)
+		writeRecord(w, http.StatusOK, r)
+	case len(req.parts) == 4:
+		r, ok := s.lookup("swiftPasswords", req.parts[3])
+		if !ok || r.obj["userId"] != userID || c.isDeleted(r) {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		switch req.Method {
+		case http.MethodPut:
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			merge(r.obj, patch)
+			r.etag++
+			writeRecord(w, http.StatusOK, r)
+		case http.MethodDelete:
+			s.destroy(c, r, nil)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+// normalizePolicyStatements rejects statements which are not valid policy
+// language, and rewrites the rest in canonical form as the service does.
+func normalizePolicyStatements(obj object) error {
+	statements, ok := obj["statements"].([]interface{})
+	if !ok {
+		return nil
+	}
+	for i, raw := range statements {
+		text, _ := raw.(string)
+		statement, err := policy.Parse(text)
+		if err != nil {
+			return err
+		}
+		statements[i] = statement.String()
+	}
+	return nil
+}
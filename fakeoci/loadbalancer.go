@@ -0,0 +1,457 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	loadBalancerLifecycle = lifecycle{"CREATING", "ACTIVE", "DELETING", "DELETED"}
+	workRequestLifecycle  = lifecycle{"", "ACCEPTED", "", ""}
+
+	loadBalancerShapes    = []object{{"name": "100Mbps"}, {"name": "400Mbps"}, {"name": "8000Mbps"}}
+	loadBalancerPolicies  = []object{{"name": "ROUND_ROBIN"}, {"name": "LEAST_CONNECTIONS"}, {"name": "IP_HASH"}}
+	loadBalancerProtocols = []object{{"name": "HTTP"}, {"name": "TCP"}}
+)
+
+func (s *Server) registerLoadBalancer() {
+	s.register("loadBalancers", "loadbalancer", loadBalancerLifecycle)
+	s.register("loadBalancerWorkRequests", "loadbalancerworkrequest", workRequestLifecycle)
+}
+
+// FailWorkRequests makes the next n load balancer work requests end in the
+// FAILED state with the given error message, without applying their change.
+func (s *Server) FailWorkRequests(n int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failWorkRequests = n
+	s.failWorkRequestMessage = message
+}
+
+// newWorkRequest queues a work request against a load balancer. apply runs
+// when the work request succeeds.
+func (s *Server) newWorkRequest(w http.ResponseWriter, loadBalancerID, kind string, apply func()) {
+	wr := object{
+		"loadBalancerId": loadBalancerID,
+		"type":           kind,
+		"message":        "",
+		"timeAccepted":   now(),
+		"errorDetails":   []interface{}{},
+	}
+	failed := s.failWorkRequests > 0
+	message := s.failWorkRequestMessage
+	if failed {
+		s.failWorkRequests--
+	}
+
+	var r *record
+	r = s.create(s.coll("loadBalancerWorkRequests"), wr, nil)
+	finished := func() {
+		r.obj["timeFinished"] = now()
+		if failed {
+			r.obj["message"] = message
+			r.obj["errorDetails"] = []interface{}{
+				map[string]interface{}{"errorCode": "INTERNAL_SERVER_ERROR", "message": message},
+			}
+			return
+		}
+		r.obj["message"] = fmt.Sprintf("%s succeeded", kind)
+		if apply != nil {
+			apply()
+		}
+	}
+	final := "SUCCEEDED"
+	if failed {
+		final = "FAILED"
+	}
+	r.transition(finished, "ACCEPTED", "IN_PROGRESS", final)
+
+	w.Header().Set("opc-work-request-id", r.obj["id"].(string))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// advanceWorkRequests moves the outstanding work requests of a load balancer
+// on as it is read, so callers polling the load balancer rather than the
+// work request still see it settle.
+func (s *Server) advanceWorkRequests(loadBalancerID string) {
+	for _, wr := range s.coll("loadBalancerWorkRequests").all() {
+		if wr.obj["loadBalancerId"] == loadBalancerID {
+			s.advance(wr)
+		}
+	}
+}
+
+func (s *Server) serveLoadBalancer(w http.ResponseWriter, req *request) {
+	switch req.parts[0] {
+	case "loadBalancerShapes":
+		s.writePage(w, req, loadBalancerShapes)
+	case "loadBalancerPolicies":
+		s.writePage(w, req, loadBalancerPolicies)
+	case "loadBalancerProtocols":
+		s.writePage(w, req, loadBalancerProtocols)
+	case "loadBalancerWorkRequests":
+		if len(req.parts) != 2 || req.Method != http.MethodGet {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		r, ok := s.lookup("loadBalancerWorkRequests", req.parts[1])
+		if !ok {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		writeRecord(w, http.StatusOK, r)
+	case "loadBalancers":
+		s.serveLoadBalancers(w, req)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+func (s *Server) serveLoadBalancers(w http.ResponseWriter, req *request) {
+	c := s.coll("loadBalancers")
+
+	if len(req.parts) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			s.list(w, req, c)
+		case http.MethodPost:
+			obj, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			for _, k := range []string{"backendSets", "certificates", "listeners"} {
+				if m, ok := obj[k].(map[string]interface{}); !ok || m == nil {
+					obj[k] = map[string]interface{}{}
+				}
+			}
+			obj["ipAddresses"] = []interface{}{
+				map[string]interface{}{"ipAddress": fmt.Sprintf("129.146.%d.%d", (s.counter>>8)&0xff, s.counter&0xff)},
+			}
+			r := s.create(c, obj, nil)
+			// The load balancer stays CREATING until its work request is done.
+			r.queue = nil
+			s.newWorkRequest(w, r.obj["id"].(string), "CreateLoadBalancer", func() {
+				r.transition(nil, "ACTIVE")
+			})
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+		return
+	}
+
+	if req.Method == http.MethodGet {
+		s.advanceWorkRequests(req.parts[1])
+	}
+	r, ok := s.lookup("loadBalancers", req.parts[1])
+	if !ok || c.isDeleted(r) {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	id := req.parts[1]
+
+	if len(req.parts) == 2 {
+		switch req.Method {
+		case http.MethodGet:
+			writeRecord(w, http.StatusOK, r)
+		case http.MethodPut:
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			s.newWorkRequest(w, id, "UpdateLoadBalancer", func() {
+				merge(r.obj, patch)
+				r.etag++
+			})
+		case http.MethodDelete:
+			s.newWorkRequest(w, id, "DeleteLoadBalancer", func() {
+				r.transition(nil, "DELETED")
+			})
+			r.obj["lifecycleState"] = "DELETING"
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+		return
+	}
+
+	switch req.parts[2] {
+	case "backendSets":
+		s.serveBackendSets(w, req, r)
+	case "listeners":
+		s.serveNamedChild(w, req, r, "listeners", "Listener")
+	case "certificates":
+		s.serveCertificates(w, req, r)
+	case "workRequests":
+		items := []object{}
+		for _, wr := range s.coll("loadBalancerWorkRequests").all() {
+			s.advance(wr)
+			if wr.obj["loadBalancerId"] == id {
+				items = append(items, wr.obj)
+			}
+		}
+		s.writePage(w, req, items)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// children returns the named map of sub-resources embedded in a load
+// balancer, such as its backend sets or listeners.
+func children(lb *record, key string) map[string]interface{} {
+	m, _ := lb.obj[key].(map[string]interface{})
+	if m == nil {
+		m = map[string]interface{}{}
+		lb.obj[key] = m
+	}
+	return m
+}
+
+// serveNamedChild handles POST on /{key} and PUT and DELETE on /{key}/{name}
+// for sub-resources embedded in a load balancer by name.
+func (s *Server) serveNamedChild(w http.ResponseWriter, req *request, lb *record, key, kind string) {
+	id := req.parts[1]
+	items := children(lb, key)
+	switch {
+	case len(req.parts) == 3 && req.Method == http.MethodGet:
+		list := []object{}
+		for _, v := range items {
+			list = append(list, object(v.(map[string]interface{})))
+		}
+		writeJSON(w, http.StatusOK, list, "")
+	case len(req.parts) == 3 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		name, _ := obj["name"].(string)
+		if name == "" {
+			name, _ = obj["certificateName"].(string)
+		}
+		if _, exists := items[name]; exists || name == "" {
+			writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("%s %q already exists", kind, name))
+			return
+		}
+		s.newWorkRequest(w, id, "Create"+kind, func() {
+			items[name] = map[string]interface{}(obj)
+			lb.etag++
+		})
+	case len(req.parts) == 4:
+		name := req.parts[3]
+		existing, ok := items[name].(map[string]interface{})
+		if !ok {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, existing, "")
+		case http.MethodPut:
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			s.newWorkRequest(w, id, "Update"+kind, func() {
+				merge(object(existing), patch)
+				lb.etag++
+			})
+		case http.MethodDelete:
+			s.newWorkRequest(w, id, "Delete"+kind, func() {
+				delete(items, name)
+				lb.etag++
+			})
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+func (s *Server) serveCertificates(w http.ResponseWriter, req *request, lb *record) {
+	if len(req.parts) == 3 && req.Method == http.MethodPost {
+		// Private keys and passphrases are never returned by the API.
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		name, _ := obj["certificateName"].(string)
+		items := children(lb, "certificates")
+		if _, exists := items[name]; exists || name == "" {
+			writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Certificate %q already exists", name))
+			return
+		}
+		delete(obj, "privateKey")
+		delete(obj, "passphrase")
+		s.newWorkRequest(w, req.parts[1], "CreateCertificate", func() {
+			items[name] = map[string]interface{}(obj)
+			lb.etag++
+		})
+		return
+	}
+	s.serveNamedChild(w, req, lb, "certificates", "Certificate")
+}
+
+func (s *Server) serveBackendSets(w http.ResponseWriter, req *request, lb *record) {
+	if len(req.parts) <= 4 {
+		if len(req.parts) == 3 && req.Method == http.MethodPost {
+			obj, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			backends, _ := obj["backends"].([]interface{})
+			for _, b := range backends {
+				setBackendName(b)
+			}
+			if backends == nil {
+				obj["backends"] = []interface{}{}
+			}
+			name, _ := obj["name"].(string)
+			items := children(lb, "backendSets")
+			if _, exists := items[name]; exists || name == "" {
+				writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Backend set %q already exists", name))
+				return
+			}
+			s.newWorkRequest(w, req.parts[1], "CreateBackendSet", func() {
+				items[name] = map[string]interface{}(obj)
+				lb.etag++
+			})
+			return
+		}
+		s.serveNamedChild(w, req, lb, "backendSets", "BackendSet")
+		return
+	}
+
+	set, ok := children(lb, "backendSets")[req.parts[3]].(map[string]interface{})
+	if !ok {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+
+	switch req.parts[4] {
+	case "healthChecker":
+		switch req.Method {
+		case http.MethodGet:
+			hc, _ := set["healthChecker"].(map[string]interface{})
+			if hc == nil {
+				hc = map[string]interface{}{}
+			}
+			writeJSON(w, http.StatusOK, hc, "")
+		case http.MethodPut:
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			for k, v := range healthCheckerDefaults {
+				if _, ok := patch[k]; !ok {
+					patch[k] = v
+				}
+			}
+			s.newWorkRequest(w, req.parts[1], "UpdateHealthChecker", func() {
+				set["healthChecker"] = map[string]interface{}(patch)
+				lb.etag++
+			})
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	case "backends":
+		s.serveBackends(w, req, lb, set)
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// healthCheckerDefaults are the values the service fills in for fields
+// omitted from a health checker update.
+var healthCheckerDefaults = object{
+	"intervalInMillis":  10000,
+	"port":              0,
+	"responseBodyRegex": ".*",
+	"retries":           3,
+	"returnCode":        200,
+	"timeoutInMillis":   3000,
+}
+
+// setBackendName fills in the name of a backend, which is its ip:port.
+func setBackendName(b interface{}) {
+	if m, ok := b.(map[string]interface{}); ok {
+		m["name"] = fmt.Sprintf("%v:%d", m["ipAddress"], toInt(m["port"]))
+	}
+}
+
+func (s *Server) serveBackends(w http.ResponseWriter, req *request, lb *record, set map[string]interface{}) {
+	id := req.parts[1]
+	backends, _ := set["backends"].([]interface{})
+	find := func(name string) int {
+		for i, b := range backends {
+			if m, ok := b.(map[string]interface{}); ok && m["name"] == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	switch {
+	case len(req.parts) == 5 && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, backends, "")
+	case len(req.parts) == 5 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		setBackendName(map[string]interface{}(obj))
+		if find(obj["name"].(string)) >= 0 {
+			writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Backend %v already exists", obj["name"]))
+			return
+		}
+		s.newWorkRequest(w, id, "CreateBackend", func() {
+			set["backends"] = append(set["backends"].([]interface{}), map[string]interface{}(obj))
+			lb.etag++
+		})
+	case len(req.parts) == 6:
+		name := strings.Replace(req.parts[5], "%3A", ":", -1)
+		i := find(name)
+		if i < 0 {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, backends[i], "")
+		case http.MethodPut:
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			s.newWorkRequest(w, id, "UpdateBackend", func() {
+				merge(object(backends[i].(map[string]interface{})), patch)
+				lb.etag++
+			})
+		case http.MethodDelete:
+			s.newWorkRequest(w, id, "DeleteBackend", func() {
+				current := set["backends"].([]interface{})
+				for j, b := range current {
+					if b.(map[string]interface{})["name"] == name {
+						set["backends"] = append(current[:j], current[j+1:]...)
+						break
+					}
+				}
+				lb.etag++
+			})
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
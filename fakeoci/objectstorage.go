@@ -0,0 +1,607 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const objectMetadataPrefix = "opc-meta-"
+
+// objectStore holds the buckets of the tenancy's namespace.
+type objectStore struct {
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	obj     object
+	etag    int
+	objects map[string]*storedObject
+	pars    map[string]object
+	uploads map[string]*multipartUpload
+}
+
+type storedObject struct {
+	body        []byte
+	md5         string
+	etag        string
+	contentType string
+	language    string
+	encoding    string
+	metadata    map[string]string
+	timeCreated string
+	// multipartMD5 is set instead of md5 for objects committed from a
+	// multipart upload.
+	multipartMD5 string
+}
+
+// multipartUpload is an upload in progress. obj is the summary returned by
+// the API.
+type multipartUpload struct {
+	obj         object
+	contentType string
+	language    string
+	encoding    string
+	metadata    map[string]string
+	parts       map[int]*uploadPart
+}
+
+type uploadPart struct {
+	body []byte
+	md5  string
+	etag string
+}
+
+func newObjectStore() *objectStore {
+	return &objectStore{buckets: map[string]*bucket{}}
+}
+
+// serveObjectStorage handles paths below /n, which have the form
+// /n/{namespace}/b/{bucket}/{o|p|u}/{name}.
+func (s *Server) serveObjectStorage(w http.ResponseWriter, req *request) {
+	parts := req.parts
+	if len(parts) == 0 || parts[0] != "n" {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+	if len(parts) == 1 {
+		writeJSON(w, http.StatusOK, s.Namespace, "")
+		return
+	}
+	if parts[1] != s.Namespace || len(parts) < 3 || parts[2] != "b" {
+		writeNotFound(w, req.URL.Path)
+		return
+	}
+
+	if len(parts) == 3 {
+		s.serveBuckets(w, req)
+		return
+	}
+	b, ok := s.objectStorage.buckets[parts[3]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "BucketNotFound", fmt.Sprintf("Bucket %s does not exist", parts[3]))
+		return
+	}
+	if len(parts) == 4 {
+		s.serveBucket(w, req, b)
+		return
+	}
+
+	switch parts[4] {
+	case "o":
+		if len(parts) == 5 {
+			s.listObjects(w, req, b)
+			return
+		}
+		// Object names may contain slashes.
+		name := strings.Join(parts[5:], "/")
+		s.serveObject(w, req, b, name)
+	case "p":
+		s.servePreauthenticatedRequests(w, req, b)
+	case "u":
+		if len(parts) == 5 {
+			s.serveMultipartUploads(w, req, b)
+			return
+		}
+		s.serveMultipartUpload(w, req, b, strings.Join(parts[5:], "/"))
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+func (s *Server) serveBuckets(w http.ResponseWriter, req *request) {
+	switch req.Method {
+	case http.MethodGet:
+		compartmentID := req.query.Get("compartmentId")
+		names := make([]string, 0, len(s.objectStorage.buckets))
+		for name, b := range s.objectStorage.buckets {
+			if b.obj["compartmentId"] == compartmentID {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		items := []object{}
+		for _, name := range names {
+			b := s.objectStorage.buckets[name]
+			items = append(items, object{
+				"namespace":     s.Namespace,
+				"name":          name,
+				"compartmentId": b.obj["compartmentId"],
+				"createdBy":     b.obj["createdBy"],
+				"timeCreated":   b.obj["timeCreated"],
+				"etag":          strconv.Itoa(b.etag),
+			})
+		}
+		s.writePage(w, req, items)
+	case http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		name, _ := obj["name"].(string)
+		if name == "" {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "name is required")
+			return
+		}
+		if _, exists := s.objectStorage.buckets[name]; exists {
+			writeError(w, http.StatusConflict, "BucketAlreadyExists", fmt.Sprintf("Bucket %s already exists", name))
+			return
+		}
+		obj["namespace"] = s.Namespace
+		obj["createdBy"] = "ocid1.user.oc1..fakeuser"
+		obj["timeCreated"] = now()
+		if _, ok := obj["publicAccessType"]; !ok {
+			obj["publicAccessType"] = "NoPublicAccess"
+		}
+		if _, ok := obj["metadata"]; !ok {
+			obj["metadata"] = map[string]interface{}{}
+		}
+		b := &bucket{
+			obj:     obj,
+			etag:    1,
+			objects: map[string]*storedObject{},
+			pars:    map[string]object{},
+			uploads: map[string]*multipartUpload{},
+		}
+		s.objectStorage.buckets[name] = b
+		writeJSON(w, http.StatusOK, b.obj, strconv.Itoa(b.etag))
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, req *request, b *bucket) {
+	etag := strconv.Itoa(b.etag)
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" && ifMatch != etag {
+		writeError(w, http.StatusPreconditionFailed, "NoEtagMatch", "The If-Match header does not match the current ETag")
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		writeJSON(w, http.StatusOK, b.obj, etag)
+	case http.MethodPost, http.MethodPut:
+		patch, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		oldName := b.obj["name"].(string)
+		delete(patch, "namespace")
+		merge(b.obj, patch)
+		if newName := b.obj["name"].(string); newName != oldName {
+			delete(s.objectStorage.buckets, oldName)
+			s.objectStorage.buckets[newName] = b
+		}
+		b.etag++
+		writeJSON(w, http.StatusOK, b.obj, strconv.Itoa(b.etag))
+	case http.MethodDelete:
+		if len(b.objects) > 0 {
+			writeError(w, http.StatusConflict, "BucketNotEmpty", fmt.Sprintf("Bucket %v is not empty", b.obj["name"]))
+			return
+		}
+		delete(s.objectStorage.buckets, b.obj["name"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+// listObjects lists objects in name order, honouring the prefix, start,
+// end, delimiter and limit query parameters.
+func (s *Server) listObjects(w http.ResponseWriter, req *request, b *bucket) {
+	if req.Method != http.MethodGet {
+		writeMethodNotAllowed(w, req)
+		return
+	}
+	prefix := req.query.Get("prefix")
+	start := req.query.Get("start")
+	end := req.query.Get("end")
+	delimiter := req.query.Get("delimiter")
+	limit, _ := strconv.Atoi(req.query.Get("limit"))
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	summaries := []object{}
+	prefixes := []string{}
+	seen := map[string]bool{}
+	next := ""
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || name < start || (end != "" && name >= end) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				p := name[:len(prefix)+i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					prefixes = append(prefixes, p)
+				}
+				continue
+			}
+		}
+		if len(summaries) == limit {
+			next = name
+			break
+		}
+		o := b.objects[name]
+		summaries = append(summaries, object{
+			"name":        name,
+			"size":        len(o.body),
+			"md5":         o.md5,
+			"timeCreated": o.timeCreated,
+		})
+	}
+
+	result := object{"objects": summaries, "prefixes": prefixes}
+	if next != "" {
+		result["nextStartWith"] = next
+	}
+	writeJSON(w, http.StatusOK, result, "")
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, req *request, b *bucket, name string) {
+	o, exists := b.objects[name]
+	if exists {
+		if ifMatch := req.Header.Get("If-Match"); ifMatch != "" && ifMatch != o.etag {
+			writeError(w, http.StatusPreconditionFailed, "NoEtagMatch", "The If-Match header does not match the current ETag")
+			return
+		}
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		if exists && req.Header.Get("If-None-Match") == "*" {
+			writeError(w, http.StatusPreconditionFailed, "IfNoneMatchFailed", fmt.Sprintf("Object %s already exists", name))
+			return
+		}
+		sum := md5.Sum(req.body)
+		digest := base64.StdEncoding.EncodeToString(sum[:])
+		if expected := req.Header.Get("Content-MD5"); expected != "" && expected != digest {
+			writeError(w, http.StatusBadRequest, "InvalidContentMD5", "The Content-MD5 header does not match the body")
+			return
+		}
+		metadata := map[string]string{}
+		for k, vs := range req.Header {
+			if lower := strings.ToLower(k); strings.HasPrefix(lower, objectMetadataPrefix) && len(vs) > 0 {
+				metadata[lower] = vs[0]
+			}
+		}
+		o = &storedObject{
+			body:        req.body,
+			md5:         digest,
+			etag:        s.newETag(),
+			contentType: req.Header.Get("Content-Type"),
+			language:    req.Header.Get("Content-Language"),
+			encoding:    req.Header.Get("Content-Encoding"),
+			metadata:    metadata,
+			timeCreated: now(),
+		}
+		b.objects[name] = o
+		w.Header().Set("ETag", o.etag)
+		w.Header().Set("opc-content-md5", o.md5)
+		w.Header().Set("Last-Modified", o.timeCreated)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			writeError(w, http.StatusNotFound, "ObjectNotFound", fmt.Sprintf("Object %s does not exist", name))
+			return
+		}
+		for k, v := range o.metadata {
+			w.Header().Set(k, v)
+		}
+		contentType := o.contentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+		if o.language != "" {
+			w.Header().Set("Content-Language", o.language)
+		}
+		if o.encoding != "" {
+			w.Header().Set("Content-Encoding", o.encoding)
+		}
+		if o.multipartMD5 != "" {
+			w.Header().Set("opc-multipart-md5", o.multipartMD5)
+		} else {
+			w.Header().Set("Content-MD5", o.md5)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.body)))
+		w.Header().Set("ETag", o.etag)
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			w.Write(o.body)
+		}
+	case http.MethodDelete:
+		if !exists {
+			writeError(w, http.StatusNotFound, "ObjectNotFound", fmt.Sprintf("Object %s does not exist", name))
+			return
+		}
+		delete(b.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+func (s *Server) servePreauthenticatedRequests(w http.ResponseWriter, req *request, b *bucket) {
+	switch {
+	case len(req.parts) == 5 && req.Method == http.MethodGet:
+		prefix := req.query.Get("objectNamePrefix")
+		ids := make([]string, 0, len(b.pars))
+		for id, par := range b.pars {
+			if name, _ := par["objectName"].(string); strings.HasPrefix(name, prefix) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		items := []object{}
+		for _, id := range ids {
+			items = append(items, parSummary(b.pars[id]))
+		}
+		s.writePage(w, req, items)
+	case len(req.parts) == 5 && req.Method == http.MethodPost:
+		obj, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		id := s.newID("preauthenticatedrequest")
+		obj["id"] = id
+		obj["timeCreated"] = now()
+		obj["accessUri"] = fmt.Sprintf("/p/%s/n/%s/b/%v/o/%v", id, s.Namespace, b.obj["name"], obj["objectName"])
+		b.pars[id] = obj
+		writeJSON(w, http.StatusOK, obj, "")
+	case len(req.parts) == 6:
+		par, ok := b.pars[req.parts[5]]
+		if !ok {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, parSummary(par), "")
+		case http.MethodDelete:
+			delete(b.pars, req.parts[5])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// parSummary strips the access URI, which is only returned on creation.
+func parSummary(par object) object {
+	summary := object{}
+	for k, v := range par {
+		if k != "accessUri" {
+			summary[k] = v
+		}
+	}
+	return summary
+}
+
+// putObject stores an object written by another service, such as an image
+// export.
+func (s *Server) putObject(b *bucket, name string, body []byte) {
+	sum := md5.Sum(body)
+	b.objects[name] = &storedObject{
+		body:        body,
+		md5:         base64.StdEncoding.EncodeToString(sum[:]),
+		etag:        s.newETag(),
+		contentType: "application/octet-stream",
+		metadata:    map[string]string{},
+		timeCreated: now(),
+	}
+}
+
+func (s *Server) newETag() string {
+	s.counter++
+	return fmt.Sprintf("fake-etag-%06d", s.counter)
+}
+
+// serveMultipartUploads lists and creates the multipart uploads of a bucket.
+func (s *Server) serveMultipartUploads(w http.ResponseWriter, req *request, b *bucket) {
+	switch req.Method {
+	case http.MethodGet:
+		ids := make([]string, 0, len(b.uploads))
+		for id := range b.uploads {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		items := []object{}
+		for _, id := range ids {
+			items = append(items, b.uploads[id].obj)
+		}
+		s.writePage(w, req, items)
+	case http.MethodPost:
+		details, err := req.decode()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		name, _ := details["object"].(string)
+		if name == "" {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "object is required")
+			return
+		}
+		upload := &multipartUpload{
+			obj: object{
+				"namespace":   s.Namespace,
+				"bucket":      b.obj["name"],
+				"object":      name,
+				"uploadId":    s.newID("multipartupload"),
+				"timeCreated": now(),
+			},
+			metadata: map[string]string{},
+			parts:    map[int]*uploadPart{},
+		}
+		upload.contentType, _ = details["contentType"].(string)
+		upload.language, _ = details["contentLanguage"].(string)
+		upload.encoding, _ = details["contentEncoding"].(string)
+		if metadata, ok := details["metadata"].(map[string]interface{}); ok {
+			// Keys carry the same opc-meta- prefix as the PutObject headers.
+			for k, v := range metadata {
+				upload.metadata[strings.ToLower(k)] = fmt.Sprint(v)
+			}
+		}
+		b.uploads[upload.obj["uploadId"].(string)] = upload
+		writeJSON(w, http.StatusOK, upload.obj, "")
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
+
+// serveMultipartUpload uploads parts to, lists the parts of, commits and
+// aborts the upload named by the uploadId query parameter.
+func (s *Server) serveMultipartUpload(w http.ResponseWriter, req *request, b *bucket, name string) {
+	uploadID := req.query.Get("uploadId")
+	upload, ok := b.uploads[uploadID]
+	if !ok || upload.obj["object"] != name {
+		writeError(w, http.StatusNotFound, "NoSuchUpload", fmt.Sprintf("Upload %s does not exist", uploadID))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		partNum, err := strconv.Atoi(req.query.Get("uploadPartNum"))
+		if err != nil || partNum < 1 || partNum > 10000 {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "uploadPartNum must be between 1 and 10000")
+			return
+		}
+		sum := md5.Sum(req.body)
+		digest := base64.StdEncoding.EncodeToString(sum[:])
+		if expected := req.Header.Get("Content-MD5"); expected != "" && expected != digest {
+			writeError(w, http.StatusBadRequest, "InvalidContentMD5", "The Content-MD5 header does not match the body")
+			return
+		}
+		part := &uploadPart{body: req.body, md5: digest, etag: s.newETag()}
+		upload.parts[partNum] = part
+		w.Header().Set("ETag", part.etag)
+		w.Header().Set("opc-content-md5", part.md5)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		nums := make([]int, 0, len(upload.parts))
+		for n := range upload.parts {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		items := []object{}
+		for _, n := range nums {
+			p := upload.parts[n]
+			items = append(items, object{
+				"partNumber": n,
+				"etag":       p.etag,
+				"md5":        p.md5,
+				"size":       len(p.body),
+			})
+		}
+		s.writePage(w, req, items)
+	case http.MethodPost:
+		var details struct {
+			PartsToCommit []struct {
+				PartNum int    `json:"partNum"`
+				ETag    string `json:"etag"`
+			} `json:"partsToCommit"`
+			PartsToExclude []int `json:"partsToExclude"`
+		}
+		if err := json.Unmarshal(req.body, &details); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		if len(details.PartsToCommit) == 0 {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "partsToCommit is required")
+			return
+		}
+		listed := map[int]bool{}
+		for _, n := range details.PartsToExclude {
+			listed[n] = true
+		}
+		var body []byte
+		var digests []byte
+		prev := 0
+		for _, c := range details.PartsToCommit {
+			p, ok := upload.parts[c.PartNum]
+			if !ok || p.etag != c.ETag {
+				writeError(w, http.StatusBadRequest, "InvalidUploadPart", fmt.Sprintf("Part %d was not uploaded with ETag %s", c.PartNum, c.ETag))
+				return
+			}
+			if c.PartNum <= prev {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", "partsToCommit must be in ascending order")
+				return
+			}
+			prev = c.PartNum
+			listed[c.PartNum] = true
+			body = append(body, p.body...)
+			raw, _ := base64.StdEncoding.DecodeString(p.md5)
+			digests = append(digests, raw...)
+		}
+		for n := range upload.parts {
+			if !listed[n] {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("Part %d must be committed or excluded", n))
+				return
+			}
+		}
+		whole := md5.Sum(body)
+		sum := md5.Sum(digests)
+		o := &storedObject{
+			body:         body,
+			md5:          base64.StdEncoding.EncodeToString(whole[:]),
+			etag:         s.newETag(),
+			contentType:  upload.contentType,
+			language:     upload.language,
+			encoding:     upload.encoding,
+			metadata:     upload.metadata,
+			timeCreated:  now(),
+			multipartMD5: fmt.Sprintf("%s-%d", base64.StdEncoding.EncodeToString(sum[:]), len(details.PartsToCommit)),
+		}
+		b.objects[name] = o
+		delete(b.uploads, uploadID)
+		w.Header().Set("ETag", o.etag)
+		w.Header().Set("opc-multipart-md5", o.multipartMD5)
+		w.Header().Set("Last-Modified", o.timeCreated)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(b.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, req)
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+// Package fakeoci is an in-memory fake of the Oracle Cloud Infrastructure
+// Core, Identity, Database, Load Balancer and Object Storage APIs.
+//
+// The fake is an httptest TLS server. Point the provider at it through the
+// url_template and allow_insecure_tls settings, for example:
+//
+//	srv := fakeoci.NewServer()
+//	defer srv.Close()
+//	os.Setenv("OCI_url_template", srv.URLTemplate())
+//	os.Setenv("OCI_allow_insecure_tls", "true")
+//
+// Requests signed with API keys are not authenticated. Resources move
+// through their transitional lifecycle states (PROVISIONING, TERMINATING,
+// ...) as they are polled, and load balancer changes are applied through work
+// requests.
+//
+// Instance principals are supported through a fake instance metadata service
+// at MetadataURL and the federation endpoint of the "auth" service. Requests
+// signed with a security token are verified against the session key the
+// token was issued for.
+package fakeoci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	coreAPIVersion         = "20160918"
+	loadBalancerAPIVersion = "20170115"
+)
+
+// Server is a fake OCI endpoint for every service used by the provider.
+type Server struct {
+	*httptest.Server
+
+	// PendingReads is how many times a resource in a transitional state
+	// must be read before it moves on to its next state.
+	PendingReads int
+
+	// Region is reported on instances and used to build OCIDs when a
+	// request does not name a region.
+	Region string
+	// TenancyID is the OCID of the fake tenancy, which is also the root
+	// compartment.
+	TenancyID string
+	// Namespace is the object storage namespace of the tenancy.
+	Namespace string
+	// TokenLifetime is how long security tokens issued to instance
+	// principals are valid.
+	TokenLifetime time.Duration
+
+	mu      sync.Mutex
+	counter int
+	// region is the region of the request being served, taken from its
+	// URL. OCIDs and instances are created in that region.
+	region string
+	colls  map[string]*collection
+	faults []fault
+	// served records the method and path of every request.
+	served []served
+
+	failWorkRequests       int
+	failWorkRequestMessage string
+
+	objectStorage *objectStore
+
+	instanceIdentity *instanceIdentity
+}
+
+// fault is a one-shot error returned instead of handling a request.
+type fault struct {
+	method string
+	path   string
+	status int
+	code   string
+}
+
+type served struct {
+	method string
+	path   string
+}
+
+// request is a parsed API call.
+type request struct {
+	*http.Request
+	// parts is the resource path below the API version, e.g.
+	// ["instances", "ocid1.instance..."].
+	parts []string
+	query url.Values
+	body  []byte
+}
+
+// decode unmarshals the request body into an object.
+func (r *request) decode() (object, error) {
+	obj := object{}
+	if len(r.body) == 0 {
+		return obj, nil
+	}
+	if err := json.Unmarshal(r.body, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// NewServer starts a fake OCI server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		PendingReads:  2,
+		Region:        "us-phoenix-1",
+		TenancyID:     "ocid1.tenancy.oc1..faketenancy",
+		Namespace:     "fakenamespace",
+		TokenLifetime: time.Hour,
+		colls:         map[string]*collection{},
+	}
+	s.registerCore()
+	s.registerIdentity()
+	s.registerDatabase()
+	s.registerLoadBalancer()
+	s.objectStorage = newObjectStore()
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+// URLTemplate returns the url_template that routes every service to s.
+func (s *Server) URLTemplate() string {
+	return s.URL + "/%s/%s"
+}
+
+// InjectError makes the next request whose method matches and whose path
+// contains pathSubstring fail with the given status and error code.
+func (s *Server) InjectError(method, pathSubstring string, status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault{method, pathSubstring, status, code})
+}
+
+// RequestCount returns how many requests whose method matches and whose
+// path contains pathSubstring have been received.
+func (s *Server) RequestCount(method, pathSubstring string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.served {
+		if r.method == method && strings.Contains(r.path, pathSubstring) {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) register(name, prefix string, lc lifecycle) *collection {
+	c := newCollection(prefix, lc)
+	s.colls[name] = c
+	return c
+}
+
+func (s *Server) coll(name string) *collection {
+	return s.colls[name]
+}
+
+// lookup returns the record for id in the named collection, advancing its
+// lifecycle as a read.
+func (s *Server) lookup(name, id string) (*record, bool) {
+	c := s.coll(name)
+	if c == nil {
+		return nil, false
+	}
+	r, ok := c.records[id]
+	if ok {
+		s.advance(r)
+	}
+	return r, ok
+}
+
+// ServeHTTP routes requests built from URLTemplate, which have the form
+// /{service}/{region}/{version}/{resource path}.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	s.served = append(s.served, served{r.Method, r.URL.Path})
+
+	for i, f := range s.faults {
+		if f.method == r.Method && strings.Contains(r.URL.Path, f.path) {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			writeError(w, f.status, f.code, fmt.Sprintf("Injected error for %s %s", r.Method, r.URL.Path))
+			return
+		}
+	}
+
+	var segments []string
+	for _, p := range strings.Split(r.URL.Path, "/") {
+		if p != "" {
+			segments = append(segments, p)
+		}
+	}
+	if len(segments) < 2 {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+	if segments[0] == "opc" && segments[1] == "v1" {
+		s.serveMetadata(w, segments[2:])
+		return
+	}
+	if err := s.authenticate(r); err != nil {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", err.Error())
+		return
+	}
+	service, rest := segments[0], segments[2:]
+	s.region = segments[1]
+	defer func() { s.region = "" }()
+
+	req := &request{Request: r, query: r.URL.Query(), body: body}
+
+	w.Header().Set("opc-request-id", s.newID("request"))
+
+	switch service {
+	case "objectstorage":
+		req.parts = rest
+		s.serveObjectStorage(w, req)
+		return
+	case "auth":
+		req.parts = rest
+		s.serveFederation(w, req)
+		return
+	case "iaas", "identity", "database":
+	default:
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	if len(rest) == 0 {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+	version := rest[0]
+	req.parts = rest[1:]
+	if len(req.parts) == 0 {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	switch {
+	case service == "iaas" && version == coreAPIVersion:
+		s.serveCore(w, req)
+	case service == "iaas" && version == loadBalancerAPIVersion:
+		s.serveLoadBalancer(w, req)
+	case service == "identity":
+		s.serveIdentity(w, req)
+	case service == "database":
+		s.serveDatabase(w, req)
+	default:
+		writeNotFound(w, r.URL.Path)
+	}
+}
+
+// serveCollection implements the common REST shape shared by most
+// resources: POST and GET on /{name}, and GET, PUT and DELETE on
+// /{name}/{id}. onCreate may fill in server-generated fields before the
+// resource is stored.
+func (s *Server) serveCollection(w http.ResponseWriter, req *request, name string, onCreate func(object) error) {
+	c := s.coll(name)
+	switch len(req.parts) {
+	case 1:
+		switch req.Method {
+		case http.MethodGet:
+			s.list(w, req, c)
+		case http.MethodPost:
+			obj, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			if onCreate != nil {
+				if err = onCreate(obj); err != nil {
+					writeAPIError(w, err)
+					return
+				}
+			}
+			r := s.create(c, obj, nil)
+			writeRecord(w, http.StatusOK, r)
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	case 2:
+		r, ok := s.lookup(name, req.parts[1])
+		if !ok {
+			writeNotFound(w, req.URL.Path)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeRecord(w, http.StatusOK, r)
+		case http.MethodPut:
+			if !checkIfMatch(w, req, r) {
+				return
+			}
+			if c.isDeleted(r) {
+				writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("%s is %s", req.parts[1], r.state()))
+				return
+			}
+			patch, err := req.decode()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+				return
+			}
+			merge(r.obj, patch)
+			r.etag++
+			writeRecord(w, http.StatusOK, r)
+		case http.MethodDelete:
+			if !checkIfMatch(w, req, r) {
+				return
+			}
+			if c.isDeleted(r) {
+				writeNotFound(w, req.URL.Path)
+				return
+			}
+			s.destroy(c, r, nil)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, req)
+		}
+	default:
+		writeNotFound(w, req.URL.Path)
+	}
+}
+
+// checkIfMatch enforces optimistic concurrency for requests carrying an
+// If-Match header.
+func checkIfMatch(w http.ResponseWriter, req *request, r *record) bool {
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" && ifMatch != r.ETag() {
+		writeError(w, http.StatusPreconditionFailed, "NoEtagMatch", "The If-Match header does not match the current ETag")
+		return false
+	}
+	return true
+}
+
+func writeRecord(w http.ResponseWriter, status int, r *record) {
+	writeJSON(w, status, r.obj, r.ETag())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}, etag string) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	w.WriteHeader(status)
+	w.Write(buf)
+}
+
+// apiError is returned by request hooks that need a status other than 400.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// writeAPIError writes err, treating anything but an *apiError as an invalid
+// parameter.
+func writeAPIError(w http.ResponseWriter, err error) {
+	if e, ok := err.(*apiError); ok {
+		writeError(w, e.status, e.code, e.message)
+		return
+	}
+	writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	buf, _ := json.Marshal(map[string]string{"code": code, "message": message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf)
+}
+
+// writeNotFound uses the wording the provider recognizes as a missing
+// resource.
+func writeNotFound(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusNotFound, "NotAuthorizedOrNotFound", fmt.Sprintf("Resource %s not found", path))
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, req *request) {
+	writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not allowed on %s", req.Method, req.URL.Path))
+}
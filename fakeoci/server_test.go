@@ -0,0 +1,202 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+type FakeServerTestSuite struct {
+	suite.Suite
+	Server *Server
+	Client *baremetal.Client
+}
+
+func (s *FakeServerTestSuite) SetupTest() {
+	s.Server = NewServer()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	s.Client, err = baremetal.NewClient("ocid1.user.oc1..fakeuser", s.Server.TenancyID, "aa:bb",
+		baremetal.PrivateKeyBytes(keyPEM),
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.DisableAutoRetries(true),
+		baremetal.CustomTransport(&http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *FakeServerTestSuite) TearDownTest() {
+	s.Server.Close()
+}
+
+// waitFor reads a resource until it reports the wanted state.
+func (s *FakeServerTestSuite) waitFor(want string, get func() (string, error)) {
+	for i := 0; i < 10; i++ {
+		state, err := get()
+		s.Require().NoError(err)
+		if state == want {
+			return
+		}
+	}
+	s.FailNow("resource never reached " + want)
+}
+
+func (s *FakeServerTestSuite) TestVcnLifecycle() {
+	compartmentID := "ocid1.compartment.oc1..fake"
+	vcn, err := s.Client.CreateVirtualNetwork("10.0.0.0/16", compartmentID, nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceProvisioning, vcn.State)
+	s.NotEmpty(vcn.DefaultRouteTableID)
+	s.NotEmpty(vcn.DefaultSecurityListID)
+
+	s.waitFor(baremetal.ResourceAvailable, func() (string, error) {
+		v, err := s.Client.GetVirtualNetwork(vcn.ID)
+		if err != nil {
+			return "", err
+		}
+		return v.State, nil
+	})
+
+	list, err := s.Client.ListVirtualNetworks(compartmentID, nil)
+	s.Require().NoError(err)
+	s.Len(list.VirtualNetworks, 1)
+
+	s.Require().NoError(s.Client.DeleteVirtualNetwork(vcn.ID, nil))
+	s.waitFor(baremetal.ResourceTerminated, func() (string, error) {
+		v, err := s.Client.GetVirtualNetwork(vcn.ID)
+		if err != nil {
+			return "", err
+		}
+		return v.State, nil
+	})
+}
+
+func (s *FakeServerTestSuite) TestNotFound() {
+	_, err := s.Client.GetVirtualNetwork("ocid1.vcn.oc1..missing")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "not found")
+}
+
+func (s *FakeServerTestSuite) TestInjectError() {
+	s.Server.InjectError(http.MethodPost, "/vcns", http.StatusInternalServerError, "InternalServerError")
+	_, err := s.Client.CreateVirtualNetwork("10.0.0.0/16", "ocid1.compartment.oc1..fake", nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "InternalServerError")
+
+	_, err = s.Client.CreateVirtualNetwork("10.0.0.0/16", "ocid1.compartment.oc1..fake", nil)
+	s.NoError(err)
+}
+
+func (s *FakeServerTestSuite) TestObjectStorage() {
+	namespace, err := s.Client.GetNamespace()
+	s.Require().NoError(err)
+	s.Equal(baremetal.Namespace(s.Server.Namespace), *namespace)
+
+	_, err = s.Client.CreateBucket("ocid1.compartment.oc1..fake", "b", *namespace, nil)
+	s.Require().NoError(err)
+
+	for _, name := range []string{"a/1", "a/2", "b"} {
+		_, err = s.Client.PutObject(*namespace, "b", name, []byte(name), nil)
+		s.Require().NoError(err)
+	}
+
+	obj, err := s.Client.GetObject(*namespace, "b", "a/1", nil)
+	s.Require().NoError(err)
+	s.Equal([]byte("a/1"), obj.Body)
+
+	list, err := s.Client.ListObjects(*namespace, "b", &baremetal.ListObjectsOptions{Delimiter: "/"})
+	s.Require().NoError(err)
+	s.Equal([]string{"a/"}, list.Prefixes)
+	s.Require().Len(list.Objects, 1)
+	s.Equal("b", list.Objects[0].Name)
+}
+
+func (s *FakeServerTestSuite) TestLoadBalancerWorkRequest() {
+	s.Server.FailWorkRequests(1, "backend capacity exceeded")
+	wrID, err := s.Client.CreateLoadBalancer(nil, nil, "ocid1.compartment.oc1..fake", nil, "100Mbps", []string{"ocid1.subnet.oc1..fake"}, nil)
+	s.Require().NoError(err)
+
+	s.waitFor(baremetal.WorkRequestFailed, func() (string, error) {
+		wr, err := s.Client.GetWorkRequest(wrID, nil)
+		if err != nil {
+			return "", err
+		}
+		return wr.State, nil
+	})
+}
+
+func (s *FakeServerTestSuite) TestInstancePrincipal() {
+	s.Server.TokenLifetime = 2 * time.Second
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	provider, err := sdk.NewInstancePrincipalKeyProvider(s.Server.MetadataURL(),
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.CustomTransport(transport),
+	)
+	s.Require().NoError(err)
+	s.Equal(s.Server.TenancyID, provider.TenancyOCID())
+
+	client, err := sdk.NewKeyProviderClient(provider.TenancyOCID(), provider,
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.DisableAutoRetries(true),
+		baremetal.CustomTransport(transport),
+	)
+	s.Require().NoError(err)
+
+	_, err = client.CreateGroup("instance-principal", "created by an instance", nil)
+	s.Require().NoError(err)
+	_, err = client.CreateDynamicGroup("instances", "instance.compartment.id = '"+s.Server.TenancyID+"'", "created by an instance", nil)
+	s.Require().NoError(err)
+	s.Equal(1, s.Server.RequestCount(http.MethodPost, "/x509"))
+
+	// The token is replaced once half of its lifetime has passed.
+	time.Sleep(1500 * time.Millisecond)
+	_, err = client.ListGroups(nil)
+	s.Require().NoError(err)
+	s.Equal(2, s.Server.RequestCount(http.MethodPost, "/x509"))
+
+	keyID, _, err := provider.SigningKey()
+	s.Require().NoError(err)
+	forged, err := sdk.NewKeyProviderClient(s.Server.TenancyID, forgedKeyProvider{keyID},
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.DisableAutoRetries(true),
+		baremetal.CustomTransport(transport),
+	)
+	s.Require().NoError(err)
+	_, err = forged.ListGroups(nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NotAuthenticated")
+}
+
+// forgedKeyProvider presents a valid security token, but signs with a key
+// the token was not issued for.
+type forgedKeyProvider struct {
+	keyID string
+}
+
+func (p forgedKeyProvider) SigningKey() (string, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	return p.keyID, key, err
+}
+
+func TestFakeServerTestSuite(t *testing.T) {
+	suite.Run(t, new(FakeServerTestSuite))
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeoci
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is the JSON representation of a resource, exactly as it is
+// returned to the SDK.
+type object map[string]interface{}
+
+// lifecycle lists the states a resource moves through. An empty creating
+// state means the resource is created directly in its created state. An
+// empty deleted state means the resource disappears as soon as it is deleted.
+type lifecycle struct {
+	creating string
+	created  string
+	deleting string
+	deleted  string
+}
+
+var (
+	provisioningLifecycle = lifecycle{"PROVISIONING", "AVAILABLE", "TERMINATING", "TERMINATED"}
+	attachmentLifecycle   = lifecycle{"ATTACHING", "ATTACHED", "DETACHING", "DETACHED"}
+	identityLifecycle     = lifecycle{"CREATING", "ACTIVE", "DELETING", "DELETED"}
+	instanceLifecycle     = lifecycle{"PROVISIONING", "RUNNING", "TERMINATING", "TERMINATED"}
+	availableLifecycle    = lifecycle{"", "AVAILABLE", "", ""}
+)
+
+// record wraps a stored object with the bookkeeping needed to simulate
+// asynchronous state changes.
+type record struct {
+	obj  object
+	etag int
+
+	// queue holds the lifecycle states the record still has to pass
+	// through. The head of the queue is applied after PendingReads reads.
+	queue []string
+	reads int
+	// settled runs once, after the last queued state has been applied.
+	settled func()
+}
+
+func (r *record) ETag() string {
+	return strconv.Itoa(r.etag)
+}
+
+func (r *record) state() string {
+	s, _ := r.obj["lifecycleState"].(string)
+	return s
+}
+
+// transition queues a sequence of lifecycle states on the record. The
+// record is immediately moved to the first state; the remaining states are
+// applied as the record is read.
+func (r *record) transition(settled func(), states ...string) {
+	r.reads = 0
+	r.settled = settled
+	if len(states) == 0 {
+		r.queue = nil
+		r.settle()
+		return
+	}
+	r.obj["lifecycleState"] = states[0]
+	r.queue = states[1:]
+	if len(r.queue) == 0 {
+		r.settle()
+	}
+}
+
+func (r *record) settle() {
+	if fn := r.settled; fn != nil {
+		r.settled = nil
+		fn()
+	}
+}
+
+// collection is an ordered, in-memory table of records.
+type collection struct {
+	prefix    string
+	lifecycle lifecycle
+	records   map[string]*record
+	order     []string
+}
+
+func newCollection(prefix string, lc lifecycle) *collection {
+	return &collection{
+		prefix:    prefix,
+		lifecycle: lc,
+		records:   map[string]*record{},
+	}
+}
+
+func (c *collection) put(id string, r *record) {
+	if _, ok := c.records[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.records[id] = r
+}
+
+func (c *collection) remove(id string) {
+	delete(c.records, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection) all() []*record {
+	res := make([]*record, 0, len(c.order))
+	for _, id := range c.order {
+		res = append(res, c.records[id])
+	}
+	return res
+}
+
+// newID returns a fresh OCID for the given resource type in the region of
+// the current request.
+func (s *Server) newID(resourceType string) string {
+	s.counter++
+	return fmt.Sprintf("ocid1.%s.oc1.%s.fake%06d", resourceType, s.currentRegion(), s.counter)
+}
+
+// currentRegion returns the region of the request being served.
+func (s *Server) currentRegion() string {
+	if s.region != "" {
+		return s.region
+	}
+	return s.Region
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// advance applies the next queued lifecycle state once the record has been
+// read PendingReads times.
+func (s *Server) advance(r *record) {
+	if len(r.queue) == 0 {
+		return
+	}
+	r.reads++
+	if r.reads < s.PendingReads {
+		return
+	}
+	r.reads = 0
+	r.obj["lifecycleState"] = r.queue[0]
+	r.queue = r.queue[1:]
+	r.etag++
+	if len(r.queue) == 0 {
+		r.settle()
+	}
+}
+
+// create stores obj in c, assigning an ID and creation time if missing and
+// starting its creation lifecycle.
+func (s *Server) create(c *collection, obj object, settled func()) *record {
+	if _, ok := obj["id"]; !ok {
+		obj["id"] = s.newID(c.prefix)
+	}
+	if _, ok := obj["timeCreated"]; !ok {
+		obj["timeCreated"] = now()
+	}
+	r := &record{obj: obj, etag: 1}
+	if c.lifecycle.creating != "" {
+		r.transition(settled, c.lifecycle.creating, c.lifecycle.created)
+	} else if c.lifecycle.created != "" {
+		r.transition(settled, c.lifecycle.created)
+	} else if settled != nil {
+		settled()
+	}
+	c.put(obj["id"].(string), r)
+	return r
+}
+
+// destroy starts the deletion lifecycle of r, removing it from c if the
+// collection does not retain deleted records.
+func (s *Server) destroy(c *collection, r *record, settled func()) {
+	id := r.obj["id"].(string)
+	r.etag++
+	switch {
+	case c.lifecycle.deleted == "":
+		c.remove(id)
+		if settled != nil {
+			settled()
+		}
+	case c.lifecycle.deleting != "":
+		r.transition(settled, c.lifecycle.deleting, c.lifecycle.deleted)
+	default:
+		r.transition(settled, c.lifecycle.deleted)
+	}
+}
+
+// isDeleted reports whether r has reached the terminal state of c.
+func (c *collection) isDeleted(r *record) bool {
+	return c.lifecycle.deleted != "" && r.state() == c.lifecycle.deleted
+}
+
+// merge copies the fields of patch onto obj, skipping the identity and
+// lifecycle fields which are owned by the server.
+func merge(obj, patch object) {
+	for k, v := range patch {
+		switch k {
+		case "id", "lifecycleState", "timeCreated":
+			continue
+		}
+		obj[k] = v
+	}
+}
+
+// listParams are query parameters which drive paging and sorting rather
+// than filtering.
+var listParams = map[string]bool{
+	"limit":     true,
+	"page":      true,
+	"sortBy":    true,
+	"sortOrder": true,
+}
+
+// matches reports whether obj has every string field named in query.
+func matches(obj object, query map[string][]string) bool {
+	for k, vs := range query {
+		if listParams[k] || len(vs) == 0 {
+			continue
+		}
+		v, ok := obj[k]
+		if !ok {
+			continue
+		}
+		if str, isStr := v.(string); isStr && str != vs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// list reads every matching record in c, advancing each one, and writes
+// one page of results.
+func (s *Server) list(w http.ResponseWriter, req *request, c *collection) {
+	var items []object
+	for _, r := range c.all() {
+		s.advance(r)
+		if matches(r.obj, req.query) {
+			items = append(items, r.obj)
+		}
+	}
+	s.writePage(w, req, items)
+}
+
+// writePage writes the page of items selected by the "limit" and "page"
+// query parameters, setting opc-next-page when more items remain.
+func (s *Server) writePage(w http.ResponseWriter, req *request, items []object) {
+	if sortBy := req.query.Get("sortBy"); sortBy != "" {
+		key := map[string]string{"TIMECREATED": "timeCreated", "DISPLAYNAME": "displayName"}[strings.ToUpper(sortBy)]
+		desc := strings.ToUpper(req.query.Get("sortOrder")) == "DESC"
+		sort.SliceStable(items, func(i, j int) bool {
+			a, _ := items[i][key].(string)
+			b, _ := items[j][key].(string)
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+	}
+
+	start, _ := strconv.Atoi(req.query.Get("page"))
+	if start > len(items) {
+		start = len(items)
+	}
+	end := len(items)
+	if limit, err := strconv.Atoi(req.query.Get("limit")); err == nil && limit > 0 && start+limit < end {
+		end = start + limit
+		w.Header().Set("opc-next-page", strconv.Itoa(end))
+	}
+
+	page := items[start:end]
+	if page == nil {
+		page = []object{}
+	}
+	writeJSON(w, http.StatusOK, page, "")
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package policy
+
+import "strings"
+
+// AllResources is the resource type which includes every other.
+const AllResources = "all-resources"
+
+// ResourceFamilies lists the resource types each family grants access to.
+var ResourceFamilies = map[string][]string{
+	"database-family":        {"db-systems", "db-nodes", "db-homes", "databases", "backups"},
+	"instance-family":        {"instances", "instance-images", "instance-console-connection", "console-histories", "volume-attachments", "app-catalog-listing"},
+	"object-family":          {"buckets", "objects"},
+	"virtual-network-family": {"vcns", "subnets", "route-tables", "security-lists", "dhcp-options", "private-ips", "public-ips", "internet-gateways", "local-peering-gateways", "drgs", "drg-attachments", "cpes", "ipsec-connections", "vnics", "vnic-attachments"},
+	"volume-family":          {"volumes", "volume-attachments", "volume-backups", "boot-volumes", "boot-volume-backups"},
+}
+
+// Compartment is a compartment on the path to the compartment access is
+// requested in. The tenancy is the root compartment.
+type Compartment struct {
+	ID   string
+	Name string
+}
+
+// Request describes the access to evaluate: whether members of a group may
+// use a verb on a resource type in the last compartment of Path. Path starts
+// at the tenancy.
+type Request struct {
+	GroupName    string
+	GroupID      string
+	Verb         string
+	ResourceType string
+	Path         []Compartment
+}
+
+// Grant is a statement of a policy, and the compartment the policy is
+// attached to.
+type Grant struct {
+	PolicyID      string
+	PolicyName    string
+	CompartmentID string
+	Statement     string
+}
+
+// Match is a grant whose statement gives the requested access. Conditional
+// is set when the statement has a where clause, which depends on the
+// request at hand and so is not evaluated.
+type Match struct {
+	Grant
+	Conditional bool
+}
+
+// Evaluate returns the grants which give the requested access. Statements
+// granting access to a compartment also grant it to the compartments below,
+// and each verb includes the access of the verbs before it in Verbs. Only
+// Allow statements naming a verb are evaluated; statements which do not
+// parse are ignored.
+func Evaluate(r *Request, grants []Grant) []Match {
+	matches := []Match{}
+	for _, grant := range grants {
+		attached := r.indexOf(grant.CompartmentID)
+		if attached < 0 {
+			continue
+		}
+		s, err := Parse(grant.Statement)
+		if err != nil || s.Kind != Allow || s.Verb == "" {
+			continue
+		}
+		if r.matchesSubjects(s.Subjects) && verbIncludes(s.Verb, r.Verb) &&
+			resourceTypeIncludes(s.ResourceType, r.ResourceType) && r.inLocation(s.Location, attached) {
+			matches = append(matches, Match{Grant: grant, Conditional: s.Condition != nil})
+		}
+	}
+	return matches
+}
+
+// Allowed reports whether any of the matches grants access unconditionally.
+func Allowed(matches []Match) bool {
+	for _, match := range matches {
+		if !match.Conditional {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Request) indexOf(compartmentID string) int {
+	for i, compartment := range r.Path {
+		if compartment.ID == compartmentID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Request) matchesSubjects(subjects []Subject) bool {
+	for _, subject := range subjects {
+		switch {
+		case subject.Kind == SubjectAnyUser:
+			return true
+		case subject.Kind != SubjectGroup:
+		case subject.ByID && r.GroupID != "" && strings.EqualFold(subject.Name, r.GroupID):
+			return true
+		case !subject.ByID && strings.EqualFold(subject.Name, r.GroupName):
+			return true
+		}
+	}
+	return false
+}
+
+func verbIncludes(granted, requested string) bool {
+	return verbIndex(granted) >= verbIndex(requested) && verbIndex(requested) >= 0
+}
+
+func verbIndex(verb string) int {
+	for i, v := range Verbs {
+		if v == verb {
+			return i
+		}
+	}
+	return -1
+}
+
+func resourceTypeIncludes(granted, requested string) bool {
+	if granted == AllResources || granted == requested {
+		return true
+	}
+	for _, resourceType := range ResourceFamilies[granted] {
+		if resourceType == requested {
+			return true
+		}
+	}
+	return false
+}
+
+// inLocation reports whether a statement of a policy attached to the
+// compartment at Path[attached] grants access in the requested compartment.
+// Compartments are named by their path below the policy's compartment, or
+// starting with the policy's compartment itself.
+func (r *Request) inLocation(l Location, attached int) bool {
+	switch {
+	case l.Kind == LocationTenancy:
+		return attached == 0
+	case l.Kind != LocationCompartment:
+		return false
+	case l.ByID:
+		return r.indexOf(l.Name) >= attached
+	}
+
+	names := strings.Split(l.Name, ":")
+	start := attached + 1
+	if attached > 0 && strings.EqualFold(names[0], r.Path[attached].Name) {
+		start = attached
+	}
+	if start+len(names) > len(r.Path) {
+		return false
+	}
+	for i, name := range names {
+		if !strings.EqualFold(name, r.Path[start+i].Name) {
+			return false
+		}
+	}
+	return true
+}
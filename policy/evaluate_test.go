@@ -0,0 +1,113 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package policy
+
+import (
+	"testing"
+)
+
+var evaluatePath = []Compartment{
+	{ID: "ocid1.tenancy.oc1..t", Name: "tenancy"},
+	{ID: "ocid1.compartment.oc1..a", Name: "A"},
+	{ID: "ocid1.compartment.oc1..b", Name: "B"},
+}
+
+func evaluateRequest(verb, resourceType string, depth int) *Request {
+	return &Request{
+		GroupName:    "Developers",
+		GroupID:      "ocid1.group.oc1..dev",
+		Verb:         verb,
+		ResourceType: resourceType,
+		Path:         evaluatePath[:depth+1],
+	}
+}
+
+// Statements should match by subject, verb, resource type and location,
+// including access inherited from parent compartments
+func TestEvaluate(t *testing.T) {
+	cases := []struct {
+		compartment int
+		statement   string
+		request     *Request
+		matches     bool
+	}{
+		// subjects
+		{0, "Allow group Developers to read instances in tenancy", evaluateRequest("read", "instances", 0), true},
+		{0, "Allow group developers to read instances in tenancy", evaluateRequest("read", "instances", 0), true},
+		{0, "Allow group Operators to read instances in tenancy", evaluateRequest("read", "instances", 0), false},
+		{0, "Allow group Operators, group id ocid1.group.oc1..dev to read instances in tenancy", evaluateRequest("read", "instances", 0), true},
+		{0, "Allow any-user to read instances in tenancy", evaluateRequest("read", "instances", 0), true},
+		{0, "Allow dynamic-group Developers to read instances in tenancy", evaluateRequest("read", "instances", 0), false},
+
+		// verbs imply the verbs before them
+		{0, "Allow group Developers to manage instances in tenancy", evaluateRequest("inspect", "instances", 0), true},
+		{0, "Allow group Developers to use instances in tenancy", evaluateRequest("read", "instances", 0), true},
+		{0, "Allow group Developers to read instances in tenancy", evaluateRequest("use", "instances", 0), false},
+		{0, "Allow group Developers to inspect instances in tenancy", evaluateRequest("manage", "instances", 0), false},
+
+		// resource types and families
+		{0, "Allow group Developers to read all-resources in tenancy", evaluateRequest("read", "buckets", 0), true},
+		{0, "Allow group Developers to read object-family in tenancy", evaluateRequest("read", "buckets", 0), true},
+		{0, "Allow group Developers to read object-family in tenancy", evaluateRequest("read", "object-family", 0), true},
+		{0, "Allow group Developers to read buckets in tenancy", evaluateRequest("read", "object-family", 0), false},
+		{0, "Allow group Developers to read volume-family in tenancy", evaluateRequest("read", "buckets", 0), false},
+
+		// locations and inheritance
+		{0, "Allow group Developers to read instances in tenancy", evaluateRequest("read", "instances", 2), true},
+		{0, "Allow group Developers to read instances in compartment A", evaluateRequest("read", "instances", 1), true},
+		{0, "Allow group Developers to read instances in compartment A", evaluateRequest("read", "instances", 2), true},
+		{0, "Allow group Developers to read instances in compartment A:B", evaluateRequest("read", "instances", 2), true},
+		{0, "Allow group Developers to read instances in compartment A:B", evaluateRequest("read", "instances", 1), false},
+		{0, "Allow group Developers to read instances in compartment B", evaluateRequest("read", "instances", 2), false},
+		{0, "Allow group Developers to read instances in compartment A", evaluateRequest("read", "instances", 0), false},
+		{1, "Allow group Developers to read instances in compartment B", evaluateRequest("read", "instances", 2), true},
+		{1, "Allow group Developers to read instances in compartment A", evaluateRequest("read", "instances", 2), true},
+		{1, "Allow group Developers to read instances in compartment A:B", evaluateRequest("read", "instances", 2), true},
+		{1, "Allow group Developers to read instances in tenancy", evaluateRequest("read", "instances", 2), false},
+		{2, "Allow group Developers to read instances in compartment B", evaluateRequest("read", "instances", 1), false},
+		{0, "Allow group Developers to read instances in compartment id ocid1.compartment.oc1..a", evaluateRequest("read", "instances", 2), true},
+		{0, "Allow group Developers to read instances in compartment id ocid1.compartment.oc1..b", evaluateRequest("read", "instances", 1), false},
+		{2, "Allow group Developers to read instances in compartment id ocid1.compartment.oc1..a", evaluateRequest("read", "instances", 2), false},
+
+		// statements which are not evaluated
+		{0, "Allow group Developers to {INSTANCE_READ} in tenancy", evaluateRequest("read", "instances", 0), false},
+		{0, "Endorse group Developers to read instances in any-tenancy", evaluateRequest("read", "instances", 0), false},
+		{0, "Allow group Developers to raed instances in tenancy", evaluateRequest("read", "instances", 0), false},
+	}
+
+	for _, c := range cases {
+		grant := Grant{CompartmentID: evaluatePath[c.compartment].ID, Statement: c.statement}
+		if matches := Evaluate(c.request, []Grant{grant}); (len(matches) == 1) != c.matches {
+			t.Errorf("Expected %q in %s to match %s %s in %s: %t", c.statement, evaluatePath[c.compartment].Name,
+				c.request.Verb, c.request.ResourceType, c.request.Path[len(c.request.Path)-1].Name, c.matches)
+		}
+	}
+}
+
+// Policies attached outside the requested compartment's path should be ignored
+func TestEvaluate_otherCompartments(t *testing.T) {
+	grant := Grant{CompartmentID: "ocid1.compartment.oc1..other", Statement: "Allow group Developers to read instances in compartment B"}
+	if matches := Evaluate(evaluateRequest("read", "instances", 2), []Grant{grant}); len(matches) != 0 {
+		t.Errorf("Expected no matches, got %v", matches)
+	}
+}
+
+// Conditional statements should be reported, but not allow access by themselves
+func TestAllowed(t *testing.T) {
+	grants := []Grant{
+		{PolicyID: "p1", CompartmentID: evaluatePath[0].ID, Statement: "Allow group Developers to read instances in tenancy where request.operation = 'GetInstance'"},
+		{PolicyID: "p2", CompartmentID: evaluatePath[0].ID, Statement: "Allow group Operators to read instances in tenancy"},
+	}
+	matches := Evaluate(evaluateRequest("read", "instances", 0), grants)
+	if len(matches) != 1 || matches[0].PolicyID != "p1" || !matches[0].Conditional {
+		t.Fatalf("Expected a conditional match of p1, got %v", matches)
+	}
+	if Allowed(matches) {
+		t.Errorf("Expected conditional matches not to allow access")
+	}
+
+	grants = append(grants, Grant{PolicyID: "p3", CompartmentID: evaluatePath[0].ID, Statement: "Allow group Developers to use instances in tenancy"})
+	if matches = Evaluate(evaluateRequest("read", "instances", 0), grants); !Allowed(matches) || len(matches) != 2 {
+		t.Errorf("Expected an unconditional match to allow access, got %v", matches)
+	}
+}
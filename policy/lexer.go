@@ -0,0 +1,78 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package policy
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokPattern
+	tokComma
+	tokLBrace
+	tokRBrace
+	tokOperator
+)
+
+// token is a lexeme of a statement. col is the 1-based column the token
+// starts at, used to point at the offending token in errors.
+type token struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+// lex splits a statement into tokens. Words run until whitespace or
+// punctuation; quoted strings and /patterns/ only appear in conditions.
+func lex(text string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokComma, ",", i + 1})
+			i++
+		case c == '{':
+			tokens = append(tokens, token{tokLBrace, "{", i + 1})
+			i++
+		case c == '}':
+			tokens = append(tokens, token{tokRBrace, "}", i + 1})
+			i++
+		case c == '=':
+			tokens = append(tokens, token{tokOperator, "=", i + 1})
+			i++
+		case c == '!':
+			if i+1 >= len(text) || text[i+1] != '=' {
+				return nil, &SyntaxError{Statement: text, Token: "!", Column: i + 1, Expected: "!="}
+			}
+			tokens = append(tokens, token{tokOperator, "!=", i + 1})
+			i += 2
+		case c == '\'' || c == '"' || c == '/':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				return nil, &SyntaxError{Statement: text, Token: text[i:], Column: i + 1, Expected: "closing " + string(c)}
+			}
+			kind := tokString
+			if c == '/' {
+				kind = tokPattern
+			}
+			tokens = append(tokens, token{kind, text[i+1 : i+1+end], i + 1})
+			i += end + 2
+		default:
+			start := i
+			for i < len(text) && !unicode.IsSpace(rune(text[i])) && !strings.ContainsRune(",{}=!'\"", rune(text[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, text[start:i], start + 1})
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(text) + 1}), nil
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package policy
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Names of groups, compartments and other identity resources. Nested
+	// compartments are named by path, e.g. "Parent:Child".
+	namePattern         = regexp.MustCompile(`^[\w.+@:-]+$`)
+	resourceTypePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	permissionPattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	variablePattern     = regexp.MustCompile(`^(request|target)(\.[\w-]+)+$`)
+)
+
+// Parse parses a single policy statement. Keywords, verbs, resource types
+// and permissions are case insensitive. The returned error is a
+// *SyntaxError naming the token that could not be parsed.
+func Parse(text string) (*Statement, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{text: text, tokens: tokens}
+	s, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok.kind != tokEOF {
+		return nil, p.unexpected(tok, "end of statement")
+	}
+	return s, nil
+}
+
+type parser struct {
+	text   string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) unexpected(tok token, expected string) error {
+	return &SyntaxError{Statement: p.text, Token: tok.text, Column: tok.col, Expected: expected}
+}
+
+// isKeyword reports whether tok is one of the given keywords.
+func isKeyword(tok token, keywords ...string) bool {
+	if tok.kind != tokWord {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(tok.text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyword consumes one of the given keywords, returning it in lower case.
+func (p *parser) keyword(keywords ...string) (string, error) {
+	tok := p.next()
+	if !isKeyword(tok, keywords...) {
+		return "", p.unexpected(tok, expectedList(keywords))
+	}
+	return strings.ToLower(tok.text), nil
+}
+
+// word consumes a word matching pattern, described by what in errors.
+func (p *parser) word(pattern *regexp.Regexp, what string) (string, error) {
+	tok := p.next()
+	if tok.kind != tokWord || !pattern.MatchString(tok.text) {
+		return "", p.unexpected(tok, what)
+	}
+	return tok.text, nil
+}
+
+// ocid consumes an OCID, used wherever a statement names a resource by id.
+func (p *parser) ocid() (string, error) {
+	tok := p.next()
+	if tok.kind != tokWord || !strings.HasPrefix(strings.ToLower(tok.text), "ocid1.") {
+		return "", p.unexpected(tok, "an OCID")
+	}
+	return tok.text, nil
+}
+
+func expectedList(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+func (p *parser) statement() (s *Statement, err error) {
+	s = &Statement{}
+	if s.Kind, err = p.keyword(Allow, Endorse, Admit, Define); err != nil {
+		return nil, err
+	}
+	if s.Kind == Define {
+		s.Definition, err = p.definition()
+		return s, err
+	}
+
+	if s.Subjects, err = p.subjects(); err != nil {
+		return nil, err
+	}
+	if s.Kind == Admit {
+		if _, err = p.keyword("of"); err != nil {
+			return nil, err
+		}
+		if _, err = p.keyword(LocationTenancy); err != nil {
+			return nil, err
+		}
+		if s.Tenancy, err = p.word(namePattern, "a tenancy alias"); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = p.keyword("to"); err != nil {
+		return nil, err
+	}
+	if err = p.access(s); err != nil {
+		return nil, err
+	}
+
+	if _, err = p.keyword("in"); err != nil {
+		return nil, err
+	}
+	if s.Location, err = p.location(s.Kind); err != nil {
+		return nil, err
+	}
+
+	if isKeyword(p.peek(), "where") {
+		p.next()
+		if s.Condition, err = p.condition(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (p *parser) definition() (d *Definition, err error) {
+	d = &Definition{}
+	if d.Kind, err = p.keyword(LocationTenancy, SubjectGroup, SubjectDynamicGroup); err != nil {
+		return nil, err
+	}
+	if d.Alias, err = p.word(namePattern, "an alias"); err != nil {
+		return nil, err
+	}
+	if _, err = p.keyword("as"); err != nil {
+		return nil, err
+	}
+	if d.OCID, err = p.ocid(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (p *parser) subjects() (subjects []Subject, err error) {
+	for {
+		subject := Subject{}
+		if subject.Kind, err = p.keyword(SubjectGroup, SubjectDynamicGroup, SubjectAnyUser, SubjectService); err != nil {
+			return nil, err
+		}
+		switch subject.Kind {
+		case SubjectGroup, SubjectDynamicGroup:
+			if isKeyword(p.peek(), "id") {
+				p.next()
+				subject.ByID = true
+				subject.Name, err = p.ocid()
+			} else {
+				subject.Name, err = p.word(namePattern, "a "+subject.Kind+" name")
+			}
+		case SubjectService:
+			subject.Name, err = p.word(namePattern, "a service name")
+		}
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, subject)
+
+		if p.peek().kind != tokComma {
+			return subjects, nil
+		}
+		p.next()
+	}
+}
+
+// access parses either a verb and resource type, or a permission list.
+func (p *parser) access(s *Statement) (err error) {
+	if p.peek().kind != tokLBrace {
+		if s.Verb, err = p.keyword(Verbs...); err != nil {
+			return err
+		}
+		tok := p.next()
+		if tok.kind != tokWord || !resourceTypePattern.MatchString(strings.ToLower(tok.text)) {
+			return p.unexpected(tok, "a resource type")
+		}
+		s.ResourceType = strings.ToLower(tok.text)
+		return nil
+	}
+
+	p.next()
+	s.Permissions = []string{}
+	for {
+		tok := p.next()
+		if tok.kind != tokWord || !permissionPattern.MatchString(strings.ToUpper(tok.text)) {
+			return p.unexpected(tok, "a permission")
+		}
+		s.Permissions = append(s.Permissions, strings.ToUpper(tok.text))
+
+		switch tok = p.next(); tok.kind {
+		case tokComma:
+		case tokRBrace:
+			return nil
+		default:
+			return p.unexpected(tok, ", or }")
+		}
+	}
+}
+
+// location parses where access is granted. Endorse statements grant access
+// in another tenancy, named by alias, or in any tenancy.
+func (p *parser) location(kind string) (l Location, err error) {
+	if kind == Endorse {
+		if l.Kind, err = p.keyword(LocationTenancy, LocationAnyTenancy); err != nil {
+			return l, err
+		}
+		if l.Kind == LocationTenancy {
+			l.Name, err = p.word(namePattern, "a tenancy alias")
+		}
+		return l, err
+	}
+
+	if l.Kind, err = p.keyword(LocationTenancy, LocationCompartment); err != nil {
+		return l, err
+	}
+	if l.Kind == LocationCompartment {
+		if isKeyword(p.peek(), "id") {
+			p.next()
+			l.ByID = true
+			l.Name, err = p.ocid()
+		} else {
+			l.Name, err = p.word(namePattern, "a compartment name")
+		}
+	}
+	return l, err
+}
+
+func (p *parser) condition() (c *Condition, err error) {
+	c = &Condition{}
+	if isKeyword(p.peek(), "all", "any") {
+		c.Match, _ = p.keyword("all", "any")
+		if tok := p.next(); tok.kind != tokLBrace {
+			return nil, p.unexpected(tok, "{")
+		}
+		for {
+			condition, err := p.condition()
+			if err != nil {
+				return nil, err
+			}
+			c.Conditions = append(c.Conditions, condition)
+
+			switch tok := p.next(); tok.kind {
+			case tokComma:
+			case tokRBrace:
+				return c, nil
+			default:
+				return nil, p.unexpected(tok, ", or }")
+			}
+		}
+	}
+
+	if c.Variable, err = p.word(variablePattern, "a request or target variable, all or any"); err != nil {
+		return nil, err
+	}
+
+	switch tok := p.next(); {
+	case tok.kind == tokOperator:
+		c.Operator = tok.text
+	case isKeyword(tok, "before", "after"):
+		c.Operator = strings.ToLower(tok.text)
+	default:
+		return nil, p.unexpected(tok, "=, !=, before or after")
+	}
+
+	switch tok := p.next(); {
+	case tok.kind == tokString:
+		c.Value = Value{ValueString, tok.text}
+	case tok.kind == tokPattern:
+		c.Value = Value{ValuePattern, tok.text}
+	case tok.kind == tokWord && variablePattern.MatchString(tok.text):
+		c.Value = Value{ValueVariable, tok.text}
+	default:
+		return nil, p.unexpected(tok, "a quoted value, /pattern/ or variable")
+	}
+	return c, nil
+}
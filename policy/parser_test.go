@@ -0,0 +1,174 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Valid statements should parse to their canonical form
+func TestParse_canonical(t *testing.T) {
+	cases := []struct {
+		text      string
+		canonical string
+	}{
+		{
+			"Allow group Admins to manage all-resources in tenancy",
+			"Allow group Admins to manage all-resources in tenancy",
+		},
+		{
+			"  allow GROUP Admins  to READ Instances IN Compartment Project-A ",
+			"Allow group Admins to read instances in compartment Project-A",
+		},
+		{
+			"Allow group A,group id ocid1.group.oc1..aaa , dynamic-group B to use volume-family in compartment Parent:Child",
+			"Allow group A, group id ocid1.group.oc1..aaa, dynamic-group B to use volume-family in compartment Parent:Child",
+		},
+		{
+			"Allow any-user to {volume_inspect,VOLUME_WRITE} in compartment id ocid1.compartment.oc1..aaa",
+			"Allow any-user to {VOLUME_INSPECT, VOLUME_WRITE} in compartment id ocid1.compartment.oc1..aaa",
+		},
+		{
+			"Allow service objectstorage-us-phoenix-1 to manage object-family in tenancy",
+			"Allow service objectstorage-us-phoenix-1 to manage object-family in tenancy",
+		},
+		{
+			"Allow group GroupAdmins to use users in tenancy where target.group.name!='Administrators'",
+			"Allow group GroupAdmins to use users in tenancy where target.group.name != 'Administrators'",
+		},
+		{
+			`allow group A to manage buckets in tenancy where ANY {request.permission="BUCKET_READ", all {target.bucket.name=/logs-*/, request.user.id = target.user.id}}`,
+			"Allow group A to manage buckets in tenancy where any {request.permission = 'BUCKET_READ', all {target.bucket.name = /logs-*/, request.user.id = target.user.id}}",
+		},
+		{
+			"Allow group A to read all-resources in tenancy where request.utc-timestamp before '2019-01-01T00:00Z'",
+			"Allow group A to read all-resources in tenancy where request.utc-timestamp before '2019-01-01T00:00Z'",
+		},
+		{
+			"define tenancy Acceptor as ocid1.tenancy.oc1..aaa",
+			"Define tenancy Acceptor as ocid1.tenancy.oc1..aaa",
+		},
+		{
+			"Endorse group NetworkAdmins to manage virtual-network-family in tenancy Acceptor",
+			"Endorse group NetworkAdmins to manage virtual-network-family in tenancy Acceptor",
+		},
+		{
+			"Endorse group A to read buckets in any-tenancy",
+			"Endorse group A to read buckets in any-tenancy",
+		},
+		{
+			"Admit group NetworkAdmins of tenancy Requestor to manage vcns in compartment Shared",
+			"Admit group NetworkAdmins of tenancy Requestor to manage vcns in compartment Shared",
+		},
+	}
+
+	for _, c := range cases {
+		s, err := Parse(c.text)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %s", c.text, err)
+			continue
+		}
+		if s.String() != c.canonical {
+			t.Errorf("Expected %q, got %q", c.canonical, s.String())
+		}
+		if reparsed, err := Parse(s.String()); err != nil || !reflect.DeepEqual(reparsed, s) {
+			t.Errorf("Expected canonical form %q to parse to the same statement, got %v", s.String(), err)
+		}
+	}
+}
+
+// Parsed statements should expose their parts
+func TestParse_parts(t *testing.T) {
+	s, err := Parse("Allow group A, dynamic-group id ocid1.dynamicgroup.oc1..aaa to read instances in compartment Dev where request.operation = 'GetInstance'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Statement{
+		Kind: Allow,
+		Subjects: []Subject{
+			{Kind: SubjectGroup, Name: "A"},
+			{Kind: SubjectDynamicGroup, ByID: true, Name: "ocid1.dynamicgroup.oc1..aaa"},
+		},
+		Verb:         "read",
+		ResourceType: "instances",
+		Location:     Location{Kind: LocationCompartment, Name: "Dev"},
+		Condition:    &Condition{Variable: "request.operation", Operator: "=", Value: Value{ValueString, "GetInstance"}},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, s)
+	}
+}
+
+// Invalid statements should report the offending token and its column
+func TestParse_errors(t *testing.T) {
+	cases := []struct {
+		text     string
+		token    string
+		column   int
+		expected string
+	}{
+		{"Permit group A to read instances in tenancy", "Permit", 1, "allow, endorse, admit or define"},
+		{"Allow group A to raed instances in tenancy", "raed", 18, "inspect, read, use or manage"},
+		{"Allow group A to read instances in >> compartment B", ">>", 36, "tenancy or compartment"},
+		{"Allow groups A to read instances in tenancy", "groups", 7, "group, dynamic-group, any-user or service"},
+		{"Allow group A read instances in tenancy", "read", 15, "to"},
+		{"Allow group id A to read instances in tenancy", "A", 16, "an OCID"},
+		{"Allow group A to read instances", "", 32, "in"},
+		{"Allow group A to read instances in tenancy B", "B", 44, "end of statement"},
+		{"Allow group A to {VOLUME_READ VOLUME_WRITE} in tenancy", "VOLUME_WRITE", 31, ", or }"},
+		{"Allow group A to read instances in tenancy where operation = 'x'", "operation", 50, "a request or target variable, all or any"},
+		{"Allow group A to read instances in tenancy where request.operation == 'x'", "=", 69, "a quoted value, /pattern/ or variable"},
+		{"Allow group A to read instances in tenancy where request.operation = 'x", "'x", 70, "closing '"},
+		{"Endorse group A to read buckets in compartment B", "compartment", 36, "tenancy or any-tenancy"},
+		{"Define tenancy A as B", "B", 21, "an OCID"},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.text)
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Expected a syntax error for %q, got %v", c.text, err)
+			continue
+		}
+		if syntaxErr.Statement != c.text || syntaxErr.Token != c.token || syntaxErr.Column != c.column || syntaxErr.Expected != c.expected {
+			t.Errorf("Expected %q at column %d, expected %s, got %s", c.token, c.column, c.expected, err)
+		}
+	}
+}
+
+func TestSyntaxError_message(t *testing.T) {
+	_, err := Parse("Allow group A to raed instances in tenancy")
+	expected := `invalid policy statement "Allow group A to raed instances in tenancy": unexpected "raed" at column 18, expected inspect, read, use or manage`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %s, got %v", expected, err)
+	}
+
+	_, err = Parse("Allow group A to read instances")
+	expected = `invalid policy statement "Allow group A to read instances": unexpected end of statement, expected in`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %s, got %v", expected, err)
+	}
+}
+
+// Statements should be compared by meaning, not by text
+func TestEquivalent(t *testing.T) {
+	cases := []struct {
+		a, b       string
+		equivalent bool
+	}{
+		{"Allow group A to read instances in tenancy", "allow  group A to READ instances in Tenancy", true},
+		{"Allow group A to read instances in tenancy where request.operation='x'", `Allow group A to read instances in tenancy where request.operation = "x"`, true},
+		{"Allow group A to read instances in tenancy", "Allow group a to read instances in tenancy", false},
+		{"Allow group A to read instances in tenancy", "Allow group A to use instances in tenancy", false},
+		{"Allow group A to read instances in tenancy where request.operation='x'", "Allow group A to read instances in tenancy where request.operation='X'", false},
+		{"not a statement", "not a statement", true},
+		{"not a statement", "not  a statement", false},
+	}
+
+	for _, c := range cases {
+		if Equivalent(c.a, c.b) != c.equivalent {
+			t.Errorf("Expected Equivalent(%q, %q) to be %t", c.a, c.b, c.equivalent)
+		}
+	}
+}
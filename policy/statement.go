@@ -0,0 +1,213 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+// Package policy parses statements written in the IAM policy language, so
+// they can be validated before they are sent to the service and compared by
+// meaning rather than by their exact text.
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Statement kinds, named by the keyword the statement starts with.
+const (
+	Allow   = "allow"
+	Endorse = "endorse"
+	Admit   = "admit"
+	Define  = "define"
+)
+
+// Subject kinds.
+const (
+	SubjectGroup        = "group"
+	SubjectDynamicGroup = "dynamic-group"
+	SubjectAnyUser      = "any-user"
+	SubjectService      = "service"
+)
+
+// Location kinds.
+const (
+	LocationTenancy     = "tenancy"
+	LocationCompartment = "compartment"
+	LocationAnyTenancy  = "any-tenancy"
+)
+
+// Value kinds of a condition's right hand side.
+const (
+	ValueString   = "string"
+	ValuePattern  = "pattern"
+	ValueVariable = "variable"
+)
+
+// Verbs in increasing order of the access they grant.
+var Verbs = []string{"inspect", "read", "use", "manage"}
+
+// Statement is a parsed policy statement.
+type Statement struct {
+	Kind     string
+	Subjects []Subject
+	// Tenancy is the alias of the tenancy the subjects of an Admit
+	// statement belong to.
+	Tenancy string
+	// Verb and ResourceType are set when access is granted by verb;
+	// Permissions is set instead when it is granted by a permission list.
+	Verb         string
+	ResourceType string
+	Permissions  []string
+	Location     Location
+	Condition    *Condition
+	// Definition is only set for Define statements.
+	Definition *Definition
+}
+
+// Subject is who a statement grants access to.
+type Subject struct {
+	Kind string
+	// ByID is set when the subject is named by OCID, e.g. "group id ocid1...".
+	ByID bool
+	Name string
+}
+
+// Location is where a statement grants access. Name is empty for the
+// statement's own tenancy and for any-tenancy.
+type Location struct {
+	Kind string
+	ByID bool
+	Name string
+}
+
+// Condition is either a single comparison, or a group of conditions of which
+// all or any must hold.
+type Condition struct {
+	// Match is "all" or "any" for a group, and empty for a comparison.
+	Match      string
+	Conditions []*Condition
+	Variable   string
+	Operator   string
+	Value      Value
+}
+
+// Value is the right hand side of a comparison.
+type Value struct {
+	Kind string
+	Text string
+}
+
+// Definition gives an alias to a tenancy, group or dynamic group OCID, for
+// use by Endorse and Admit statements.
+type Definition struct {
+	Kind  string
+	Alias string
+	OCID  string
+}
+
+// String returns the statement in canonical form: single spaced, with
+// keywords, verbs and resource types in lower case. Two statements with the
+// same meaning have the same canonical form.
+func (s *Statement) String() string {
+	parts := []string{strings.ToUpper(s.Kind[:1]) + s.Kind[1:]}
+	if s.Definition != nil {
+		parts = append(parts, s.Definition.Kind, s.Definition.Alias, "as", s.Definition.OCID)
+		return strings.Join(parts, " ")
+	}
+
+	subjects := make([]string, len(s.Subjects))
+	for i, subject := range s.Subjects {
+		subjects[i] = subject.String()
+	}
+	parts = append(parts, strings.Join(subjects, ", "))
+	if s.Tenancy != "" {
+		parts = append(parts, "of tenancy", s.Tenancy)
+	}
+
+	parts = append(parts, "to")
+	if s.Permissions != nil {
+		parts = append(parts, "{"+strings.Join(s.Permissions, ", ")+"}")
+	} else {
+		parts = append(parts, s.Verb, s.ResourceType)
+	}
+
+	parts = append(parts, "in", s.Location.String())
+	if s.Condition != nil {
+		parts = append(parts, "where", s.Condition.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s Subject) String() string {
+	return joinName(s.Kind, s.ByID, s.Name)
+}
+
+func (l Location) String() string {
+	return joinName(l.Kind, l.ByID, l.Name)
+}
+
+func joinName(kind string, byID bool, name string) string {
+	parts := []string{kind}
+	if byID {
+		parts = append(parts, "id")
+	}
+	if name != "" {
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (c *Condition) String() string {
+	if c.Match == "" {
+		return fmt.Sprintf("%s %s %s", c.Variable, c.Operator, c.Value)
+	}
+	conditions := make([]string, len(c.Conditions))
+	for i, condition := range c.Conditions {
+		conditions[i] = condition.String()
+	}
+	return c.Match + " {" + strings.Join(conditions, ", ") + "}"
+}
+
+func (v Value) String() string {
+	switch v.Kind {
+	case ValuePattern:
+		return "/" + v.Text + "/"
+	case ValueVariable:
+		return v.Text
+	}
+	if strings.Contains(v.Text, "'") {
+		return `"` + v.Text + `"`
+	}
+	return "'" + v.Text + "'"
+}
+
+// Equivalent reports whether two statements have the same meaning. Text
+// which does not parse is only equivalent to identical text.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	sa, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	sb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return sa.String() == sb.String()
+}
+
+// SyntaxError reports the statement that failed to parse and the token at
+// which parsing stopped. Token is empty at the end of the statement.
+type SyntaxError struct {
+	Statement string
+	Token     string
+	Column    int
+	Expected  string
+}
+
+func (e *SyntaxError) Error() string {
+	found := "end of statement"
+	if e.Token != "" {
+		found = fmt.Sprintf("%q at column %d", e.Token, e.Column)
+	}
+	return fmt.Sprintf("invalid policy statement %q: unexpected %s, expected %s", e.Statement, found, e.Expected)
+}
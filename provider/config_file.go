@@ -0,0 +1,165 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	defaultConfigFilePath    = "~/.oci/config"
+	defaultConfigFileProfile = "DEFAULT"
+)
+
+// configFileProfile is a profile of an OCI CLI config file. As in the CLI,
+// settings of the DEFAULT profile apply to every other profile unless they
+// are overridden.
+type configFileProfile struct {
+	path   string
+	name   string
+	values map[string]string
+}
+
+// loadConfigFileProfile reads the named profile from the config file at
+// path. Empty arguments select the CLI's defaults.
+func loadConfigFileProfile(path, name string) (*configFileProfile, error) {
+	if path == "" {
+		path = defaultConfigFilePath
+	}
+	if name == "" {
+		name = defaultConfigFileProfile
+	}
+
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the config file: %s", err)
+	}
+	defer f.Close()
+
+	profiles, err := parseConfigFile(f, expanded)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("The config file %s has no profile named %s", expanded, name)
+	}
+	for k, v := range profiles[defaultConfigFileProfile] {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+	return &configFileProfile{path: expanded, name: name, values: values}, nil
+}
+
+// parseConfigFile parses the INI format of OCI CLI config files into the
+// settings of each profile.
+func parseConfigFile(r io.Reader, path string) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+		default:
+			i := strings.Index(text, "=")
+			if i < 0 {
+				return nil, fmt.Errorf("The config file %s is malformed at line %d: expected key=value", path, line)
+			}
+			if current == nil {
+				return nil, fmt.Errorf("The config file %s is malformed at line %d: expected a [profile] before any settings", path, line)
+			}
+			current[strings.TrimSpace(text[:i])] = strings.TrimSpace(text[i+1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Could not read the config file %s: %s", path, err)
+	}
+	return profiles, nil
+}
+
+// fill sets setting from key in the profile if the provider configuration
+// left it empty, and records the profile as the setting's source.
+func (p *configFileProfile) fill(setting *string, key string, sources map[string]string, arg string) {
+	if *setting != "" {
+		return
+	}
+	if v := p.values[key]; v != "" {
+		*setting = v
+		sources[arg] = p.describe(key)
+		log.Printf("[DEBUG] Using %s from %s", arg, sources[arg])
+	}
+}
+
+// describe names a key of the profile, for error messages.
+func (p *configFileProfile) describe(key string) string {
+	return fmt.Sprintf("%s in profile %s of %s", key, p.name, p.path)
+}
+
+// missingSettingError reports a required setting which neither the provider
+// configuration nor the config file profile, if any, set.
+func missingSettingError(p *configFileProfile, arg, key, requiredFor string) error {
+	if p == nil {
+		return fmt.Errorf("%s is required%s", arg, requiredFor)
+	}
+	return fmt.Errorf("%s is required%s, and is not set in the provider configuration or as %s", arg, requiredFor, p.describe(key))
+}
+
+// settingEnvVars are the environment variables the provider's settings
+// default to, by setting.
+var settingEnvVars = map[string]string{
+	"tenancy_ocid":         "OCI_TENANCY_OCID",
+	"user_ocid":            "OCI_USER_OCID",
+	"fingerprint":          "OCI_FINGERPRINT",
+	"private_key":          "OCI_PRIVATE_KEY",
+	"private_key_path":     "OCI_PRIVATE_KEY_PATH",
+	"private_key_password": "OCI_PRIVATE_KEY_PASSWORD",
+	"region":               "OCI_REGION",
+}
+
+// recordEnvSources records the settings whose value is that of their
+// environment variable as read from it.
+func recordEnvSources(d *schema.ResourceData, sources map[string]string) {
+	for arg, env := range settingEnvVars {
+		if v := os.Getenv(env); v != "" && d.Get(arg) == v {
+			sources[arg] = "the " + env + " environment variable"
+		}
+	}
+}
+
+// settingSource names where a setting was read from.
+func settingSource(sources map[string]string, arg string) string {
+	if source, ok := sources[arg]; ok {
+		return source
+	}
+	return arg + " in the provider configuration"
+}
+
+// settingOrigin names where a setting was read from, without naming the
+// setting itself.
+func settingOrigin(sources map[string]string, arg string) string {
+	if source, ok := sources[arg]; ok {
+		return source
+	}
+	return "the provider configuration"
+}
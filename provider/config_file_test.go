@@ -0,0 +1,200 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	profiles, err := parseConfigFile(strings.NewReader(`
+# comments and blank lines are ignored
+[DEFAULT]
+user=ocid1.user.oc1..default
+region = us-phoenix-1
+
+; profiles may override the defaults
+[DEV]
+user = ocid1.user.oc1..dev
+key_file=~/.oci/dev.pem
+`), "config")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"DEFAULT": {"user": "ocid1.user.oc1..default", "region": "us-phoenix-1"},
+		"DEV":     {"user": "ocid1.user.oc1..dev", "key_file": "~/.oci/dev.pem"},
+	}, profiles)
+
+	_, err = parseConfigFile(strings.NewReader("[DEFAULT]\nuser\n"), "config")
+	assert.EqualError(t, err, "The config file config is malformed at line 2: expected key=value")
+
+	_, err = parseConfigFile(strings.NewReader("user=ocid1.user.oc1..default\n"), "config")
+	assert.EqualError(t, err, "The config file config is malformed at line 1: expected a [profile] before any settings")
+}
+
+// writeConfigFile writes an OCI CLI config file, and the private key it
+// refers to, to a temporary directory which the returned function removes.
+func writeConfigFile(t *testing.T, config string) (string, func()) {
+	dir, err := ioutil.TempDir("", "oci-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(dir, "key.pem")
+	if err = ioutil.WriteFile(keyPath, []byte(testPrivateKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config")
+	if err = ioutil.WriteFile(path, []byte(strings.Replace(config, "{{key_file}}", keyPath, -1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+var testConfigFile = `
+[DEFAULT]
+tenancy=` + testTenancyOCID + `
+region=us-ashburn-1
+
+[DEV]
+user=` + testUserOCID + `
+fingerprint=` + testKeyFingerPrint + `
+key_file={{key_file}}
+pass_phrase=password
+`
+
+func TestProviderConfigFromConfigFile(t *testing.T) {
+	path, cleanup := writeConfigFile(t, testConfigFile)
+	defer cleanup()
+
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("config_file_path", path)
+	d.Set("config_file_profile", "DEV")
+	// Settings of the provider configuration take precedence.
+	d.Set("region", "us-phoenix-1")
+
+	client, err := ProviderConfig(d)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "us-phoenix-1", client.(*OracleClients).region)
+}
+
+func TestProviderConfigFromConfigFileErrors(t *testing.T) {
+	path, cleanup := writeConfigFile(t, testConfigFile+`
+[BADKEY]
+user=`+testUserOCID+`
+fingerprint=`+testKeyFingerPrint+`
+key_file={{key_file}}
+pass_phrase=wrong
+
+[NOKEY]
+user=`+testUserOCID+`
+fingerprint=`+testKeyFingerPrint+`
+`)
+	defer cleanup()
+
+	configure := func(profile string, settings map[string]string) error {
+		r := &schema.Resource{
+			Schema: schemaMap(),
+		}
+		d := r.Data(nil)
+		d.SetId("tenancy_ocid")
+		d.Set("config_file_path", path)
+		d.Set("config_file_profile", profile)
+		for k, v := range settings {
+			d.Set(k, v)
+		}
+		_, err := ProviderConfig(d)
+		return err
+	}
+
+	err := configure("MISSING", nil)
+	assert.EqualError(t, err, "The config file "+path+" has no profile named MISSING")
+
+	err = configure("DEFAULT", nil)
+	assert.EqualError(t, err, "user_ocid is required for ApiKey authentication, and is not set in the provider configuration or as user in profile DEFAULT of "+path)
+
+	err = configure("NOKEY", nil)
+	assert.EqualError(t, err, "One of private_key or private_key_path is required, and key_file is not set in profile NOKEY of "+path)
+
+	err = configure("BADKEY", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Could not load the private key from "+filepath.Join(filepath.Dir(path), "key.pem")+" (key_file in profile BADKEY of "+path+")")
+	}
+
+	// A key from the provider configuration is not decrypted with the pass
+	// phrase of the profile.
+	err = configure("DEV", map[string]string{"private_key": testPrivateKey})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Could not load the private key from private_key in the provider configuration")
+	}
+}
+
+func TestProviderConfigFromEnvironmentErrors(t *testing.T) {
+	configure := func(env map[string]string) error {
+		for k, v := range env {
+			defer setEnv(k, v)()
+		}
+		d := schema.TestResourceDataRaw(t, schemaMap(), map[string]interface{}{
+			"tenancy_ocid": testTenancyOCID,
+			"user_ocid":    testUserOCID,
+			"fingerprint":  testKeyFingerPrint,
+		})
+		_, err := ProviderConfig(d)
+		return err
+	}
+
+	err := configure(map[string]string{"OCI_REGION": "us-phoenix-1", "OCI_PRIVATE_KEY_PATH": "/nonexistent/key.pem"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Could not load the private key from /nonexistent/key.pem (the OCI_PRIVATE_KEY_PATH environment variable)")
+	}
+
+	err = configure(map[string]string{"OCI_REGION": "us-phoenix-1", "OCI_PRIVATE_KEY": "not a key"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Could not load the private key from the OCI_PRIVATE_KEY environment variable")
+	}
+
+	err = configure(map[string]string{"OCI_REGION": "phx", "OCI_PRIVATE_KEY": testPrivateKey})
+	assert.EqualError(t, err, `region "phx" from the OCI_REGION environment variable is not a region name such as us-phoenix-1`)
+}
+
+func TestProviderConfigCredentialErrors(t *testing.T) {
+	path, cleanup := writeConfigFile(t, testConfigFile)
+	defer cleanup()
+	defer setEnv("OCI_USER_OCID", testUserOCID)()
+
+	d := schema.TestResourceDataRaw(t, schemaMap(), map[string]interface{}{
+		"config_file_path":    path,
+		"config_file_profile": "DEV",
+		"fingerprint":         testKeyFingerPrint,
+	})
+	clients, err := ProviderConfig(d)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	err = clients.(*OracleClients).explainError(&baremetal.Error{Status: "401", Code: "NotAuthenticated", Message: "Not authenticated"})
+	keyPath := filepath.Join(filepath.Dir(path), "key.pem")
+	assert.Contains(t, err.Error(), "; the request was signed with tenancy_ocid from tenancy in profile DEV of "+path+
+		", user_ocid from the OCI_USER_OCID environment variable, fingerprint from the provider configuration and the private key from "+
+		keyPath+" (key_file in profile DEV of "+path+")")
+
+	err = clients.(*OracleClients).explainError(errors.New("dial tcp: lookup identity.us-ashburn-1.oraclecloud.com: no such host"))
+	assert.Contains(t, err.Error(), "; region us-ashburn-1 was read from region in profile DEV of "+path)
+
+	err = clients.(*OracleClients).explainError(&baremetal.Error{Status: "404", Code: "NotAuthorizedOrNotFound"})
+	assert.NotContains(t, err.Error(), "signed with")
+}
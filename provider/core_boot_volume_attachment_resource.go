@@ -0,0 +1,151 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// BootVolumeAttachmentResource attaches a boot volume to a stopped instance
+// whose own boot volume has been detached.
+func BootVolumeAttachmentResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createBootVolumeAttachment,
+		Read:     readBootVolumeAttachment,
+		Delete:   deleteBootVolumeAttachment,
+		Schema: map[string]*schema.Schema{
+			"boot_volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createBootVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeAttachmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.CreateResource(d, sync)
+}
+
+func readBootVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeAttachmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+func deleteBootVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeAttachmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
+	return crud.DeleteResource(d, sync)
+}
+
+type BootVolumeAttachmentResourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.BootVolumeAttachment
+}
+
+func (s *BootVolumeAttachmentResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *BootVolumeAttachmentResourceCrud) CreatedPending() []string {
+	return []string{baremetal.ResourceAttaching}
+}
+
+func (s *BootVolumeAttachmentResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAttached}
+}
+
+func (s *BootVolumeAttachmentResourceCrud) DeletedPending() []string {
+	return []string{baremetal.ResourceDetaching}
+}
+
+func (s *BootVolumeAttachmentResourceCrud) DeletedTarget() []string {
+	return []string{baremetal.ResourceDetached}
+}
+
+func (s *BootVolumeAttachmentResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *BootVolumeAttachmentResourceCrud) Create() (e error) {
+	bootVolumeID := s.D.Get("boot_volume_id").(string)
+	instanceID := s.D.Get("instance_id").(string)
+
+	opts := &baremetal.CreateOptions{}
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		opts.DisplayName = displayName.(string)
+	}
+
+	s.Res, e = s.Client.AttachBootVolume(bootVolumeID, instanceID, opts)
+	return
+}
+
+func (s *BootVolumeAttachmentResourceCrud) Get() (e error) {
+	res, e := s.Client.GetBootVolumeAttachment(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *BootVolumeAttachmentResourceCrud) SetData() {
+	s.D.Set("availability_domain", s.Res.AvailabilityDomain)
+	s.D.Set("boot_volume_id", s.Res.BootVolumeID)
+	s.D.Set("compartment_id", s.Res.CompartmentID)
+	s.D.Set("display_name", s.Res.DisplayName)
+	s.D.Set("instance_id", s.Res.InstanceID)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *BootVolumeAttachmentResourceCrud) Delete() (e error) {
+	return s.Client.DetachBootVolume(s.D.Id(), nil)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+type ResourceCoreBootVolumeAttachmentTestSuite struct {
+	suite.Suite
+	Client       *sdk.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreBootVolumeAttachmentTestSuite) SetupTest() {
+	s.Client = GetTestProvider().client
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + subnetConfig + `
+	data "oci_core_images" "t" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "Oracle-Linux-7.4-2017.10.25-0"
+	}
+	resource "oci_core_instance" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-instance"
+		image = "${data.oci_core_images.t.images.0.id}"
+		shape = "VM.Standard1.1"
+		subnet_id = "${oci_core_subnet.WebSubnetAD1.id}"
+		state = "STOPPED"
+	}`
+	s.ResourceName = "oci_core_boot_volume_attachment.t"
+}
+
+// detachBootVolume detaches the boot volume an instance was launched with,
+// as is done before attaching a repaired boot volume.
+func (s *ResourceCoreBootVolumeAttachmentTestSuite) detachBootVolume(instanceID, availabilityDomain, compartmentID string) error {
+	opts := &sdk.ListBootVolumeAttachmentsOptions{}
+	opts.InstanceID = instanceID
+	list, err := s.Client.ListBootVolumeAttachments(availabilityDomain, compartmentID, opts)
+	if err != nil {
+		return err
+	}
+	if len(list.BootVolumeAttachments) != 1 {
+		return fmt.Errorf("Expected 1 boot volume attachment for instance %s, got %d", instanceID, len(list.BootVolumeAttachments))
+	}
+
+	attachmentID := list.BootVolumeAttachments[0].ID
+	if err = s.Client.DetachBootVolume(attachmentID, nil); err != nil {
+		return err
+	}
+	for i := 0; i < 60; i++ {
+		attachment, err := s.Client.GetBootVolumeAttachment(attachmentID)
+		if err != nil {
+			return err
+		}
+		if attachment.State == baremetal.ResourceDetached {
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("Boot volume attachment %s did not detach", attachmentID)
+}
+
+func (s *ResourceCoreBootVolumeAttachmentTestSuite) TestAccResourceCoreBootVolumeAttachment_basic() {
+	var bootVolumeID string
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		// A boot volume that is not attached when its instance terminates
+		// is kept.
+		CheckDestroy: func(ts *terraform.State) error {
+			if bootVolumeID == "" {
+				return nil
+			}
+			return s.Client.DeleteBootVolume(bootVolumeID, nil)
+		},
+		Steps: []resource.TestStep{
+			// stop the instance and detach its boot volume
+			{
+				Config: s.Config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_instance.t", "state", baremetal.ResourceStopped),
+					resource.TestCheckResourceAttrSet("oci_core_instance.t", "boot_volume_id"),
+					func(ts *terraform.State) (err error) {
+						instanceID, _ := fromInstanceState(ts, "oci_core_instance.t", "id")
+						availabilityDomain, _ := fromInstanceState(ts, "oci_core_instance.t", "availability_domain")
+						compartmentID, _ := fromInstanceState(ts, "oci_core_instance.t", "compartment_id")
+						if bootVolumeID, err = fromInstanceState(ts, "oci_core_instance.t", "boot_volume_id"); err != nil {
+							return err
+						}
+						return s.detachBootVolume(instanceID, availabilityDomain, compartmentID)
+					},
+				),
+			},
+			// verify create
+			{
+				Config: s.Config + `
+				resource "oci_core_boot_volume_attachment" "t" {
+					boot_volume_id = "${oci_core_instance.t.boot_volume_id}"
+					instance_id = "${oci_core_instance.t.id}"
+					display_name = "-tf-boot-volume-attachment"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "availability_domain"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "compartment_id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-boot-volume-attachment"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAttached),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "boot_volume_id", "oci_core_instance.t", "boot_volume_id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "instance_id", "oci_core_instance.t", "id"),
+				),
+			},
+			// verify resource import
+			{
+				Config: s.Config + `
+				resource "oci_core_boot_volume_attachment" "t" {
+					boot_volume_id = "${oci_core_instance.t.boot_volume_id}"
+					instance_id = "${oci_core_instance.t.id}"
+					display_name = "-tf-boot-volume-attachment"
+				}`,
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceCoreBootVolumeAttachmentTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreBootVolumeAttachmentTestSuite))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-oci/options"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func BootVolumeAttachmentDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readBootVolumeAttachments,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"availability_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"page": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"boot_volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"boot_volume_attachments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     BootVolumeAttachmentResource(),
+			},
+		},
+	}
+}
+
+func readBootVolumeAttachments(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeAttachmentDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type BootVolumeAttachmentDatasourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.ListBootVolumeAttachments
+}
+
+func (s *BootVolumeAttachmentDatasourceCrud) Get() (e error) {
+	availabilityDomain := s.D.Get("availability_domain").(string)
+	compartmentID := s.D.Get("compartment_id").(string)
+
+	opts := &sdk.ListBootVolumeAttachmentsOptions{}
+	options.SetListOptions(s.D, &opts.ListOptions)
+	if val, ok := s.D.GetOk("boot_volume_id"); ok {
+		opts.BootVolumeID = val.(string)
+	}
+	if val, ok := s.D.GetOk("instance_id"); ok {
+		opts.InstanceID = val.(string)
+	}
+
+	s.Res = &sdk.ListBootVolumeAttachments{
+		BootVolumeAttachments: []sdk.BootVolumeAttachment{},
+	}
+
+	for {
+		var list *sdk.ListBootVolumeAttachments
+		if list, e = s.Client.ListBootVolumeAttachments(availabilityDomain, compartmentID, opts); e != nil {
+			break
+		}
+
+		s.Res.BootVolumeAttachments = append(s.Res.BootVolumeAttachments, list.BootVolumeAttachments...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *BootVolumeAttachmentDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.BootVolumeAttachments {
+		res := map[string]interface{}{
+			"availability_domain": v.AvailabilityDomain,
+			"boot_volume_id":      v.BootVolumeID,
+			"compartment_id":      v.CompartmentID,
+			"display_name":        v.DisplayName,
+			"id":                  v.ID,
+			"instance_id":         v.InstanceID,
+			"state":               v.State,
+			"time_created":        v.TimeCreated.String(),
+		}
+		resources = append(resources, res)
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	if err := s.D.Set("boot_volume_attachments", resources); err != nil {
+		panic(err)
+	}
+
+	return
+}
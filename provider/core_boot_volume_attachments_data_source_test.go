@@ -0,0 +1,62 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceCoreBootVolumeAttachmentTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceCoreBootVolumeAttachmentTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + instanceConfig
+	s.ResourceName = "data.oci_core_boot_volume_attachments.t"
+}
+
+func (s *DatasourceCoreBootVolumeAttachmentTestSuite) TestAccDatasourceCoreBootVolumeAttachment_basic() {
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_core_boot_volume_attachments" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					instance_id = "${oci_core_instance.t.id}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "boot_volume_attachments.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "boot_volume_attachments.0.state", baremetal.ResourceAttached),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volume_attachments.0.id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volume_attachments.0.availability_domain"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volume_attachments.0.compartment_id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volume_attachments.0.time_created"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "boot_volume_attachments.0.boot_volume_id", "oci_core_instance.t", "boot_volume_id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "boot_volume_attachments.0.instance_id", "oci_core_instance.t", "id"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceCoreBootVolumeAttachmentTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceCoreBootVolumeAttachmentTestSuite))
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// bootVolumeSourceTypeBootVolume clones another boot volume.
+const bootVolumeSourceTypeBootVolume = "bootVolume"
+
+func BootVolumeResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createBootVolume,
+		Read:     readBootVolume,
+		Update:   updateBootVolume,
+		Delete:   deleteBootVolume,
+		Schema: map[string]*schema.Schema{
+			"availability_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"size_in_gbs": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			// source_details is required to create a boot volume. Boot
+			// volumes created by launching an instance have none and can be
+			// imported.
+			"source_details": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{bootVolumeSourceTypeBootVolume}, false),
+						},
+					},
+				},
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_in_mbs": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createBootVolume(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.CreateResource(d, sync)
+}
+
+func readBootVolume(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+func updateBootVolume(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteBootVolume(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeResourceCrud{}
+	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
+	return crud.DeleteResource(d, sync)
+}
+
+type BootVolumeResourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.BootVolume
+}
+
+func (s *BootVolumeResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *BootVolumeResourceCrud) CreatedPending() []string {
+	return []string{baremetal.ResourceProvisioning}
+}
+
+func (s *BootVolumeResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *BootVolumeResourceCrud) DeletedPending() []string {
+	return []string{baremetal.ResourceTerminating}
+}
+
+func (s *BootVolumeResourceCrud) DeletedTarget() []string {
+	return []string{baremetal.ResourceTerminated}
+}
+
+func (s *BootVolumeResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *BootVolumeResourceCrud) Create() (e error) {
+	availabilityDomain := s.D.Get("availability_domain").(string)
+	compartmentID := s.D.Get("compartment_id").(string)
+
+	sourceDetailsList, ok := s.D.GetOk("source_details")
+	if !ok {
+		return errors.New("source_details is required to create a boot volume, boot volumes of launched instances can be imported instead")
+	}
+	sdItem := sourceDetailsList.([]interface{})[0].(map[string]interface{})
+	sourceDetails := &baremetal.VolumeSourceDetails{
+		Id:   sdItem["id"].(string),
+		Type: sdItem["type"].(string),
+	}
+
+	opts := &sdk.CreateBootVolumeOptions{}
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		opts.DisplayName = displayName.(string)
+	}
+	if sizeInGBs, ok := s.D.GetOk("size_in_gbs"); ok {
+		opts.SizeInGBs = sizeInGBs.(int)
+	}
+
+	s.Res, e = s.Client.CreateBootVolume(availabilityDomain, compartmentID, sourceDetails, opts)
+	return
+}
+
+func (s *BootVolumeResourceCrud) Get() (e error) {
+	res, e := s.Client.GetBootVolume(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *BootVolumeResourceCrud) Update() (e error) {
+	opts := &baremetal.UpdateOptions{}
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		opts.DisplayName = displayName.(string)
+	}
+
+	s.Res, e = s.Client.UpdateBootVolume(s.D.Id(), opts)
+	return
+}
+
+func (s *BootVolumeResourceCrud) SetData() {
+	s.D.Set("availability_domain", s.Res.AvailabilityDomain)
+	s.D.Set("compartment_id", s.Res.CompartmentID)
+	s.D.Set("display_name", s.Res.DisplayName)
+	s.D.Set("image_id", s.Res.ImageID)
+	s.D.Set("size_in_gbs", s.Res.SizeInGBs)
+	s.D.Set("size_in_mbs", s.Res.SizeInMBs)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+
+	if sd := s.Res.SourceDetails; sd != nil {
+		s.D.Set("source_details", []interface{}{map[string]interface{}{
+			"id":   sd.Id,
+			"type": sd.Type,
+		}})
+	}
+}
+
+func (s *BootVolumeResourceCrud) Delete() (e error) {
+	return s.Client.DeleteBootVolume(s.D.Id(), nil)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+type ResourceCoreBootVolumeTestSuite struct {
+	suite.Suite
+	Client       *sdk.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreBootVolumeTestSuite) SetupTest() {
+	s.Client = GetTestProvider().client
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + subnetConfig + `
+	data "oci_core_images" "t" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "Oracle-Linux-7.4-2017.10.25-0"
+	}`
+	s.ResourceName = "oci_core_boot_volume.clone"
+}
+
+// preservedBootVolumeConfig finds the boot volume kept by terminating the
+// "-tf-preserved-instance" instance.
+const preservedBootVolumeConfig = `
+	data "oci_core_boot_volumes" "preserved" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		filter {
+			name = "display_name"
+			values = ["-tf-preserved-instance (Boot Volume)"]
+		}
+		filter {
+			name = "state"
+			values = ["AVAILABLE"]
+		}
+	}`
+
+const bootVolumeCloneConfig = `
+	resource "oci_core_boot_volume" "clone" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		display_name = "%s"
+		source_details {
+			type = "bootVolume"
+			id = "${data.oci_core_boot_volumes.preserved.boot_volumes.0.id}"
+		}
+	}`
+
+func (s *ResourceCoreBootVolumeTestSuite) TestAccResourceCoreBootVolume_basic() {
+	var preservedID, rescueBootVolumeID string
+	launchFromCloneConfig := s.Config + preservedBootVolumeConfig + fmt.Sprintf(bootVolumeCloneConfig, "-tf-boot-volume-clone-renamed") + `
+	resource "oci_core_instance" "t2" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-instance-from-boot-volume"
+		shape = "VM.Standard1.1"
+		subnet_id = "${oci_core_subnet.WebSubnetAD1.id}"
+		source_details {
+			source_type = "bootVolume"
+			source_id = "${oci_core_boot_volume.clone.id}"
+		}
+	}`
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		// The preserved boot volume is not managed by terraform, while the
+		// rescue instance's boot volume is deleted with the instance.
+		CheckDestroy: func(ts *terraform.State) error {
+			if rescueBootVolumeID != "" {
+				res, err := s.Client.GetBootVolume(rescueBootVolumeID)
+				if err == nil && res.State != baremetal.ResourceTerminating && res.State != baremetal.ResourceTerminated {
+					return fmt.Errorf("Expected boot volume %s to be deleted with its instance, got %s", rescueBootVolumeID, res.State)
+				}
+			}
+			if preservedID == "" {
+				return nil
+			}
+			return s.Client.DeleteBootVolume(preservedID, nil)
+		},
+		Steps: []resource.TestStep{
+			// verify an instance launched from an image reports its boot volume
+			{
+				Config: s.Config + `
+				resource "oci_core_instance" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-preserved-instance"
+					image = "${data.oci_core_images.t.images.0.id}"
+					shape = "VM.Standard1.1"
+					subnet_id = "${oci_core_subnet.WebSubnetAD1.id}"
+					preserve_boot_volume = true
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("oci_core_instance.t", "boot_volume_id"),
+					resource.TestCheckResourceAttr("oci_core_instance.t", "preserve_boot_volume", "true"),
+					resource.TestCheckResourceAttr("oci_core_instance.t", "source_details.#", "1"),
+					resource.TestCheckResourceAttr("oci_core_instance.t", "source_details.0.source_type", "image"),
+					resource.TestCheckResourceAttrPair("oci_core_instance.t", "source_details.0.source_id", "data.oci_core_images.t", "images.0.id"),
+					func(ts *terraform.State) (err error) {
+						preservedID, err = fromInstanceState(ts, "oci_core_instance.t", "boot_volume_id")
+						return err
+					},
+				),
+			},
+			// verify terminating the instance keeps its boot volume
+			{
+				Config: s.Config + preservedBootVolumeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.oci_core_boot_volumes.preserved", "boot_volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.oci_core_boot_volumes.preserved", "boot_volumes.0.state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrPair("data.oci_core_boot_volumes.preserved", "boot_volumes.0.image_id", "data.oci_core_images.t", "images.0.id"),
+					func(ts *terraform.State) error {
+						return resource.TestCheckResourceAttr("data.oci_core_boot_volumes.preserved", "boot_volumes.0.id", preservedID)(ts)
+					},
+				),
+			},
+			// verify the preserved boot volume can be attached to a rescue
+			// instance as a block volume, and cloned
+			{
+				Config: s.Config + preservedBootVolumeConfig + fmt.Sprintf(bootVolumeCloneConfig, "-tf-boot-volume-clone") + `
+				resource "oci_core_instance" "rescue" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-rescue-instance"
+					image = "${data.oci_core_images.t.images.0.id}"
+					shape = "VM.Standard1.1"
+					subnet_id = "${oci_core_subnet.WebSubnetAD1.id}"
+				}
+				resource "oci_core_volume_attachment" "rescue" {
+					attachment_type = "iscsi"
+					compartment_id = "${var.compartment_id}"
+					instance_id = "${oci_core_instance.rescue.id}"
+					volume_id = "${data.oci_core_boot_volumes.preserved.boot_volumes.0.id}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_volume_attachment.rescue", "state", baremetal.ResourceAttached),
+					resource.TestCheckResourceAttrPair("oci_core_volume_attachment.rescue", "volume_id", "data.oci_core_boot_volumes.preserved", "boot_volumes.0.id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-boot-volume-clone"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr(s.ResourceName, "source_details.0.type", "bootVolume"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "source_details.0.id", "data.oci_core_boot_volumes.preserved", "boot_volumes.0.id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "image_id", "data.oci_core_images.t", "images.0.id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "size_in_gbs", "data.oci_core_boot_volumes.preserved", "boot_volumes.0.size_in_gbs"),
+					func(ts *terraform.State) (err error) {
+						rescueBootVolumeID, err = fromInstanceState(ts, "oci_core_instance.rescue", "boot_volume_id")
+						return err
+					},
+				),
+			},
+			// verify an instance can launch from the clone, and the clone can
+			// be renamed in place
+			{
+				Config: launchFromCloneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-boot-volume-clone-renamed"),
+					resource.TestCheckResourceAttr("oci_core_instance.t2", "state", baremetal.ResourceRunning),
+					resource.TestCheckResourceAttr("oci_core_instance.t2", "source_details.0.source_type", "bootVolume"),
+					resource.TestCheckResourceAttrPair("oci_core_instance.t2", "source_details.0.source_id", s.ResourceName, "id"),
+					resource.TestCheckResourceAttrPair("oci_core_instance.t2", "boot_volume_id", s.ResourceName, "id"),
+					resource.TestCheckResourceAttrPair("oci_core_instance.t2", "image", "data.oci_core_images.t", "images.0.id"),
+				),
+			},
+			// verify resource import
+			{
+				Config:            launchFromCloneConfig,
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceCoreBootVolumeTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreBootVolumeTestSuite))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/options"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func BootVolumeDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readBootVolumes,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"availability_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"page": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"boot_volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     BootVolumeResource(),
+			},
+		},
+	}
+}
+
+func readBootVolumes(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &BootVolumeDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type BootVolumeDatasourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.ListBootVolumes
+}
+
+func (s *BootVolumeDatasourceCrud) Get() (e error) {
+	availabilityDomain := s.D.Get("availability_domain").(string)
+	compartmentID := s.D.Get("compartment_id").(string)
+
+	opts := &baremetal.ListOptions{}
+	options.SetListOptions(s.D, opts)
+
+	s.Res = &sdk.ListBootVolumes{BootVolumes: []sdk.BootVolume{}}
+
+	for {
+		var list *sdk.ListBootVolumes
+		if list, e = s.Client.ListBootVolumes(availabilityDomain, compartmentID, opts); e != nil {
+			break
+		}
+
+		s.Res.BootVolumes = append(s.Res.BootVolumes, list.BootVolumes...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *BootVolumeDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.BootVolumes {
+		res := map[string]interface{}{
+			"availability_domain": v.AvailabilityDomain,
+			"compartment_id":      v.CompartmentID,
+			"display_name":        v.DisplayName,
+			"id":                  v.ID,
+			"image_id":            v.ImageID,
+			"size_in_gbs":         v.SizeInGBs,
+			"size_in_mbs":         v.SizeInMBs,
+			"state":               v.State,
+			"time_created":        v.TimeCreated.String(),
+		}
+		if sd := v.SourceDetails; sd != nil {
+			res["source_details"] = []interface{}{map[string]interface{}{
+				"id":   sd.Id,
+				"type": sd.Type,
+			}}
+		}
+		resources = append(resources, res)
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	if err := s.D.Set("boot_volumes", resources); err != nil {
+		panic(err)
+	}
+
+	return
+}
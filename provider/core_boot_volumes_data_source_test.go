@@ -0,0 +1,66 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceCoreBootVolumeTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceCoreBootVolumeTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + instanceConfig
+	s.ResourceName = "data.oci_core_boot_volumes.t"
+}
+
+func (s *DatasourceCoreBootVolumeTestSuite) TestAccDatasourceCoreBootVolume_basic() {
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_core_boot_volumes" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					filter {
+						name = "id"
+						values = ["${oci_core_instance.t.boot_volume_id}"]
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "boot_volumes.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "boot_volumes.0.state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volumes.0.availability_domain"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volumes.0.display_name"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volumes.0.size_in_gbs"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volumes.0.size_in_mbs"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volumes.0.time_created"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "boot_volumes.0.id", "oci_core_instance.t", "boot_volume_id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "boot_volumes.0.image_id", "oci_core_instance.t", "image"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceCoreBootVolumeTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceCoreBootVolumeTestSuite))
+}
@@ -29,7 +29,7 @@ func (s *ResourceCoreConsoleHistoryTestSuite) SetupTest() {
 	s.Providers = testAccProviders
 	s.Config = testProviderConfig() + instanceConfig
 
-	p := s.Provider.(*schema.Provider)
+	p := s.Provider.(*ociProvider)
 	res := p.ResourcesMap["oci_core_console_history"]
 	res.Delete = func(d *schema.ResourceData, m interface{}) (e error) {
 		return nil
@@ -0,0 +1,160 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// ImageExportResource exports an image to an Object Storage object.
+// Destroying the resource leaves the exported object in its bucket.
+func ImageExportResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: crud.DefaultTimeout,
+		Create:   createImageExport,
+		Read:     readImageExport,
+		Delete:   deleteImageExport,
+		Schema: map[string]*schema.Schema{
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					sdk.ImageSourceTypeObjectStorageTuple,
+					sdk.ImageSourceTypeObjectStorageURI,
+				}, false),
+			},
+			"destination_uri": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"namespace_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"bucket_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"object_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createImageExport(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &ImageExportResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	if e = crud.CreateResource(d, sync); e != nil {
+		return imageStateError(sync.Res, "export", e)
+	}
+	return
+}
+
+func readImageExport(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &ImageExportResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.ReadResource(sync)
+}
+
+func deleteImageExport(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &ImageExportResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.DeleteResource(d, sync)
+}
+
+// ImageExportResourceCrud tracks the exported image, which is EXPORTING
+// until the export completes.
+type ImageExportResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.Image
+}
+
+func (s *ImageExportResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *ImageExportResourceCrud) CreatedPending() []string {
+	return []string{sdk.ResourceExporting}
+}
+
+func (s *ImageExportResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *ImageExportResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *ImageExportResourceCrud) Create() (e error) {
+	details := &sdk.ExportImageDetails{
+		BucketName:      s.D.Get("bucket_name").(string),
+		DestinationType: s.D.Get("destination_type").(string),
+		DestinationURI:  s.D.Get("destination_uri").(string),
+		NamespaceName:   s.D.Get("namespace_name").(string),
+		ObjectName:      s.D.Get("object_name").(string),
+	}
+
+	switch details.DestinationType {
+	case sdk.ImageSourceTypeObjectStorageTuple:
+		if details.NamespaceName == "" || details.BucketName == "" || details.ObjectName == "" {
+			return fmt.Errorf("namespace_name, bucket_name and object_name are required for destination_type %s", details.DestinationType)
+		}
+	case sdk.ImageSourceTypeObjectStorageURI:
+		if details.DestinationURI == "" {
+			return fmt.Errorf("destination_uri is required for destination_type %s", details.DestinationType)
+		}
+	}
+
+	s.Res, e = s.Client.ExportImage(s.D.Get("image_id").(string), details, nil)
+	return
+}
+
+func (s *ImageExportResourceCrud) Get() (e error) {
+	res, e := s.Client.GetImage(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *ImageExportResourceCrud) SetData() {
+	s.D.Set("image_id", s.Res.ID)
+	s.D.Set("state", s.Res.State)
+}
+
+// Delete leaves the exported object in its bucket; only the resource is
+// removed from state.
+func (s *ImageExportResourceCrud) Delete() (e error) {
+	return
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceCoreImageExportTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+	Token        string
+	TokenFn      func(string, map[string]string) string
+}
+
+func (s *ResourceCoreImageExportTestSuite) SetupTest() {
+	s.Token, s.TokenFn = tokenize()
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + instanceConfig + s.TokenFn(`
+	data "oci_objectstorage_namespace" "t" {
+	}
+
+	resource "oci_objectstorage_bucket" "t" {
+		compartment_id = "${var.compartment_id}"
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		name = "{{.token}}"
+	}
+
+	resource "oci_core_image" "t" {
+		compartment_id = "${var.compartment_id}"
+		instance_id = "${oci_core_instance.t.id}"
+		display_name = "-tf-image"
+	}
+
+	resource "oci_core_image_export" "t" {
+		image_id = "${oci_core_image.t.id}"
+		destination_type = "objectStorageTuple"
+		namespace_name = "${data.oci_objectstorage_namespace.t.namespace}"
+		bucket_name = "${oci_objectstorage_bucket.t.name}"
+		object_name = "-tf-image-export"
+	}`, nil)
+	s.ResourceName = "oci_core_image_export.t"
+}
+
+func (s *ResourceCoreImageExportTestSuite) TestAccResourceCoreImageExport_basic() {
+	parConfig := s.Config + `
+	resource "oci_objectstorage_preauthrequest" "t" {
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		bucket = "${oci_objectstorage_bucket.t.name}"
+		name = "-tf-image-par"
+		object = "-tf-image-export-by-uri"
+		access_type = "ObjectReadWrite"
+		time_expires = "2030-11-10T23:00:00Z"
+	}
+
+	resource "oci_core_image_export" "uri" {
+		image_id = "${oci_core_image.t.id}"
+		destination_type = "objectStorageUri"
+		destination_uri = "https://objectstorage.us-phoenix-1.oraclecloud.com${oci_objectstorage_preauthrequest.t.access_uri}"
+	}
+
+	resource "oci_core_image" "imported_by_uri" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-image-imported-by-uri"
+		image_source_details {
+			source_type = "objectStorageUri"
+			source_uri = "${oci_core_image_export.uri.destination_uri}"
+			source_image_type = "QCOW2"
+		}
+	}`
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify export to a bucket and import of the exported object
+			{
+				Config: s.Config + `
+				resource "oci_core_image" "imported" {
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-image-imported"
+					image_source_details {
+						source_type = "objectStorageTuple"
+						namespace_name = "${oci_core_image_export.t.namespace_name}"
+						bucket_name = "${oci_core_image_export.t.bucket_name}"
+						object_name = "${oci_core_image_export.t.object_name}"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "oci_core_image.t", "id"),
+					func(ts *terraform.State) error {
+						namespace, err := fromInstanceState(ts, "data.oci_objectstorage_namespace.t", "namespace")
+						if err != nil {
+							return err
+						}
+						_, err = s.Client.HeadObject(baremetal.Namespace(namespace), s.Token, "-tf-image-export", nil)
+						return err
+					},
+					resource.TestCheckResourceAttr("oci_core_image.imported", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("oci_core_image.imported", "display_name", "-tf-image-imported"),
+					resource.TestCheckResourceAttr("oci_core_image.imported", "base_image_id", ""),
+					resource.TestCheckResourceAttrPair("oci_core_image.imported", "operating_system", "oci_core_image.t", "operating_system"),
+					resource.TestCheckResourceAttrPair("oci_core_image.imported", "operating_system_version", "oci_core_image.t", "operating_system_version"),
+				),
+			},
+			// verify export and import through a pre-authenticated request
+			{
+				Config: parConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_image_export.uri", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("oci_core_image.imported_by_uri", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrPair("oci_core_image.imported_by_uri", "operating_system", "oci_core_image.t", "operating_system"),
+					// The exported objects are not managed by terraform, and
+					// must be removed before the bucket can be destroyed.
+					func(ts *terraform.State) error {
+						namespace, err := fromInstanceState(ts, "data.oci_objectstorage_namespace.t", "namespace")
+						if err != nil {
+							return err
+						}
+						for _, name := range []string{"-tf-image-export", "-tf-image-export-by-uri"} {
+							if _, err = s.Client.DeleteObject(baremetal.Namespace(namespace), s.Token, name, nil); err != nil {
+								return err
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceCoreImageExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreImageExportTestSuite))
+}
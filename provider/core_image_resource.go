@@ -3,10 +3,15 @@
 package provider
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func ImageResource() *schema.Resource {
@@ -42,10 +47,63 @@ func ImageResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// Images are captured from instance_id or imported from the
+			// Object Storage object named by image_source_details.
 			"instance_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image_source_details"},
+			},
+			"image_source_details": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"instance_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								sdk.ImageSourceTypeObjectStorageTuple,
+								sdk.ImageSourceTypeObjectStorageURI,
+							}, false),
+						},
+						"source_uri": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"namespace_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"bucket_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"object_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"source_image_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								sdk.ImageTypeQCOW2,
+								sdk.ImageTypeVMDK,
+							}, false),
+						},
+					},
+				},
 			},
 			"state": {
 				Type:     schema.TypeString,
@@ -72,7 +130,19 @@ func createImage(d *schema.ResourceData, m interface{}) (e error) {
 	sync := &ImageResourceCrud{}
 	sync.D = d
 	sync.Client = client.client
-	return crud.CreateResource(d, sync)
+	if e = crud.CreateResource(d, sync); e != nil {
+		return imageStateError(sync.Res, "creation", e)
+	}
+	return
+}
+
+// imageStateError explains an image creation, import or export which left
+// the image DISABLED or DELETED rather than AVAILABLE.
+func imageStateError(res *baremetal.Image, operation string, e error) error {
+	if res != nil && (res.State == baremetal.ResourceDisabled || res.State == baremetal.ResourceDeleted) {
+		return fmt.Errorf("Image %s %s failed, state %s", res.ID, operation, res.State)
+	}
+	return e
 }
 
 func readImage(d *schema.ResourceData, m interface{}) (e error) {
@@ -109,7 +179,10 @@ func (s *ImageResourceCrud) ID() string {
 }
 
 func (s *ImageResourceCrud) CreatedPending() []string {
-	return []string{baremetal.ResourceProvisioning}
+	return []string{
+		baremetal.ResourceProvisioning,
+		sdk.ResourceImporting,
+	}
 }
 
 func (s *ImageResourceCrud) CreatedTarget() []string {
@@ -132,17 +205,53 @@ func (s *ImageResourceCrud) Create() (e error) {
 	compartmentID := s.D.Get("compartment_id").(string)
 	instanceID := s.D.Get("instance_id").(string)
 
-	opts := &baremetal.CreateOptions{}
+	opts := &sdk.CreateImageOptions{}
 	displayName, ok := s.D.GetOk("display_name")
 	if ok {
 		opts.DisplayName = displayName.(string)
 	}
+	if opts.ImageSourceDetails, e = s.imageSourceDetails(); e != nil {
+		return
+	}
+	if instanceID == "" && opts.ImageSourceDetails == nil {
+		return errors.New("one of instance_id or image_source_details must be set")
+	}
 
 	s.Res, e = s.Client.CreateImage(compartmentID, instanceID, opts)
 
 	return
 }
 
+// imageSourceDetails reads the Object Storage object to import the image
+// from, checking the fields required by its source type are set.
+func (s *ImageResourceCrud) imageSourceDetails() (*sdk.ImageSourceDetails, error) {
+	rawSourceDetails, ok := s.D.GetOk("image_source_details")
+	if !ok {
+		return nil, nil
+	}
+	sourceDetails := rawSourceDetails.([]interface{})[0].(map[string]interface{})
+	res := &sdk.ImageSourceDetails{
+		BucketName:      sourceDetails["bucket_name"].(string),
+		NamespaceName:   sourceDetails["namespace_name"].(string),
+		ObjectName:      sourceDetails["object_name"].(string),
+		SourceImageType: sourceDetails["source_image_type"].(string),
+		SourceType:      sourceDetails["source_type"].(string),
+		SourceURI:       sourceDetails["source_uri"].(string),
+	}
+
+	switch res.SourceType {
+	case sdk.ImageSourceTypeObjectStorageTuple:
+		if res.NamespaceName == "" || res.BucketName == "" || res.ObjectName == "" {
+			return nil, fmt.Errorf("namespace_name, bucket_name and object_name are required for source_type %s", res.SourceType)
+		}
+	case sdk.ImageSourceTypeObjectStorageURI:
+		if res.SourceURI == "" {
+			return nil, fmt.Errorf("source_uri is required for source_type %s", res.SourceType)
+		}
+	}
+	return res, nil
+}
+
 func (s *ImageResourceCrud) Get() (e error) {
 	res, e := s.Client.GetImage(s.D.Id())
 	if e == nil {
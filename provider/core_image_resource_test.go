@@ -3,6 +3,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -72,6 +73,52 @@ func (s *ResourceCoreImageTestSuite) TestAccResourceCoreImage_basic() {
 	})
 }
 
+func (s *ResourceCoreImageTestSuite) TestAccResourceCoreImage_importFailure() {
+	_, tokenFn := tokenize()
+	config := testProviderConfig() + tokenFn(`
+	data "oci_objectstorage_namespace" "t" {
+	}
+
+	resource "oci_objectstorage_bucket" "t" {
+		compartment_id = "${var.compartment_id}"
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		name = "{{.token}}"
+	}`, nil)
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify the fields required by the source type are checked
+			{
+				Config: config + `
+				resource "oci_core_image" "t" {
+					compartment_id = "${var.compartment_id}"
+					image_source_details {
+						source_type = "objectStorageUri"
+						bucket_name = "${oci_objectstorage_bucket.t.name}"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("source_uri is required for source_type objectStorageUri"),
+			},
+			// verify an import of a missing object reports the failed state
+			{
+				Config: config + `
+				resource "oci_core_image" "t" {
+					compartment_id = "${var.compartment_id}"
+					image_source_details {
+						source_type = "objectStorageTuple"
+						namespace_name = "${data.oci_objectstorage_namespace.t.namespace}"
+						bucket_name = "${oci_objectstorage_bucket.t.name}"
+						object_name = "-tf-missing-image"
+						source_image_type = "VMDK"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("Image .* creation failed, state DELETED"),
+			},
+		},
+	})
+}
+
 func TestResourceCoreImageTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreImageTestSuite))
 }
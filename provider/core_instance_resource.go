@@ -8,10 +8,12 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"github.com/oracle/terraform-provider-oci/crud"
 	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func InstanceResource() *schema.Resource {
@@ -55,19 +57,61 @@ func InstanceResource() *schema.Resource {
 				Computed: true,
 			},
 			"hostname_label": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateHostnameLabel,
 			},
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// Instances launch from either image or source_details, which can
+			// also name an existing boot volume.
 			"image": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_details"},
+			},
+			"source_details": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								sdk.InstanceSourceTypeImage,
+								sdk.InstanceSourceTypeBootVolume,
+							}, false),
+						},
+						"source_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			// preserve_boot_volume keeps the boot volume when the instance is
+			// terminated so it can be attached elsewhere or launched again.
+			"preserve_boot_volume": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"boot_volume_id": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"ipxe_script": {
 				Type:     schema.TypeString,
@@ -86,18 +130,21 @@ func InstanceResource() *schema.Resource {
 				ForceNew: true,
 				Elem:     schema.TypeString,
 			},
-			"region": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
 			"shape": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			// state may be set to RUNNING or STOPPED to start or stop the
+			// instance. Other lifecycle states are reported but cannot be set.
 			"state": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					baremetal.ResourceRunning,
+					baremetal.ResourceStopped,
+				}, false),
 			},
 			"subnet_id": {
 				Type:     schema.TypeString,
@@ -125,7 +172,23 @@ func createInstance(d *schema.ResourceData, m interface{}) (e error) {
 	sync := &InstanceResourceCrud{}
 	sync.D = d
 	sync.Client = m.(*OracleClients).client
-	return crud.CreateResource(d, sync)
+
+	// Instances always launch running, so a desired STOPPED state is applied
+	// once the launch completes.
+	desiredState := d.Get("state").(string)
+	if e = crud.CreateResource(d, sync); e != nil || desiredState != baremetal.ResourceStopped {
+		return
+	}
+
+	d.Set("state", desiredState)
+	if e = sync.updatePowerState(); e != nil {
+		return
+	}
+	if e = crud.WaitForUpdatedState(d, sync); e != nil {
+		return
+	}
+	sync.SetData()
+	return
 }
 
 func readInstance(d *schema.ResourceData, m interface{}) (e error) {
@@ -151,7 +214,7 @@ func deleteInstance(d *schema.ResourceData, m interface{}) (e error) {
 
 type InstanceResourceCrud struct {
 	crud.BaseCrud
-	Resource *baremetal.Instance
+	Resource *sdk.Instance
 
 	// Computed fields
 	public_ip  string
@@ -173,6 +236,20 @@ func (s *InstanceResourceCrud) CreatedTarget() []string {
 	return []string{baremetal.ResourceRunning}
 }
 
+func (s *InstanceResourceCrud) UpdatedPending() []string {
+	return []string{
+		baremetal.ResourceStarting,
+		baremetal.ResourceStopping,
+	}
+}
+
+func (s *InstanceResourceCrud) UpdatedTarget() []string {
+	return []string{
+		baremetal.ResourceRunning,
+		baremetal.ResourceStopped,
+	}
+}
+
 func (s *InstanceResourceCrud) DeletedPending() []string {
 	return []string{baremetal.ResourceTerminating}
 }
@@ -210,7 +287,21 @@ func (s *InstanceResourceCrud) Create() (e error) {
 	shape := s.D.Get("shape").(string)
 	subnet := s.D.Get("subnet_id").(string)
 
-	opts := &baremetal.LaunchInstanceOptions{}
+	opts := &sdk.LaunchInstanceOptions{}
+	if rawSource, ok := s.D.GetOk("source_details"); ok {
+		source := rawSource.([]interface{})[0].(map[string]interface{})
+		opts.SourceDetails = &sdk.InstanceSourceDetails{
+			SourceType: source["source_type"].(string),
+		}
+		switch opts.SourceDetails.SourceType {
+		case sdk.InstanceSourceTypeBootVolume:
+			opts.SourceDetails.BootVolumeID = source["source_id"].(string)
+		default:
+			opts.SourceDetails.ImageID = source["source_id"].(string)
+		}
+	} else if image == "" {
+		return errors.New("one of image or source_details must be set")
+	}
 	if displayName, ok := s.D.GetOk("display_name"); ok {
 		opts.DisplayName = displayName.(string)
 	}
@@ -291,6 +382,31 @@ func (s *InstanceResourceCrud) getPrimaryVnic() (vnic *baremetal.Vnic, e error)
 	return nil, errors.New("Primary VNIC not found.")
 }
 
+// getBootVolumeAttachment returns the attachment of the volume this instance
+// boots from.
+func (s *InstanceResourceCrud) getBootVolumeAttachment() (attachment *sdk.BootVolumeAttachment, e error) {
+	opts := &sdk.ListBootVolumeAttachmentsOptions{}
+	opts.InstanceID = s.Resource.ID
+
+	for {
+		var result *sdk.ListBootVolumeAttachments
+		if result, e = s.Client.ListBootVolumeAttachments(s.Resource.AvailabilityDomain, s.Resource.CompartmentID, opts); e != nil {
+			return
+		}
+
+		for _, a := range result.BootVolumeAttachments {
+			if a.State == baremetal.ResourceAttaching || a.State == baremetal.ResourceAttached {
+				return &a, nil
+			}
+		}
+		if hasNextPage := options.SetNextPageOption(result.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return nil, errors.New("No attached boot volume found.")
+}
+
 func (s *InstanceResourceCrud) Get() (e error) {
 	res, e := s.Client.GetInstance(s.D.Id())
 	if e == nil {
@@ -311,6 +427,10 @@ func (s *InstanceResourceCrud) Update() (e error) {
 		return
 	}
 
+	if e = s.updatePowerState(); e != nil {
+		return
+	}
+
 	// HasChange returns true for any changes within create_vnic_details.
 	if !s.D.HasChange("create_vnic_details") {
 		log.Printf("[DEBUG] No changes to primary VNIC. Instance ID: %q", s.Resource.ID)
@@ -331,6 +451,38 @@ func (s *InstanceResourceCrud) Update() (e error) {
 	return
 }
 
+// updatePowerState starts or stops the instance when the desired state
+// differs from the current one. The caller waits for the transition through
+// UpdatedPending and UpdatedTarget.
+func (s *InstanceResourceCrud) updatePowerState() (e error) {
+	desiredState, ok := s.D.GetOk("state")
+	if !ok || desiredState.(string) == s.Resource.State {
+		return
+	}
+
+	// An instance already moving towards the desired state only needs to
+	// be waited on.
+	var action baremetal.InstanceActions
+	switch desiredState.(string) {
+	case baremetal.ResourceRunning:
+		if s.Resource.State == baremetal.ResourceStarting {
+			return
+		}
+		action = sdk.InstanceActionStart
+	case baremetal.ResourceStopped:
+		if s.Resource.State == baremetal.ResourceStopping {
+			return
+		}
+		action = sdk.InstanceActionStop
+	default:
+		return
+	}
+
+	log.Printf("[DEBUG] Changing instance power state. Instance ID: %q, Action: %q", s.Resource.ID, action)
+	s.Resource, e = s.Client.InstanceAction(s.D.Id(), action, nil)
+	return
+}
+
 func (s *InstanceResourceCrud) SetData() {
 	s.D.Set("availability_domain", s.Resource.AvailabilityDomain)
 	s.D.Set("compartment_id", s.Resource.CompartmentID)
@@ -338,11 +490,31 @@ func (s *InstanceResourceCrud) SetData() {
 	s.D.Set("image", s.Resource.ImageID)
 	s.D.Set("ipxe_script", s.Resource.IpxeScript)
 	s.D.Set("metadata", s.Resource.Metadata)
-	s.D.Set("region", s.Resource.Region)
 	s.D.Set("shape", s.Resource.Shape)
 	s.D.Set("state", s.Resource.State)
 	s.D.Set("time_created", s.Resource.TimeCreated.String())
 
+	if source := s.Resource.SourceDetails; source != nil {
+		sourceID := source.ImageID
+		if source.SourceType == sdk.InstanceSourceTypeBootVolume {
+			sourceID = source.BootVolumeID
+		}
+		s.D.Set("source_details", []map[string]interface{}{{
+			"source_type": source.SourceType,
+			"source_id":   sourceID,
+		}})
+	}
+
+	if s.Resource.State != baremetal.ResourceRunning && s.Resource.State != baremetal.ResourceStopped {
+		return
+	}
+
+	if attachment, e := s.getBootVolumeAttachment(); e != nil {
+		log.Printf("[WARN] Boot volume attachment could not be found during instance refresh: %q (Instance ID: %q, State: %q)", e, s.Resource.ID, s.Resource.State)
+	} else {
+		s.D.Set("boot_volume_id", attachment.BootVolumeID)
+	}
+
 	if s.Resource.State != baremetal.ResourceRunning {
 		return
 	}
@@ -362,5 +534,7 @@ func (s *InstanceResourceCrud) SetData() {
 }
 
 func (s *InstanceResourceCrud) Delete() (e error) {
-	return s.Client.TerminateInstance(s.D.Id(), nil)
+	opts := &sdk.TerminateInstanceOptions{}
+	opts.PreserveBootVolume = s.D.Get("preserve_boot_volume").(bool)
+	return s.Client.TerminateInstance(s.D.Id(), opts)
 }
@@ -98,6 +98,9 @@ func (s *ResourceCoreInstanceTestSuite) TestAccResourceCoreInstance_basic() {
 					resource.TestCheckResourceAttrSet(s.ResourceName, "public_ip"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "private_ip"),
 					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceRunning),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "boot_volume_id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "preserve_boot_volume", "false"),
+					resource.TestCheckResourceAttr(s.ResourceName, "source_details.0.source_type", "image"),
 					func(ts *terraform.State) (err error) {
 						instanceId, err = fromInstanceState(ts, s.ResourceName, "id")
 						return err
@@ -270,6 +273,69 @@ func (s *ResourceCoreInstanceTestSuite) TestAccResourceCoreInstance_basic() {
 					},
 				),
 			},
+			// verify the instance can be stopped and started in place
+			{
+				Config: s.Config + `
+				resource "oci_core_instance" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					image = "${data.oci_core_images.t.images.0.id}"
+					shape = "VM.Standard1.1"
+					display_name = "-tf-instance"
+					subnet_id = "${oci_core_subnet.t.id}"
+					state = "STOPPED"
+					metadata {
+						ssh_authorized_keys = "${var.ssh_public_key}"
+					}
+					create_vnic_details {
+						subnet_id = "${oci_core_subnet.t.id}"
+						display_name = "-tf-vnic-2"
+						skip_source_dest_check = true
+						hostname_label = "mytftesthostname"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceStopped),
+					func(ts *terraform.State) (err error) {
+						newId, err := fromInstanceState(ts, s.ResourceName, "id")
+						if newId != instanceId {
+							return fmt.Errorf("Expected same instance ocid, got different.")
+						}
+						return err
+					},
+				),
+			},
+			{
+				Config: s.Config + `
+				resource "oci_core_instance" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					image = "${data.oci_core_images.t.images.0.id}"
+					shape = "VM.Standard1.1"
+					display_name = "-tf-instance"
+					subnet_id = "${oci_core_subnet.t.id}"
+					state = "RUNNING"
+					metadata {
+						ssh_authorized_keys = "${var.ssh_public_key}"
+					}
+					create_vnic_details {
+						subnet_id = "${oci_core_subnet.t.id}"
+						display_name = "-tf-vnic-2"
+						skip_source_dest_check = true
+						hostname_label = "mytftesthostname"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceRunning),
+					func(ts *terraform.State) (err error) {
+						newId, err := fromInstanceState(ts, s.ResourceName, "id")
+						if newId != instanceId {
+							return fmt.Errorf("Expected same instance ocid, got different.")
+						}
+						return err
+					},
+				),
+			},
 			// verify force new by setting non-updateable VNIC details.
 			{
 				ImportState:       true,
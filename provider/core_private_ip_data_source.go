@@ -0,0 +1,106 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+// PrivateIPAddressDatasource resolves a single private IP address to its
+// OCID and the VNIC it is currently assigned to.
+func PrivateIPAddressDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readPrivateIPAddress,
+		Schema: map[string]*schema.Schema{
+			"ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"availability_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hostname_label": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_primary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vnic_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readPrivateIPAddress(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &PrivateIPAddressDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type PrivateIPAddressDatasourceCrud struct {
+	crud.BaseCrud
+	Resource *baremetal.PrivateIP
+}
+
+func (s *PrivateIPAddressDatasourceCrud) Get() (e error) {
+	ipAddress := s.D.Get("ip_address").(string)
+	subnetID := s.D.Get("subnet_id").(string)
+
+	opts := &baremetal.ListPrivateIPsOptions{IPAddress: ipAddress, SubnetID: subnetID}
+	list, e := s.Client.ListPrivateIPs(opts)
+	if e != nil {
+		return
+	}
+	if len(list.PrivateIPs) == 0 {
+		return fmt.Errorf("No private IP %s found in subnet %s", ipAddress, subnetID)
+	}
+	s.Resource = &list.PrivateIPs[0]
+	return
+}
+
+func (s *PrivateIPAddressDatasourceCrud) SetData() {
+	if s.Resource != nil {
+		s.D.SetId(s.Resource.ID)
+		s.D.Set("id", s.Resource.ID)
+		s.D.Set("availability_domain", s.Resource.AvailabilityDomain)
+		s.D.Set("compartment_id", s.Resource.CompartmentID)
+		s.D.Set("display_name", s.Resource.DisplayName)
+		s.D.Set("hostname_label", s.Resource.HostnameLabel)
+		s.D.Set("is_primary", s.Resource.IsPrimary)
+		s.D.Set("time_created", s.Resource.TimeCreated.String())
+		s.D.Set("vnic_id", s.Resource.VnicID)
+	}
+	return
+}
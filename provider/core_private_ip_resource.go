@@ -3,6 +3,8 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/oracle/bmcs-go-sdk"
 
@@ -33,9 +35,10 @@ func PrivateIPResource() *schema.Resource {
 				Computed: true,
 			},
 			"hostname_label": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateHostnameLabel,
 			},
 			"ip_address": {
 				Type:     schema.TypeString,
@@ -43,6 +46,11 @@ func PrivateIPResource() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
+			"unassign_if_already_assigned": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
 			//Computed
 			"availability_domain": {
@@ -113,6 +121,24 @@ func (s *PrivateIPResourceCrud) ID() string {
 func (s *PrivateIPResourceCrud) Create() (e error) {
 	vnicID := s.D.Get("vnic_id").(string)
 
+	// Move the address from the VNIC holding it instead of failing with a
+	// conflict.
+	if ipAddress, ok := s.D.GetOk("ip_address"); ok && s.D.Get("unassign_if_already_assigned").(bool) {
+		existing, e := s.findAssigned(vnicID, ipAddress.(string))
+		if e != nil {
+			return e
+		}
+		if existing != nil {
+			s.D.SetId(existing.ID)
+			if e = s.Update(); e != nil {
+				// Don't leave the other VNIC's address in the state, where a
+				// destroy would delete it.
+				s.D.SetId("")
+			}
+			return e
+		}
+	}
+
 	opts := &baremetal.CreatePrivateIPOptions{}
 	displayName, ok := s.D.GetOk("display_name")
 	if ok {
@@ -131,6 +157,27 @@ func (s *PrivateIPResourceCrud) Create() (e error) {
 	return
 }
 
+// findAssigned returns the private IP with ipAddress in the subnet of vnicID,
+// or nil if the address is free.
+func (s *PrivateIPResourceCrud) findAssigned(vnicID, ipAddress string) (privateIP *baremetal.PrivateIP, e error) {
+	vnic, e := s.Client.GetVnic(vnicID)
+	if e != nil {
+		return
+	}
+
+	opts := &baremetal.ListPrivateIPsOptions{IPAddress: ipAddress, SubnetID: vnic.SubnetID}
+	list, e := s.Client.ListPrivateIPs(opts)
+	if e != nil || len(list.PrivateIPs) == 0 {
+		return
+	}
+
+	privateIP = &list.PrivateIPs[0]
+	if privateIP.IsPrimary {
+		return nil, fmt.Errorf("ip_address %s is the primary private IP of VNIC %s and cannot be reassigned", ipAddress, privateIP.VnicID)
+	}
+	return
+}
+
 func (s *PrivateIPResourceCrud) Get() (e error) {
 	res, e := s.Client.GetPrivateIP(s.D.Id())
 	if e == nil {
@@ -147,8 +194,18 @@ func (s *PrivateIPResourceCrud) Update() (e error) {
 	if hostnameLabel, ok := s.D.GetOk("hostname_label"); ok {
 		opts.HostnameLabel = hostnameLabel.(string)
 	}
-	if vnicID, ok := s.D.GetOk("vnic_id"); ok {
-		opts.VnicID = vnicID.(string)
+	// A secondary private IP can only move to another VNIC in its subnet.
+	// Check before the update so the error names vnic_id.
+	if vnicID := s.D.Get("vnic_id").(string); s.D.HasChange("vnic_id") {
+		vnic, e := s.Client.GetVnic(vnicID)
+		if e != nil {
+			return e
+		}
+		if subnetID := s.D.Get("subnet_id").(string); subnetID != "" && vnic.SubnetID != subnetID {
+			return fmt.Errorf("vnic_id %s is in subnet %s, but a private IP can only move to a VNIC in its own subnet %s",
+				vnicID, vnic.SubnetID, subnetID)
+		}
+		opts.VnicID = vnicID
 	}
 	s.Res, e = s.Client.UpdatePrivateIP(s.D.Id(), opts)
 	return
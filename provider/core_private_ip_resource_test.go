@@ -112,6 +112,108 @@ func (s *ResourcePrivateIPTestSuite) TestAccCoreResourcePrivateIP_basic() {
 	})
 }
 
+func (s *ResourcePrivateIPTestSuite) TestAccCoreResourcePrivateIP_failover() {
+	config := s.Config + `
+	resource "oci_core_vnic_attachment" "t" {
+		instance_id = "${oci_core_instance.t.id}"
+		create_vnic_details {
+			subnet_id = "${oci_core_subnet.t.id}"
+			assign_public_ip = false
+		}
+	}
+
+	data "oci_core_private_ip" "primary" {
+		ip_address = "${oci_core_instance.t.private_ip}"
+		subnet_id = "${oci_core_subnet.t.id}"
+	}`
+
+	var resId, secondaryVnicID, takenID string
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+				resource "oci_core_private_ip" "t" {
+					vnic_id = "${data.oci_core_private_ip.primary.vnic_id}"
+					ip_address = "10.0.1.50"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.oci_core_private_ip.primary", "is_primary", "true"),
+					resource.TestCheckResourceAttrPair("data.oci_core_private_ip.primary", "vnic_id", s.ResourceName, "vnic_id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "ip_address", "10.0.1.50"),
+					resource.TestCheckResourceAttr(s.ResourceName, "unassign_if_already_assigned", "false"),
+					func(ts *terraform.State) (err error) {
+						resId, err = fromInstanceState(ts, s.ResourceName, "id")
+						if err != nil {
+							return
+						}
+						secondaryVnicID, err = fromInstanceState(ts, "oci_core_vnic_attachment.t", "vnic_id")
+						return
+					},
+				),
+			},
+			// Moving the address to another VNIC keeps the private IP.
+			{
+				Config: config + `
+				resource "oci_core_private_ip" "t" {
+					vnic_id = "${oci_core_vnic_attachment.t.vnic_id}"
+					ip_address = "10.0.1.50"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("oci_core_vnic_attachment.t", "vnic_id", s.ResourceName, "vnic_id"),
+					func(ts *terraform.State) (err error) {
+						resId2, err := fromInstanceState(ts, s.ResourceName, "id")
+						if resId != resId2 {
+							return fmt.Errorf("Expected same private_ip ocid, got a new one")
+						}
+						return err
+					},
+				),
+			},
+			// An address held by another VNIC is taken over rather than
+			// failing with a conflict.
+			{
+				PreConfig: func() {
+					taken, err := s.Client.CreatePrivateIP(secondaryVnicID, &baremetal.CreatePrivateIPOptions{IPAddress: "10.0.1.60"})
+					s.Require().NoError(err)
+					takenID = taken.ID
+				},
+				Config: config + `
+				resource "oci_core_private_ip" "t" {
+					vnic_id = "${oci_core_vnic_attachment.t.vnic_id}"
+					ip_address = "10.0.1.50"
+				}
+
+				data "oci_core_private_ip" "t" {
+					ip_address = "${oci_core_private_ip.t.ip_address}"
+					subnet_id = "${oci_core_subnet.t.id}"
+				}
+
+				resource "oci_core_private_ip" "taken" {
+					vnic_id = "${data.oci_core_private_ip.primary.vnic_id}"
+					ip_address = "10.0.1.60"
+					display_name = "-tf-taken"
+					unassign_if_already_assigned = true
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("oci_core_vnic_attachment.t", "vnic_id", "data.oci_core_private_ip.t", "vnic_id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "data.oci_core_private_ip.t", "id"),
+					resource.TestCheckResourceAttr("data.oci_core_private_ip.t", "is_primary", "false"),
+					resource.TestCheckResourceAttrPair("data.oci_core_private_ip.primary", "vnic_id", "oci_core_private_ip.taken", "vnic_id"),
+					resource.TestCheckResourceAttr("oci_core_private_ip.taken", "display_name", "-tf-taken"),
+					func(ts *terraform.State) (err error) {
+						id, err := fromInstanceState(ts, "oci_core_private_ip.taken", "id")
+						if id != takenID {
+							return fmt.Errorf("Expected the existing private IP %s to be taken over, got %s", takenID, id)
+						}
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestResourceCorePrivateIPTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourcePrivateIPTestSuite))
 }
@@ -3,7 +3,9 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"fmt"
@@ -12,16 +14,34 @@ import (
 	"github.com/oracle/terraform-provider-oci/crud"
 )
 
+var routeRuleResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"cidr_block": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressEquivalentCIDRDiff,
+			ValidateFunc:     validateCIDRBlock,
+		},
+		"network_entity_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	},
+}
+
 func RouteTableResource() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createRouteTable,
-		Read:     readRouteTable,
-		Update:   updateRouteTable,
-		Delete:   deleteRouteTable,
+		// Version 1 made route_rules a set rather than a list.
+		SchemaVersion: 1,
+		MigrateState:  migrateRouteTableState,
+		Timeouts:      crud.DefaultTimeout,
+		Create:        createRouteTable,
+		Read:          readRouteTable,
+		Update:        updateRouteTable,
+		Delete:        deleteRouteTable,
 		Schema: map[string]*schema.Schema{
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -38,20 +58,10 @@ func RouteTableResource() *schema.Resource {
 				Computed: true,
 			},
 			"route_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"cidr_block": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"network_entity_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-					},
-				},
+				Set:      routeRuleHash,
+				Elem:     routeRuleResource,
 			},
 			"time_modified": {
 				Type:     schema.TypeString,
@@ -207,7 +217,7 @@ func (s *RouteTableResourceCrud) ExtraWaitPostCreateDelete() time.Duration {
 
 func (s *RouteTableResourceCrud) buildRouteRules() (routeRules []baremetal.RouteRule, e error) {
 	routeRules = []baremetal.RouteRule{}
-	for _, val := range s.D.Get("route_rules").([]interface{}) {
+	for _, val := range s.D.Get("route_rules").(*schema.Set).List() {
 
 		if val == nil {
 			return nil, fmt.Errorf("Empty route_rules are not permitted. Instead, the route_rules block may be omitted entirely.")
@@ -222,3 +232,21 @@ func (s *RouteTableResourceCrud) buildRouteRules() (routeRules []baremetal.Route
 	}
 	return
 }
+
+// routeRuleHash identifies a rule by its destination and target, however
+// its CIDR block is written.
+func routeRuleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%v", canonicalCIDR(stringValue(m["cidr_block"])), m["network_entity_id"]))
+}
+
+func migrateRouteTableState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		return is, nil
+	}
+	switch v {
+	case 0:
+		migrateListToSet(is, "route_rules", routeRuleResource, routeRuleHash)
+	}
+	return is, nil
+}
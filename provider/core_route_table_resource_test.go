@@ -3,6 +3,8 @@
 package provider
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -71,9 +73,8 @@ func (s *ResourceCoreRouteTableTestSuite) TestAccResourceCoreRouteTable_basic()
 					}`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(s.ResourceName, "display_name"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "route_rules.0.network_entity_id"),
 					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.#", "1"),
-					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.0.cidr_block", "0.0.0.0/0"),
+					testCheckResourceSetAttr(s.ResourceName, "route_rules", map[string]string{"cidr_block": "0.0.0.0/0"}),
 				),
 			},
 			// verify update
@@ -95,10 +96,28 @@ func (s *ResourceCoreRouteTableTestSuite) TestAccResourceCoreRouteTable_basic()
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-route-table"),
 					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.#", "2"),
-					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.0.cidr_block", "0.0.0.0/0"),
-					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.1.cidr_block", "10.0.0.0/8"),
+					testCheckResourceSetAttr(s.ResourceName, "route_rules", map[string]string{"cidr_block": "0.0.0.0/0"}),
+					testCheckResourceSetAttr(s.ResourceName, "route_rules", map[string]string{"cidr_block": "10.0.0.0/8"}),
 				),
 			},
+			// verify reordered rules and equivalent CIDR blocks show no diff
+			{
+				PlanOnly: true,
+				Config: s.Config + `
+					resource "oci_core_route_table" "t" {
+						compartment_id = "${var.compartment_id}"
+						vcn_id = "${oci_core_virtual_network.t.id}"
+						display_name = "-tf-route-table"
+						route_rules {
+							cidr_block = "10.1.2.3/8"
+							network_entity_id = "${oci_core_internet_gateway.internet-gateway1.id}"
+						}
+						route_rules {
+							cidr_block = "0.0.0.0/0"
+							network_entity_id = "${oci_core_internet_gateway.internet-gateway1.id}"
+						}
+					}`,
+			},
 		},
 	})
 }
@@ -106,3 +125,36 @@ func (s *ResourceCoreRouteTableTestSuite) TestAccResourceCoreRouteTable_basic()
 func TestResourceCoreRouteTableTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreRouteTableTestSuite))
 }
+
+func TestRouteTableMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "ocid1.routetable.oc1..a",
+		Attributes: map[string]string{
+			"id":                              "ocid1.routetable.oc1..a",
+			"route_rules.#":                   "2",
+			"route_rules.0.cidr_block":        "0.0.0.0/0",
+			"route_rules.0.network_entity_id": "ocid1.internetgateway.oc1..a",
+			"route_rules.1.cidr_block":        "10.0.0.0/8",
+			"route_rules.1.network_entity_id": "ocid1.drg.oc1..a",
+		},
+	}
+
+	is, err := RouteTableResource().MigrateState(0, is, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internet := routeRuleHash(map[string]interface{}{"cidr_block": "0.0.0.0/0", "network_entity_id": "ocid1.internetgateway.oc1..a"})
+	drg := routeRuleHash(map[string]interface{}{"cidr_block": "10.0.0.0/8", "network_entity_id": "ocid1.drg.oc1..a"})
+	expected := map[string]string{
+		"id":            "ocid1.routetable.oc1..a",
+		"route_rules.#": "2",
+		fmt.Sprintf("route_rules.%d.cidr_block", internet):        "0.0.0.0/0",
+		fmt.Sprintf("route_rules.%d.network_entity_id", internet): "ocid1.internetgateway.oc1..a",
+		fmt.Sprintf("route_rules.%d.cidr_block", drg):             "10.0.0.0/8",
+		fmt.Sprintf("route_rules.%d.network_entity_id", drg):      "ocid1.drg.oc1..a",
+	}
+	if !reflect.DeepEqual(expected, is.Attributes) {
+		t.Fatalf("Expected:\n%#v\n\nGot:\n%#v", expected, is.Attributes)
+	}
+}
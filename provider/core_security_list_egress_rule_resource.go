@@ -0,0 +1,55 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+func SecurityListEgressRuleResource() *schema.Resource {
+	return securityListRuleResource(egressRuleDirection)
+}
+
+var egressRuleDirection = &securityRuleDirection{
+	name: "egress",
+	cidr: "destination",
+	keys: func(list *baremetal.SecurityList) []string {
+		keys := make([]string, len(list.EgressSecurityRules))
+		for i, rule := range list.EgressSecurityRules {
+			keys[i] = egressRuleKey(rule)
+		}
+		return keys
+	},
+	key: func(confRule map[string]interface{}) string {
+		return egressRuleKey(buildEgressRule(confRule))
+	},
+	confRule: func(list *baremetal.SecurityList, i int) map[string]interface{} {
+		rule := list.EgressSecurityRules[i]
+		return buildConfRule(
+			map[string]interface{}{"destination": rule.Destination},
+			rule.Protocol,
+			rule.ICMPOptions,
+			rule.TCPOptions,
+			rule.UDPOptions,
+			&rule.IsStateless,
+		)
+	},
+	update: func(list *baremetal.SecurityList, keep func(i int) bool, add map[string]interface{}) *baremetal.UpdateSecurityListOptions {
+		rules := []baremetal.EgressSecurityRule{}
+		for i, rule := range list.EgressSecurityRules {
+			if keep(i) {
+				rules = append(rules, rule)
+			}
+		}
+		if add != nil {
+			rules = append(rules, buildEgressRule(add))
+		}
+		opts := &baremetal.UpdateSecurityListOptions{
+			EgressRules:  rules,
+			IngressRules: list.IngressSecurityRules,
+		}
+		opts.IfMatch = list.ETag
+		return opts
+	},
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceCoreSecurityListEgressRuleTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreSecurityListEgressRuleTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+		resource "oci_core_virtual_network" "t" {
+			cidr_block = "10.0.0.0/16"
+			compartment_id = "${var.compartment_id}"
+			display_name = "-tf-vcn"
+		}
+
+		resource "oci_core_security_list" "t" {
+			compartment_id = "${var.compartment_id}"
+			display_name = "-tf-security_list"
+			vcn_id = "${oci_core_virtual_network.t.id}"
+			ignore_standalone_rules = true
+			egress_security_rules = [{
+				destination = "10.0.0.0/16"
+				protocol = "all"
+			}]
+			ingress_security_rules = []
+		}`
+	s.ResourceName = "oci_core_security_list_egress_rule.t"
+}
+
+func (s *ResourceCoreSecurityListEgressRuleTestSuite) checkEgressRuleCount(count int) resource.TestCheckFunc {
+	return func(ts *terraform.State) (err error) {
+		id, err := fromInstanceState(ts, "oci_core_security_list.t", "id")
+		if err != nil {
+			return
+		}
+		list, err := s.Client.GetSecurityList(id)
+		if err != nil {
+			return
+		}
+		if len(list.EgressSecurityRules) != count {
+			return fmt.Errorf("Expected %d egress rules, got %d", count, len(list.EgressSecurityRules))
+		}
+		return
+	}
+}
+
+func (s *ResourceCoreSecurityListEgressRuleTestSuite) TestAccResourceCoreSecurityListEgressRule_basic() {
+	defer onFakeOCI()()
+	config := s.Config + `
+		resource "oci_core_security_list_egress_rule" "t" {
+			security_list_id = "${oci_core_security_list.t.id}"
+			destination = "0.0.0.0/0"
+			protocol = "17"
+			udp_options {
+				"min" = {{.port}}
+				"max" = {{.port}}
+			}
+		}`
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: apply(config, map[string]string{"port": "53"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_security_list.t", "egress_security_rules.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "destination", "0.0.0.0/0"),
+					resource.TestCheckResourceAttr(s.ResourceName, "udp_options.0.max", "53"),
+					resource.TestCheckResourceAttr(s.ResourceName, "stateless", "false"),
+				),
+			},
+			{
+				Config:            apply(config, map[string]string{"port": "53"}),
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the rule replaces it in the list.
+			{
+				Config: apply(config, map[string]string{"port": "123"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "udp_options.0.max", "123"),
+					s.checkEgressRuleCount(2),
+				),
+			},
+			// Without the standalone rule the list only has its own.
+			{
+				Config: s.Config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_security_list.t", "egress_security_rules.#", "1"),
+					testCheckResourceSetAttr("oci_core_security_list.t", "egress_security_rules", map[string]string{"protocol": "all"}),
+					s.checkEgressRuleCount(1),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceCoreSecurityListEgressRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreSecurityListEgressRuleTestSuite))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+func SecurityListIngressRuleResource() *schema.Resource {
+	return securityListRuleResource(ingressRuleDirection)
+}
+
+var ingressRuleDirection = &securityRuleDirection{
+	name: "ingress",
+	cidr: "source",
+	keys: func(list *baremetal.SecurityList) []string {
+		keys := make([]string, len(list.IngressSecurityRules))
+		for i, rule := range list.IngressSecurityRules {
+			keys[i] = ingressRuleKey(rule)
+		}
+		return keys
+	},
+	key: func(confRule map[string]interface{}) string {
+		return ingressRuleKey(buildIngressRule(confRule))
+	},
+	confRule: func(list *baremetal.SecurityList, i int) map[string]interface{} {
+		rule := list.IngressSecurityRules[i]
+		return buildConfRule(
+			map[string]interface{}{"source": rule.Source},
+			rule.Protocol,
+			rule.ICMPOptions,
+			rule.TCPOptions,
+			rule.UDPOptions,
+			&rule.IsStateless,
+		)
+	},
+	update: func(list *baremetal.SecurityList, keep func(i int) bool, add map[string]interface{}) *baremetal.UpdateSecurityListOptions {
+		rules := []baremetal.IngressSecurityRule{}
+		for i, rule := range list.IngressSecurityRules {
+			if keep(i) {
+				rules = append(rules, rule)
+			}
+		}
+		if add != nil {
+			rules = append(rules, buildIngressRule(add))
+		}
+		opts := &baremetal.UpdateSecurityListOptions{
+			EgressRules:  list.EgressSecurityRules,
+			IngressRules: rules,
+		}
+		opts.IfMatch = list.ETag
+		return opts
+	},
+}
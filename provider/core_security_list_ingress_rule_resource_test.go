@@ -0,0 +1,185 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceCoreSecurityListIngressRuleTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreSecurityListIngressRuleTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+		resource "oci_core_virtual_network" "t" {
+			cidr_block = "10.0.0.0/16"
+			compartment_id = "${var.compartment_id}"
+			display_name = "-tf-vcn"
+		}`
+	s.ResourceName = "oci_core_security_list_ingress_rule.t"
+}
+
+// checkIngressRuleCount checks the rules actually in the list, including
+// those the security list resource ignores.
+func (s *ResourceCoreSecurityListIngressRuleTestSuite) checkIngressRuleCount(count int) resource.TestCheckFunc {
+	return func(ts *terraform.State) (err error) {
+		id, err := fromInstanceState(ts, "oci_core_security_list.t", "id")
+		if err != nil {
+			return
+		}
+		list, err := s.Client.GetSecurityList(id)
+		if err != nil {
+			return
+		}
+		if len(list.IngressSecurityRules) != count {
+			return fmt.Errorf("Expected %d ingress rules, got %d", count, len(list.IngressSecurityRules))
+		}
+		return
+	}
+}
+
+func (s *ResourceCoreSecurityListIngressRuleTestSuite) TestAccResourceCoreSecurityListIngressRule_basic() {
+	defer onFakeOCI()()
+	securityList := `
+		resource "oci_core_security_list" "t" {
+			compartment_id = "${var.compartment_id}"
+			display_name = "-tf-security_list"
+			vcn_id = "${oci_core_virtual_network.t.id}"
+			ignore_standalone_rules = true
+			egress_security_rules = []
+			ingress_security_rules = [{
+				protocol = "6"
+				source = "10.0.0.0/16"
+				tcp_options {
+					"min" = {{.port}}
+					"max" = {{.port}}
+				}
+			}]
+		}`
+	rules := `
+		resource "oci_core_security_list_ingress_rule" "t" {
+			security_list_id = "${oci_core_security_list.t.id}"
+			protocol = "1"
+			source = "0.0.0.0/0"
+			icmp_options {
+				"type" = 3
+				"code" = 4
+			}
+		}
+
+		resource "oci_core_security_list_ingress_rule" "web" {
+			count = {{.count}}
+			security_list_id = "${oci_core_security_list.t.id}"
+			protocol = "6"
+			source = "0.0.0.0/0"
+			stateless = true
+			tcp_options {
+				"min" = "${8080 + count.index}"
+				"max" = "${8080 + count.index}"
+			}
+		}`
+
+	var listID string
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// The rules are added concurrently to the same list.
+			{
+				Config: s.Config + apply(securityList+rules, map[string]string{"port": "22", "count": "5"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_security_list.t", "ingress_security_rules.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "protocol", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "icmp_options.0.type", "3"),
+					resource.TestCheckResourceAttr("oci_core_security_list_ingress_rule.web.4", "tcp_options.0.max", "8084"),
+					resource.TestCheckResourceAttr("oci_core_security_list_ingress_rule.web.4", "stateless", "true"),
+					s.checkIngressRuleCount(7),
+				),
+			},
+			{
+				Config:            s.Config + apply(securityList+rules, map[string]string{"port": "22", "count": "5"}),
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the list's own rule leaves the standalone rules alone.
+			{
+				Config: s.Config + apply(securityList+rules, map[string]string{"port": "2222", "count": "5"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_security_list.t", "ingress_security_rules.#", "1"),
+					testCheckResourceSetAttr("oci_core_security_list.t", "ingress_security_rules", map[string]string{"tcp_options.0.max": "2222"}),
+					s.checkIngressRuleCount(7),
+				),
+			},
+			{
+				Config: s.Config + apply(securityList+rules, map[string]string{"port": "2222", "count": "2"}),
+				Check: resource.ComposeTestCheckFunc(
+					s.checkIngressRuleCount(4),
+					func(ts *terraform.State) (err error) {
+						listID, err = fromInstanceState(ts, "oci_core_security_list.t", "id")
+						return
+					},
+				),
+			},
+			// A rule removed outside of terraform is added back.
+			{
+				PreConfig: func() {
+					list, err := s.Client.GetSecurityList(listID)
+					s.Require().NoError(err)
+					opts := &baremetal.UpdateSecurityListOptions{
+						EgressRules:  list.EgressSecurityRules,
+						IngressRules: []baremetal.IngressSecurityRule{},
+					}
+					for _, rule := range list.IngressSecurityRules {
+						if rule.Protocol != "1" {
+							opts.IngressRules = append(opts.IngressRules, rule)
+						}
+					}
+					_, err = s.Client.UpdateSecurityList(listID, opts)
+					s.Require().NoError(err)
+				},
+				Config: s.Config + apply(securityList+rules, map[string]string{"port": "2222", "count": "2"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "icmp_options.0.code", "4"),
+					s.checkIngressRuleCount(4),
+				),
+			},
+			// A rule the list already has is not added to it twice.
+			{
+				Config: s.Config + apply(strings.Replace(securityList, "ingress_security_rules = [{", `ingress_security_rules = [{
+				protocol = "1"
+				source = "0.0.0.0/0"
+				icmp_options {
+					"type" = 3
+					"code" = 4
+				}
+			},
+			{`, 1)+rules, map[string]string{"port": "2222", "count": "2"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_security_list.t", "ingress_security_rules.#", "2"),
+					s.checkIngressRuleCount(4),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceCoreSecurityListIngressRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreSecurityListIngressRuleTestSuite))
+}
@@ -3,12 +3,26 @@
 package provider
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
+// securityListMutexKV serializes changes to the rules of a security list,
+// keyed by its ID. Rules are updated by replacing the whole list, so
+// concurrent read-modify-write cycles would otherwise drop each other's
+// rules.
+var securityListMutexKV = mutexkv.NewMutexKV()
+
 var transportSchema = &schema.Schema{
 	Type:     schema.TypeList,
 	Optional: true,
@@ -45,16 +59,112 @@ var icmpSchema = &schema.Schema{
 	},
 }
 
+// securityRuleTransportSchema and securityRuleICMPSchema are the rule
+// options of the standalone rule resources, which replace a rule rather
+// than update it.
+func securityRuleTransportSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max": {
+					Type:     schema.TypeInt,
+					Required: true,
+					ForceNew: true,
+				},
+				"min": {
+					Type:     schema.TypeInt,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+}
+
+func securityRuleICMPSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"code": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: true,
+				},
+				"type": {
+					Type:     schema.TypeInt,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+}
+
+var egressSecurityRuleResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"destination": {
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppressEquivalentCIDRDiff,
+		},
+		"icmp_options": icmpSchema,
+		"protocol": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"tcp_options": transportSchema,
+		"udp_options": transportSchema,
+		"stateless": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	},
+}
+
+var ingressSecurityRuleResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"icmp_options": icmpSchema,
+		"protocol": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"source": {
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppressEquivalentCIDRDiff,
+		},
+		"tcp_options": transportSchema,
+		"udp_options": transportSchema,
+		"stateless": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	},
+}
+
 func SecurityListResource() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createSecurityList,
-		Read:     readSecurityList,
-		Update:   updateSecurityList,
-		Delete:   deleteSecurityList,
+		// Version 1 made the rules sets rather than lists.
+		SchemaVersion: 1,
+		MigrateState:  migrateSecurityListState,
+		Timeouts:      crud.DefaultTimeout,
+		Create:        createSecurityList,
+		Read:          readSecurityList,
+		Update:        updateSecurityList,
+		Delete:        deleteSecurityList,
 		Schema: map[string]*schema.Schema{
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -67,56 +177,25 @@ func SecurityListResource() *schema.Resource {
 				Optional: true,
 			},
 			"egress_security_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"destination": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"icmp_options": icmpSchema,
-						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"tcp_options": transportSchema,
-						"udp_options": transportSchema,
-						"stateless": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-					},
-				},
+				Set:      egressSecurityRuleHash,
+				Elem:     egressSecurityRuleResource,
 			},
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"ignore_standalone_rules": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"ingress_security_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"icmp_options": icmpSchema,
-						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"source": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"tcp_options": transportSchema,
-						"udp_options": transportSchema,
-						"stateless": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-					},
-				},
+				Set:      ingressSecurityRuleHash,
+				Elem:     ingressSecurityRuleResource,
 			},
 			"state": {
 				Type:     schema.TypeString,
@@ -219,17 +298,37 @@ func (s *SecurityListResourceCrud) Get() (e error) {
 }
 
 func (s *SecurityListResourceCrud) Update() (e error) {
+	securityListMutexKV.Lock(s.D.Id())
+	defer securityListMutexKV.Unlock(s.D.Id())
+
 	opts := &baremetal.UpdateSecurityListOptions{}
 
 	if displayName, ok := s.D.GetOk("display_name"); ok {
 		opts.DisplayName = displayName.(string)
 	}
 
-	if egress := s.buildEgressRules(); egress != nil {
-		opts.EgressRules = egress
-	}
-	if ingress := s.buildIngressRules(); ingress != nil {
-		opts.IngressRules = ingress
+	opts.EgressRules = s.buildEgressRules()
+	opts.IngressRules = s.buildIngressRules()
+
+	if s.D.Get("ignore_standalone_rules").(bool) {
+		// Only replace the rules declared here before, keeping any added by
+		// oci_core_security_list_ingress_rule and _egress_rule resources.
+		// Rules declared here which the list already has are only sent once.
+		var current *baremetal.SecurityList
+		if current, e = s.Client.GetSecurityList(s.D.Id()); e != nil {
+			return
+		}
+		oldEgress, _ := s.D.GetChange("egress_security_rules")
+		oldIngress, _ := s.D.GetChange("ingress_security_rules")
+		opts.EgressRules = append(
+			filterEgressRules(current.EgressSecurityRules, append(buildEgressRules(oldEgress.(*schema.Set).List()), opts.EgressRules...), false),
+			opts.EgressRules...,
+		)
+		opts.IngressRules = append(
+			filterIngressRules(current.IngressSecurityRules, append(buildIngressRules(oldIngress.(*schema.Set).List()), opts.IngressRules...), false),
+			opts.IngressRules...,
+		)
+		opts.IfMatch = current.ETag
 	}
 
 	s.Res, e = s.Client.UpdateSecurityList(s.D.Id(), opts)
@@ -240,8 +339,16 @@ func (s *SecurityListResourceCrud) SetData() {
 	s.D.Set("compartment_id", s.Res.CompartmentID)
 	s.D.Set("display_name", s.Res.DisplayName)
 
+	egressRules := s.Res.EgressSecurityRules
+	ingressRules := s.Res.IngressSecurityRules
+	if s.D.Get("ignore_standalone_rules").(bool) {
+		// Rules not declared on this resource belong to someone else.
+		egressRules = filterEgressRules(egressRules, s.buildEgressRules(), true)
+		ingressRules = filterIngressRules(ingressRules, s.buildIngressRules(), true)
+	}
+
 	confEgressRules := []map[string]interface{}{}
-	for _, egressRule := range s.Res.EgressSecurityRules {
+	for _, egressRule := range egressRules {
 		confEgressRule := map[string]interface{}{}
 		confEgressRule["destination"] = egressRule.Destination
 		confEgressRule = buildConfRule(
@@ -257,7 +364,7 @@ func (s *SecurityListResourceCrud) SetData() {
 	s.D.Set("egress_security_rules", confEgressRules)
 
 	confIngressRules := []map[string]interface{}{}
-	for _, ingressRule := range s.Res.IngressSecurityRules {
+	for _, ingressRule := range ingressRules {
 		confIngressRule := map[string]interface{}{}
 		confIngressRule["source"] = ingressRule.Source
 		confIngressRule = buildConfRule(
@@ -281,45 +388,332 @@ func (s *SecurityListResourceCrud) Delete() (e error) {
 	return s.Client.DeleteSecurityList(s.D.Id(), nil)
 }
 
-func (s *SecurityListResourceCrud) buildEgressRules() (sdkRules []baremetal.EgressSecurityRule) {
+func (s *SecurityListResourceCrud) buildEgressRules() []baremetal.EgressSecurityRule {
+	return buildEgressRules(s.D.Get("egress_security_rules").(*schema.Set).List())
+}
+
+func (s *SecurityListResourceCrud) buildIngressRules() []baremetal.IngressSecurityRule {
+	return buildIngressRules(s.D.Get("ingress_security_rules").(*schema.Set).List())
+}
+
+func buildEgressRules(confRules []interface{}) (sdkRules []baremetal.EgressSecurityRule) {
 	sdkRules = []baremetal.EgressSecurityRule{}
-	for _, val := range s.D.Get("egress_security_rules").([]interface{}) {
-		confRule := val.(map[string]interface{})
-
-		sdkRule := baremetal.EgressSecurityRule{
-			Destination: confRule["destination"].(string),
-			ICMPOptions: s.buildICMPOptions(confRule),
-			Protocol:    confRule["protocol"].(string),
-			TCPOptions:  s.buildTCPOptions(confRule),
-			UDPOptions:  s.buildUDPOptions(confRule),
-			IsStateless: confRule["stateless"].(bool),
+	for _, val := range confRules {
+		sdkRules = append(sdkRules, buildEgressRule(val.(map[string]interface{})))
+	}
+	return
+}
+
+func buildIngressRules(confRules []interface{}) (sdkRules []baremetal.IngressSecurityRule) {
+	sdkRules = []baremetal.IngressSecurityRule{}
+	for _, val := range confRules {
+		sdkRules = append(sdkRules, buildIngressRule(val.(map[string]interface{})))
+	}
+	return
+}
+
+func buildEgressRule(confRule map[string]interface{}) baremetal.EgressSecurityRule {
+	return baremetal.EgressSecurityRule{
+		Destination: confRule["destination"].(string),
+		ICMPOptions: buildICMPOptions(confRule),
+		Protocol:    confRule["protocol"].(string),
+		TCPOptions:  buildTCPOptions(confRule),
+		UDPOptions:  buildUDPOptions(confRule),
+		IsStateless: confRule["stateless"].(bool),
+	}
+}
+
+func buildIngressRule(confRule map[string]interface{}) baremetal.IngressSecurityRule {
+	return baremetal.IngressSecurityRule{
+		ICMPOptions: buildICMPOptions(confRule),
+		Protocol:    confRule["protocol"].(string),
+		Source:      confRule["source"].(string),
+		TCPOptions:  buildTCPOptions(confRule),
+		UDPOptions:  buildUDPOptions(confRule),
+		IsStateless: confRule["stateless"].(bool),
+	}
+}
+
+// egressSecurityRuleHash and ingressSecurityRuleHash identify a rule by
+// what it allows, so that rules are matched regardless of their order and of
+// how their CIDR block is written.
+func egressSecurityRuleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%v-%s-%s", m["protocol"], canonicalCIDR(stringValue(m["destination"])), securityRuleOptionsKey(m)))
+}
+
+func ingressSecurityRuleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%v-%s-%s", m["protocol"], canonicalCIDR(stringValue(m["source"])), securityRuleOptionsKey(m)))
+}
+
+func securityRuleOptionsKey(m map[string]interface{}) string {
+	stateless, _ := m["stateless"].(bool)
+	key := fmt.Sprintf("stateless:%t", stateless)
+	if opts := firstElem(m["icmp_options"]); opts != nil {
+		key += fmt.Sprintf("-icmp:%d:%d", intValue(opts["type"]), intValue(opts["code"]))
+	}
+	if opts := firstElem(m["tcp_options"]); opts != nil {
+		key += fmt.Sprintf("-tcp:%d:%d", intValue(opts["min"]), intValue(opts["max"]))
+	}
+	if opts := firstElem(m["udp_options"]); opts != nil {
+		key += fmt.Sprintf("-udp:%d:%d", intValue(opts["min"]), intValue(opts["max"]))
+	}
+	return key
+}
+
+func firstElem(v interface{}) map[string]interface{} {
+	if l, ok := v.([]interface{}); ok && len(l) > 0 {
+		m, _ := l[0].(map[string]interface{})
+		return m
+	}
+	return nil
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func intValue(v interface{}) int {
+	i, _ := v.(int)
+	return i
+}
+
+func migrateSecurityListState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		return is, nil
+	}
+	switch v {
+	case 0:
+		migrateListToSet(is, "egress_security_rules", egressSecurityRuleResource, egressSecurityRuleHash)
+		migrateListToSet(is, "ingress_security_rules", ingressSecurityRuleResource, ingressSecurityRuleHash)
+	}
+	return is, nil
+}
+
+// securityListRuleID is the ID of a standalone rule resource:
+// {security_list_id}/{hash of the rule}.
+func securityListRuleID(securityListID, ruleKey string) string {
+	return fmt.Sprintf("%s/%d", securityListID, hashcode.String(ruleKey))
+}
+
+func importSecurityListRule(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	i := strings.LastIndex(d.Id(), "/")
+	if i <= 0 {
+		return nil, fmt.Errorf("Expected an ID of the form {security_list_id}/{rule_hash}, got %s", d.Id())
+	}
+	d.Set("security_list_id", d.Id()[:i])
+	return []*schema.ResourceData{d}, nil
+}
+
+// securityRuleDirection is what differs between the ingress and egress rule
+// resources: the argument holding the rule's CIDR block, and how rules are
+// read from and written to a security list.
+type securityRuleDirection struct {
+	name string
+	cidr string
+	// keys returns the ruleKey of each of the list's rules, in order.
+	keys func(list *baremetal.SecurityList) []string
+	// key returns the ruleKey of a rule given in the form of the schema.
+	key func(confRule map[string]interface{}) string
+	// confRule returns the list's rule at i in the form of the schema.
+	confRule func(list *baremetal.SecurityList, i int) map[string]interface{}
+	// update returns the options to replace the list's rules with those
+	// for which keep is true, followed by add unless it is nil. The rules
+	// of the other direction are kept.
+	update func(list *baremetal.SecurityList, keep func(i int) bool, add map[string]interface{}) *baremetal.UpdateSecurityListOptions
+}
+
+// securityListRuleResource is a single rule of a security list, added and
+// removed without touching the list's other rules.
+func securityListRuleResource(direction *securityRuleDirection) *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importSecurityListRule,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return crud.CreateResource(d, newSecurityListRuleCrud(d, m.(*OracleClients).client, direction))
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return crud.ReadResource(newSecurityListRuleCrud(d, m.(*OracleClients).client, direction))
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return crud.DeleteResource(d, newSecurityListRuleCrud(d, m.(*OracleClients).clientWithoutNotFoundRetries, direction))
+		},
+		Schema: map[string]*schema.Schema{
+			"security_list_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			direction.cidr: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"icmp_options": securityRuleICMPSchema(),
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tcp_options": securityRuleTransportSchema(),
+			"udp_options": securityRuleTransportSchema(),
+			"stateless": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func newSecurityListRuleCrud(d *schema.ResourceData, client *sdk.Client, direction *securityRuleDirection) *SecurityListRuleResourceCrud {
+	crd := &SecurityListRuleResourceCrud{direction: direction}
+	crd.D = d
+	crd.Client = client
+	return crd
+}
+
+// SecurityListRuleResourceCrud manages a single rule of a security list.
+// Rules have no identity of their own, so the resource is identified by the
+// security list and a hash of the rule's content.
+type SecurityListRuleResourceCrud struct {
+	crud.BaseCrud
+	direction *securityRuleDirection
+	key       string
+	Res       map[string]interface{}
+}
+
+func (s *SecurityListRuleResourceCrud) ID() string {
+	return securityListRuleID(s.D.Get("security_list_id").(string), s.key)
+}
+
+func (s *SecurityListRuleResourceCrud) Create() (e error) {
+	listID := s.D.Get("security_list_id").(string)
+	confRule := map[string]interface{}{}
+	for _, k := range []string{s.direction.cidr, "icmp_options", "protocol", "tcp_options", "udp_options", "stateless"} {
+		confRule[k] = s.D.Get(k)
+	}
+	key := s.direction.key(confRule)
+
+	securityListMutexKV.Lock(listID)
+	defer securityListMutexKV.Unlock(listID)
+
+	list, e := s.Client.GetSecurityList(listID)
+	if e != nil {
+		return
+	}
+	for _, k := range s.direction.keys(list) {
+		if k == key {
+			return fmt.Errorf("Security list %s already has this %s rule. Import it with the ID %s rather than declaring it again.",
+				listID, s.direction.name, securityListRuleID(listID, key))
 		}
+	}
 
-		sdkRules = append(sdkRules, sdkRule)
+	opts := s.direction.update(list, func(int) bool { return true }, confRule)
+	if _, e = s.Client.UpdateSecurityList(listID, opts); e != nil {
+		return
 	}
+	s.Res, s.key = confRule, key
 	return
 }
 
-func (s *SecurityListResourceCrud) buildIngressRules() (sdkRules []baremetal.IngressSecurityRule) {
-	sdkRules = []baremetal.IngressSecurityRule{}
-	for _, val := range s.D.Get("ingress_security_rules").([]interface{}) {
-		confRule := val.(map[string]interface{})
-
-		sdkRule := baremetal.IngressSecurityRule{
-			ICMPOptions: s.buildICMPOptions(confRule),
-			Protocol:    confRule["protocol"].(string),
-			Source:      confRule["source"].(string),
-			TCPOptions:  s.buildTCPOptions(confRule),
-			UDPOptions:  s.buildUDPOptions(confRule),
-			IsStateless: confRule["stateless"].(bool),
+func (s *SecurityListRuleResourceCrud) Get() (e error) {
+	listID := s.D.Get("security_list_id").(string)
+	list, e := s.Client.GetSecurityList(listID)
+	if e != nil {
+		return
+	}
+	if list.State == baremetal.ResourceTerminated {
+		return fmt.Errorf("Security list %s does not exist", listID)
+	}
+	for i, k := range s.direction.keys(list) {
+		if securityListRuleID(listID, k) == s.D.Id() {
+			s.Res, s.key = s.direction.confRule(list, i), k
+			return
 		}
+	}
+	return fmt.Errorf("The %s rule %s was not found in security list %s", s.direction.name, s.D.Id(), listID)
+}
+
+func (s *SecurityListRuleResourceCrud) SetData() {
+	for k, v := range s.Res {
+		s.D.Set(k, v)
+	}
+}
 
-		sdkRules = append(sdkRules, sdkRule)
+func (s *SecurityListRuleResourceCrud) Delete() (e error) {
+	listID := s.D.Get("security_list_id").(string)
+
+	securityListMutexKV.Lock(listID)
+	defer securityListMutexKV.Unlock(listID)
+
+	list, e := s.Client.GetSecurityList(listID)
+	if e != nil {
+		return
+	}
+	keys := s.direction.keys(list)
+	keep := func(i int) bool { return securityListRuleID(listID, keys[i]) != s.D.Id() }
+	found := false
+	for i := range keys {
+		found = found || !keep(i)
 	}
+	if !found {
+		return
+	}
+
+	_, e = s.Client.UpdateSecurityList(listID, s.direction.update(list, keep, nil))
 	return
 }
 
-func (s *SecurityListResourceCrud) buildICMPOptions(conf map[string]interface{}) (opts *baremetal.ICMPOptions) {
+// egressRuleKey identifies a rule by its content, since rules have no ID.
+func egressRuleKey(rule baremetal.EgressSecurityRule) string {
+	rule.Destination = canonicalCIDR(rule.Destination)
+	b, _ := json.Marshal(rule)
+	return string(b)
+}
+
+func ingressRuleKey(rule baremetal.IngressSecurityRule) string {
+	rule.Source = canonicalCIDR(rule.Source)
+	b, _ := json.Marshal(rule)
+	return string(b)
+}
+
+// filterEgressRules returns the rules that are (keep) or are not (!keep)
+// also in match, in their original order.
+func filterEgressRules(rules, match []baremetal.EgressSecurityRule, keep bool) []baremetal.EgressSecurityRule {
+	keys := map[string]bool{}
+	for _, rule := range match {
+		keys[egressRuleKey(rule)] = true
+	}
+	filtered := []baremetal.EgressSecurityRule{}
+	for _, rule := range rules {
+		if keys[egressRuleKey(rule)] == keep {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+func filterIngressRules(rules, match []baremetal.IngressSecurityRule, keep bool) []baremetal.IngressSecurityRule {
+	keys := map[string]bool{}
+	for _, rule := range match {
+		keys[ingressRuleKey(rule)] = true
+	}
+	filtered := []baremetal.IngressSecurityRule{}
+	for _, rule := range rules {
+		if keys[ingressRuleKey(rule)] == keep {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+func buildICMPOptions(conf map[string]interface{}) (opts *baremetal.ICMPOptions) {
 	l := conf["icmp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
@@ -331,7 +725,7 @@ func (s *SecurityListResourceCrud) buildICMPOptions(conf map[string]interface{})
 	return
 }
 
-func (s *SecurityListResourceCrud) buildTCPOptions(conf map[string]interface{}) (opts *baremetal.TCPOptions) {
+func buildTCPOptions(conf map[string]interface{}) (opts *baremetal.TCPOptions) {
 	l := conf["tcp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
@@ -345,7 +739,7 @@ func (s *SecurityListResourceCrud) buildTCPOptions(conf map[string]interface{})
 	return
 }
 
-func (s *SecurityListResourceCrud) buildUDPOptions(conf map[string]interface{}) (opts *baremetal.UDPOptions) {
+func buildUDPOptions(conf map[string]interface{}) (opts *baremetal.UDPOptions) {
 	l := conf["udp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
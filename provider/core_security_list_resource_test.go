@@ -3,6 +3,8 @@
 package provider
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -86,11 +88,11 @@ func (s *ResourceCoreSecurityListTestSuite) TestAccResourceCoreSecurityList_basi
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-security_list"),
 					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.#", "1"),
-					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.0.stateless", "false"),
+					testCheckResourceSetAttr(s.ResourceName, "egress_security_rules", map[string]string{"protocol": "6", "stateless": "false"}),
 					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "3"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.0.icmp_options.0.type", "3"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.1.tcp_options.0.max", "80"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.2.udp_options.0.max", "320"),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "1", "icmp_options.0.type": "3"}),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "6", "tcp_options.0.max": "80"}),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "17", "udp_options.0.max": "320"}),
 				),
 			},
 			// verify update
@@ -132,16 +134,53 @@ func (s *ResourceCoreSecurityListTestSuite) TestAccResourceCoreSecurityList_basi
 				`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-security_list-updated"),
-					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.0.protocol", "17"),
-					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.0.stateless", "true"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.0.stateless", "true"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.0.icmp_options.0.type", "5"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.1.tcp_options.0.max", "82"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.1.stateless", "true"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.2.stateless", "true"),
-					resource.TestCheckNoResourceAttr(s.ResourceName, "ingress_security_rules.2.udp_options"),
+					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.#", "1"),
+					testCheckResourceSetAttr(s.ResourceName, "egress_security_rules", map[string]string{"protocol": "17", "stateless": "true"}),
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "3"),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "1", "stateless": "true", "icmp_options.0.type": "5"}),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "6", "stateless": "true", "tcp_options.0.max": "82"}),
+					testCheckResourceSetAttr(s.ResourceName, "ingress_security_rules", map[string]string{"protocol": "17", "stateless": "true", "udp_options.#": "0"}),
 				),
 			},
+			// verify reordered rules and equivalent CIDR blocks show no diff
+			{
+				PlanOnly: true,
+				Config: s.Config + `
+					resource "oci_core_security_list" "t" {
+						compartment_id = "${var.compartment_id}"
+						display_name = "-tf-security_list-updated"
+						vcn_id = "${oci_core_virtual_network.t.id}"
+						egress_security_rules = [{
+							destination = "0.0.0.0/0"
+							protocol = "17"
+							stateless = true
+						}]
+						ingress_security_rules = [{
+							protocol = "17"
+							source = "10.0.3.7/16"
+							stateless = true
+						},
+						{
+							protocol = "6"
+							source = "0.0.0.0/0"
+							stateless = true
+							tcp_options {
+								"min" = 80
+								"max" = 82
+							}
+						},
+						{
+							protocol = "1"
+							source = "0.0.0.0/0"
+							stateless = true
+							icmp_options {
+								"type" = 5
+								"code" = 0
+							}
+						}]
+					}
+				`,
+			},
 			// todo: consistent 500 error from server without this step
 			{
 				ImportState:       true,
@@ -171,3 +210,96 @@ func (s *ResourceCoreSecurityListTestSuite) TestAccResourceCoreSecurityList_basi
 func TestResourceCoreSecurityListTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreSecurityListTestSuite))
 }
+
+func TestSecurityListMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "ocid1.securitylist.oc1..a",
+		Attributes: map[string]string{
+			"id":                                           "ocid1.securitylist.oc1..a",
+			"egress_security_rules.#":                      "1",
+			"egress_security_rules.0.destination":          "0.0.0.0/0",
+			"egress_security_rules.0.protocol":             "all",
+			"egress_security_rules.0.stateless":            "false",
+			"egress_security_rules.0.icmp_options.#":       "0",
+			"egress_security_rules.0.tcp_options.#":        "0",
+			"egress_security_rules.0.udp_options.#":        "0",
+			"ingress_security_rules.#":                     "2",
+			"ingress_security_rules.0.source":              "10.0.0.0/16",
+			"ingress_security_rules.0.protocol":            "6",
+			"ingress_security_rules.0.stateless":           "false",
+			"ingress_security_rules.0.tcp_options.#":       "1",
+			"ingress_security_rules.0.tcp_options.0.min":   "22",
+			"ingress_security_rules.0.tcp_options.0.max":   "22",
+			"ingress_security_rules.1.source":              "0.0.0.0/0",
+			"ingress_security_rules.1.protocol":            "1",
+			"ingress_security_rules.1.stateless":           "true",
+			"ingress_security_rules.1.icmp_options.#":      "1",
+			"ingress_security_rules.1.icmp_options.0.type": "3",
+			"ingress_security_rules.1.icmp_options.0.code": "4",
+		},
+	}
+
+	is, err := SecurityListResource().MigrateState(0, is, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ssh := ingressSecurityRuleHash(map[string]interface{}{
+		"source":      "10.0.0.0/16",
+		"protocol":    "6",
+		"stateless":   false,
+		"tcp_options": []interface{}{map[string]interface{}{"min": 22, "max": 22}},
+	})
+	icmp := ingressSecurityRuleHash(map[string]interface{}{
+		"source":       "0.0.0.0/0",
+		"protocol":     "1",
+		"stateless":    true,
+		"icmp_options": []interface{}{map[string]interface{}{"type": 3, "code": 4}},
+	})
+	all := egressSecurityRuleHash(map[string]interface{}{
+		"destination": "0.0.0.0/0",
+		"protocol":    "all",
+	})
+	expected := map[string]string{
+		"id":                      "ocid1.securitylist.oc1..a",
+		"egress_security_rules.#": "1",
+		fmt.Sprintf("egress_security_rules.%d.destination", all):           "0.0.0.0/0",
+		fmt.Sprintf("egress_security_rules.%d.protocol", all):              "all",
+		fmt.Sprintf("egress_security_rules.%d.stateless", all):             "false",
+		fmt.Sprintf("egress_security_rules.%d.icmp_options.#", all):        "0",
+		fmt.Sprintf("egress_security_rules.%d.tcp_options.#", all):         "0",
+		fmt.Sprintf("egress_security_rules.%d.udp_options.#", all):         "0",
+		"ingress_security_rules.#":                                         "2",
+		fmt.Sprintf("ingress_security_rules.%d.source", ssh):               "10.0.0.0/16",
+		fmt.Sprintf("ingress_security_rules.%d.protocol", ssh):             "6",
+		fmt.Sprintf("ingress_security_rules.%d.stateless", ssh):            "false",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.#", ssh):        "1",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.min", ssh):    "22",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.max", ssh):    "22",
+		fmt.Sprintf("ingress_security_rules.%d.source", icmp):              "0.0.0.0/0",
+		fmt.Sprintf("ingress_security_rules.%d.protocol", icmp):            "1",
+		fmt.Sprintf("ingress_security_rules.%d.stateless", icmp):           "true",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.#", icmp):      "1",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.0.type", icmp): "3",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.0.code", icmp): "4",
+	}
+	if !reflect.DeepEqual(expected, is.Attributes) {
+		t.Fatalf("Expected:\n%#v\n\nGot:\n%#v", expected, is.Attributes)
+	}
+}
+
+func TestSecurityRuleHashNormalizesCIDR(t *testing.T) {
+	a := ingressSecurityRuleHash(map[string]interface{}{"source": "10.0.0.5/24", "protocol": "6"})
+	b := ingressSecurityRuleHash(map[string]interface{}{"source": "10.0.0.0/24", "protocol": "6"})
+	if a != b {
+		t.Fatalf("Expected equivalent CIDR blocks to hash the same")
+	}
+	c := ingressSecurityRuleHash(map[string]interface{}{
+		"source":      "10.0.0.0/24",
+		"protocol":    "6",
+		"tcp_options": []interface{}{map[string]interface{}{"min": 22, "max": 22}},
+	})
+	if a == c {
+		t.Fatalf("Expected port ranges to be part of a rule's identity")
+	}
+}
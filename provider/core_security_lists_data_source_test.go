@@ -56,7 +56,7 @@ func (s *DatasourceCoreSecurityListTestSuite) TestAccDatasourceCoreSecurityLists
 					resource.TestCheckResourceAttrSet(s.ResourceName, "vcn_id"),
 					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.#", "1"),
 					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.0.display_name", "Default Security List for -tf-vcn"),
-					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.0.ingress_security_rules.0.tcp_options.0.max", "22"),
+					testCheckResourceSetAttr(s.ResourceName, "security_lists.0.ingress_security_rules", map[string]string{"tcp_options.0.max": "22"}),
 					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.0.state", "AVAILABLE"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "security_lists.0.id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "security_lists.0.vcn_id"),
@@ -3,12 +3,15 @@
 package provider
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func SubnetResource() *schema.Resource {
@@ -28,9 +31,10 @@ func SubnetResource() *schema.Resource {
 				ForceNew: true,
 			},
 			"cidr_block": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRBlockPrefix(16, 30),
 			},
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -67,6 +71,7 @@ func SubnetResource() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: crud.EqualIgnoreCaseSuppressDiff,
+				ValidateFunc:     validateDNSLabel,
 			},
 			"display_name": {
 				Type:     schema.TypeString,
@@ -168,6 +173,10 @@ func (s *SubnetResourceCrud) Create() (e error) {
 	compartmentID := s.D.Get("compartment_id").(string)
 	vcnID := s.D.Get("vcn_id").(string)
 
+	if e = s.checkCIDRBlock(compartmentID, vcnID, cidrBlock); e != nil {
+		return
+	}
+
 	opts := &baremetal.CreateSubnetOptions{}
 	if dhcpOptionsID, ok := s.D.GetOk("dhcp_options_id"); ok {
 		opts.DHCPOptionsID = dhcpOptionsID.(string)
@@ -212,6 +221,63 @@ func (s *SubnetResourceCrud) Create() (e error) {
 	return
 }
 
+// checkCIDRBlock fails early, with an error naming cidr_block, when the
+// subnet would not fit in its VCN or would overlap another subnet of the
+// VCN in the subnet's or the VCN's compartment. The service rejects both,
+// but only once the create request is made.
+func (s *SubnetResourceCrud) checkCIDRBlock(compartmentID, vcnID, cidrBlock string) (e error) {
+	vcn, e := s.Client.GetVirtualNetwork(vcnID)
+	if e != nil {
+		return
+	}
+	if !cidrContains(vcn.CidrBlock, cidrBlock) {
+		return fmt.Errorf("cidr_block %s is not within the cidr_block %s of vcn_id %s", cidrBlock, vcn.CidrBlock, vcnID)
+	}
+
+	subnets, e := listVcnSubnets(s.Client, vcnID, compartmentID, vcn.CompartmentID)
+	if e != nil {
+		return
+	}
+	for _, subnet := range subnets {
+		if cidrsOverlap(subnet.CIDRBlock, cidrBlock) {
+			return fmt.Errorf("cidr_block %s overlaps the cidr_block %s of subnet %s (%s) in vcn_id %s",
+				cidrBlock, subnet.CIDRBlock, subnet.DisplayName, subnet.ID, vcnID)
+		}
+	}
+	return
+}
+
+// listVcnSubnets returns the subnets of vcnID in compartmentIDs that are
+// not being terminated. Subnets can only be listed by compartment, so those
+// of the VCN in other compartments are not returned.
+func listVcnSubnets(client *sdk.Client, vcnID string, compartmentIDs ...string) (subnets []baremetal.Subnet, e error) {
+	seen := map[string]bool{}
+	subnets = []baremetal.Subnet{}
+	for _, compartmentID := range compartmentIDs {
+		if compartmentID == "" || seen[compartmentID] {
+			continue
+		}
+		seen[compartmentID] = true
+
+		opts := &baremetal.ListOptions{}
+		for {
+			var list *baremetal.ListSubnets
+			if list, e = client.ListSubnets(compartmentID, vcnID, opts); e != nil {
+				return
+			}
+			for _, subnet := range list.Subnets {
+				if subnet.State != baremetal.ResourceTerminating && subnet.State != baremetal.ResourceTerminated {
+					subnets = append(subnets, subnet)
+				}
+			}
+			if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNextPage {
+				break
+			}
+		}
+	}
+	return
+}
+
 func (s *SubnetResourceCrud) Get() (e error) {
 	res, e := s.Client.GetSubnet(s.D.Id())
 	if e == nil {
@@ -4,11 +4,13 @@ package provider
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 )
@@ -138,3 +140,173 @@ func TestAccResourceCoreSubnetCreate_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceCoreSubnetCreate_validation(t *testing.T) {
+	config := testProviderConfig() + `
+		data "oci_identity_availability_domains" "ADs" {
+			compartment_id = "${var.compartment_id}"
+		}
+
+		resource "oci_core_virtual_network" "t" {
+			cidr_block     = "{{.vcn_cidr}}"
+			compartment_id = "${var.compartment_id}"
+			display_name   = "network_name"
+			dns_label      = "{{.dns_label}}"
+		}
+
+		resource "oci_core_subnet" "s" {
+			availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+			compartment_id = "${var.compartment_id}"
+			vcn_id = "${oci_core_virtual_network.t.id}"
+			security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+			route_table_id = "${oci_core_virtual_network.t.default_route_table_id}"
+			dhcp_options_id = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+			cidr_block = "{{.subnet_cidr}}"
+		}`
+	valid := map[string]string{"vcn_cidr": "10.0.0.0/16", "dns_label": "myvcn", "subnet_cidr": "10.0.1.0/24"}
+	with := func(k, v string) map[string]string {
+		m := map[string]string{}
+		for key, val := range valid {
+			m[key] = val
+		}
+		m[k] = v
+		return m
+	}
+
+	// checkCIDRBlockIn expects an error matching match, or none if match is
+	// empty, for a subnet in the compartment of the named resource, or of the
+	// VCN if compartment is empty.
+	checkCIDRBlockIn := func(compartment, cidrBlock, match string) resource.TestCheckFunc {
+		return func(s *terraform.State) (err error) {
+			vcnID, err := fromInstanceState(s, "oci_core_virtual_network.t", "id")
+			if err != nil {
+				return
+			}
+			compartmentID := getRequiredEnvSetting("compartment_ocid")
+			if compartment != "" {
+				if compartmentID, err = fromInstanceState(s, compartment, "id"); err != nil {
+					return
+				}
+			}
+			crd := &SubnetResourceCrud{}
+			crd.Client = GetTestProvider().client
+			err = crd.checkCIDRBlock(compartmentID, vcnID, cidrBlock)
+			switch {
+			case match == "" && err != nil:
+				return fmt.Errorf("Expected %s to be accepted, got %s", cidrBlock, err)
+			case match != "" && err == nil:
+				return fmt.Errorf("Expected %s to be rejected", cidrBlock)
+			case match != "" && !regexp.MustCompile(match).MatchString(err.Error()):
+				return fmt.Errorf("Expected %s to be rejected with %q, got %s", cidrBlock, match, err)
+			}
+			return nil
+		}
+	}
+	checkCIDRBlock := func(cidrBlock, match string) resource.TestCheckFunc {
+		return checkCIDRBlockIn("", cidrBlock, match)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"oci": testAccProvider,
+		},
+		Steps: []resource.TestStep{
+			// Malformed attributes are rejected during plan.
+			{
+				Config:      apply(config, with("vcn_cidr", "10.0.0.0/8")),
+				ExpectError: regexp.MustCompile("expected cidr_block to have a prefix length between /16 and /30"),
+			},
+			{
+				Config:      apply(config, with("subnet_cidr", "10.0.1.0")),
+				ExpectError: regexp.MustCompile("expected cidr_block to be an IPv4 CIDR block"),
+			},
+			{
+				Config:      apply(config, with("dns_label", "my-vcn")),
+				ExpectError: regexp.MustCompile("expected dns_label to start with a letter"),
+			},
+			// Subnets outside the VCN or overlapping a sibling are rejected
+			// before the create request.
+			{
+				Config: apply(config, valid),
+				Check: resource.ComposeTestCheckFunc(
+					checkCIDRBlock("10.1.0.0/24", "cidr_block 10.1.0.0/24 is not within the cidr_block 10.0.0.0/16 of vcn_id"),
+					checkCIDRBlock("10.0.0.0/15", "is not within"),
+					checkCIDRBlock("10.0.1.128/25", "cidr_block 10.0.1.128/25 overlaps the cidr_block 10.0.1.0/24 of subnet"),
+					checkCIDRBlock("10.0.0.0/20", "overlaps"),
+					checkCIDRBlock("10.0.2.0/24", ""),
+				),
+			},
+			// Subnets of the VCN in the compartment of the new subnet are
+			// siblings too.
+			{
+				Config: apply(config, valid) + `
+				resource "oci_identity_compartment" "other" {
+					name = "-tf-subnet-other"
+					description = "compartment of a sibling subnet"
+				}
+
+				resource "oci_core_subnet" "other" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${oci_identity_compartment.other.id}"
+					vcn_id = "${oci_core_virtual_network.t.id}"
+					security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+					route_table_id = "${oci_core_virtual_network.t.default_route_table_id}"
+					dhcp_options_id = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+					cidr_block = "10.0.3.0/24"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					checkCIDRBlockIn("oci_identity_compartment.other", "10.0.3.128/25", "cidr_block 10.0.3.128/25 overlaps the cidr_block 10.0.3.0/24 of subnet"),
+					checkCIDRBlockIn("oci_identity_compartment.other", "10.0.1.128/25", "cidr_block 10.0.1.128/25 overlaps the cidr_block 10.0.1.0/24 of subnet"),
+					checkCIDRBlock("10.0.2.0/24", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestNetworkAttributeValidation(t *testing.T) {
+	cases := []struct {
+		validate schema.SchemaValidateFunc
+		value    string
+		valid    bool
+	}{
+		{validateCIDRBlock, "0.0.0.0/0", true},
+		{validateCIDRBlock, "10.0.0.5/24", true},
+		{validateCIDRBlock, "10.0.0.0", false},
+		{validateCIDRBlock, "10.0.0.0/33", false},
+		{validateCIDRBlock, "fd00::/64", false},
+		{validateCIDRBlockPrefix(16, 30), "10.0.0.0/16", true},
+		{validateCIDRBlockPrefix(16, 30), "10.0.0.0/30", true},
+		{validateCIDRBlockPrefix(16, 30), "10.0.0.0/8", false},
+		{validateCIDRBlockPrefix(16, 30), "10.0.0.0/31", false},
+		{validateDNSLabel, "vcn1", true},
+		{validateDNSLabel, "MyTestDNSLabel", true},
+		{validateDNSLabel, "abcdefghijklmnop", false},
+		{validateDNSLabel, "1vcn", false},
+		{validateDNSLabel, "my-vcn", false},
+		{validateHostnameLabel, "be-instance1", true},
+		{validateHostnameLabel, "a", true},
+		{validateHostnameLabel, "instance-", false},
+		{validateHostnameLabel, "-instance", false},
+		{validateHostnameLabel, "my_instance", false},
+		{validateHostnameLabel, strings.Repeat("a", 64), false},
+	}
+	for _, c := range cases {
+		_, es := c.validate(c.value, "attr")
+		if valid := len(es) == 0; valid != c.valid {
+			t.Errorf("Expected %q valid to be %t, got errors %v", c.value, c.valid, es)
+		}
+		for _, e := range es {
+			if !strings.Contains(e.Error(), "attr") {
+				t.Errorf("Expected error for %q to name the attribute, got %s", c.value, e)
+			}
+		}
+	}
+
+	if cidrsOverlap("10.0.1.0/24", "10.0.2.0/24") {
+		t.Error("Expected 10.0.1.0/24 and 10.0.2.0/24 not to overlap")
+	}
+	if !cidrsOverlap("10.0.0.0/16", "10.0.2.0/24") || !cidrsOverlap("10.0.2.0/24", "10.0.0.0/16") {
+		t.Error("Expected 10.0.0.0/16 and 10.0.2.0/24 to overlap")
+	}
+}
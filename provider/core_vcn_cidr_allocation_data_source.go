@@ -0,0 +1,255 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+func VcnCIDRAllocationDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readVcnCIDRAllocation,
+		Schema: map[string]*schema.Schema{
+			"vcn_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"prefix_lengths": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(16, 30),
+				},
+			},
+			"display_names": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringLenBetween(1, 255),
+				},
+			},
+			// Computed
+			"cidr_block": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"total_addresses": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_addresses": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_percent": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readVcnCIDRAllocation(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	reader := &VcnCIDRAllocationDatasourceCrud{}
+	reader.D = d
+	reader.Client = client.client
+
+	return crud.ReadResource(reader)
+}
+
+type VcnCIDRAllocationDatasourceCrud struct {
+	crud.BaseCrud
+	Vcn        *baremetal.VirtualNetwork
+	Subnets    []baremetal.Subnet
+	CIDRBlocks []string
+}
+
+func (s *VcnCIDRAllocationDatasourceCrud) Get() (e error) {
+	vcnID := s.D.Get("vcn_id").(string)
+
+	prefixLengths := []int{}
+	for _, v := range s.D.Get("prefix_lengths").([]interface{}) {
+		prefixLengths = append(prefixLengths, v.(int))
+	}
+	// Each block is keyed by the display name of the subnet it is for, so
+	// a block stays allocated to its request once the subnet is created.
+	rawDisplayNames := s.D.Get("display_names").([]interface{})
+	if len(rawDisplayNames) != len(prefixLengths) {
+		return fmt.Errorf("display_names must have one entry for each of the %d prefix_lengths, got %d",
+			len(prefixLengths), len(rawDisplayNames))
+	}
+	displayNames := make([]string, len(prefixLengths))
+	seen := map[string]int{}
+	for i, v := range rawDisplayNames {
+		if v != nil {
+			displayNames[i] = v.(string)
+		}
+		if displayNames[i] == "" {
+			return fmt.Errorf("display_names.%d must not be empty", i)
+		}
+		if j, ok := seen[displayNames[i]]; ok {
+			return fmt.Errorf("display_names.%d %q is the same as display_names.%d, each block needs its own", i, displayNames[i], j)
+		}
+		seen[displayNames[i]] = i
+	}
+
+	if s.Vcn, e = s.Client.GetVirtualNetwork(vcnID); e != nil {
+		return
+	}
+
+	compartmentIDs := []string{s.Vcn.CompartmentID}
+	if v, ok := s.D.GetOk("compartment_id"); ok {
+		compartmentIDs = append(compartmentIDs, v.(string))
+	}
+	if s.Subnets, e = listVcnSubnets(s.Client, vcnID, compartmentIDs...); e != nil {
+		return
+	}
+
+	s.CIDRBlocks, e = allocateCIDRBlocks(s.Vcn.CidrBlock, s.Subnets, prefixLengths, displayNames)
+	return
+}
+
+func (s *VcnCIDRAllocationDatasourceCrud) SetData() {
+	if s.Vcn == nil {
+		return
+	}
+	// Important, if you don't have an ID, make one up for your datasource
+	// or things will end in tears
+	s.D.SetId(time.Now().UTC().String())
+
+	_, vcnNet, _ := net.ParseCIDR(s.Vcn.CidrBlock)
+	total := cidrSize(vcnNet)
+	used := 0
+	for _, subnet := range s.Subnets {
+		if _, subnetNet, err := net.ParseCIDR(subnet.CIDRBlock); err == nil {
+			used += cidrSize(subnetNet)
+		}
+	}
+
+	s.D.Set("compartment_id", s.Vcn.CompartmentID)
+	s.D.Set("cidr_block", s.Vcn.CidrBlock)
+	s.D.Set("cidr_blocks", s.CIDRBlocks)
+	s.D.Set("total_addresses", total)
+	s.D.Set("used_addresses", used)
+	s.D.Set("used_percent", 100*float64(used)/float64(total))
+	return
+}
+
+// allocateCIDRBlocks returns a block of each of prefixLengths within vcnCIDR
+// that overlaps neither subnets nor the other blocks.
+//
+// A request whose display name matches a subnet of the same prefix length
+// is given that subnet's block, so the result does not move once the
+// subnets are created. The other requests are placed in order, each at the
+// lowest free address, so appending a request does not move earlier ones.
+func allocateCIDRBlocks(vcnCIDR string, subnets []baremetal.Subnet, prefixLengths []int, displayNames []string) ([]string, error) {
+	_, vcnNet, err := net.ParseCIDR(vcnCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("VCN cidr_block %q is not a CIDR block", vcnCIDR)
+	}
+	vcnOnes, _ := vcnNet.Mask.Size()
+
+	used := []*net.IPNet{}
+	for _, subnet := range subnets {
+		if _, subnetNet, err := net.ParseCIDR(subnet.CIDRBlock); err == nil {
+			used = append(used, subnetNet)
+		}
+	}
+
+	blocks := make([]string, len(prefixLengths))
+	claimed := map[string]bool{}
+	for i, prefixLength := range prefixLengths {
+		for _, subnet := range subnets {
+			_, subnetNet, err := net.ParseCIDR(subnet.CIDRBlock)
+			if err != nil || claimed[subnet.ID] || subnet.DisplayName != displayNames[i] {
+				continue
+			}
+			if ones, _ := subnetNet.Mask.Size(); ones == prefixLength {
+				blocks[i] = subnetNet.String()
+				claimed[subnet.ID] = true
+				break
+			}
+		}
+	}
+
+	for i, prefixLength := range prefixLengths {
+		if blocks[i] != "" {
+			continue
+		}
+		if prefixLength < vcnOnes {
+			return nil, fmt.Errorf("prefix_lengths.%d: /%d is larger than the VCN cidr_block %s", i, prefixLength, vcnCIDR)
+		}
+		block := firstFreeBlock(vcnNet, used, prefixLength)
+		if block == nil {
+			return nil, fmt.Errorf("prefix_lengths.%d: no free /%d block is left in the VCN cidr_block %s", i, prefixLength, vcnCIDR)
+		}
+		used = append(used, block)
+		blocks[i] = block.String()
+	}
+	return blocks, nil
+}
+
+// firstFreeBlock returns the lowest block of prefixLength in within that
+// overlaps none of used, or nil if there is none.
+func firstFreeBlock(within *net.IPNet, used []*net.IPNet, prefixLength int) *net.IPNet {
+	mask := net.CIDRMask(prefixLength, 32)
+	size := uint64(1) << uint(32-prefixLength)
+	start := uint64(binary.BigEndian.Uint32(within.IP.To4()))
+	end := start + uint64(cidrSize(within))
+
+	for addr := start; addr+size <= end; {
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, uint32(addr))
+		candidate := &net.IPNet{IP: ip, Mask: mask}
+
+		next := addr + size
+		free := true
+		for _, u := range used {
+			if !cidrsOverlap(u.String(), candidate.String()) {
+				continue
+			}
+			free = false
+			// Skip past the end of the block in the way, keeping the
+			// candidate aligned.
+			uEnd := uint64(binary.BigEndian.Uint32(u.IP.To4())) + uint64(cidrSize(u))
+			if uEnd > next {
+				next = (uEnd + size - 1) / size * size
+			}
+		}
+		if free {
+			return candidate
+		}
+		addr = next
+	}
+	return nil
+}
+
+// cidrSize returns the number of addresses in an IPv4 network.
+func cidrSize(ipNet *net.IPNet) int {
+	ones, bits := ipNet.Mask.Size()
+	return 1 << uint(bits-ones)
+}
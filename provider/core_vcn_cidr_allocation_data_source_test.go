@@ -0,0 +1,185 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	baremetal "github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceCoreVcnCIDRAllocationTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceCoreVcnCIDRAllocationTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+
+	resource "oci_core_virtual_network" "t" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-vcn"
+		cidr_block = "10.0.0.0/16"
+	}
+
+	resource "oci_core_subnet" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		vcn_id = "${oci_core_virtual_network.t.id}"
+		security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+		route_table_id = "${oci_core_virtual_network.t.default_route_table_id}"
+		dhcp_options_id = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+		display_name = "-tf-subnet"
+		cidr_block = "10.0.1.0/24"
+	}`
+	s.ResourceName = "data.oci_core_vcn_cidr_allocation.t"
+}
+
+func (s *DatasourceCoreVcnCIDRAllocationTestSuite) TestAccDatasourceCoreVcnCIDRAllocation_basic() {
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+			},
+			{
+				Config: s.Config + `
+				data "oci_core_vcn_cidr_allocation" "t" {
+					vcn_id = "${oci_core_virtual_network.t.id}"
+					prefix_lengths = [24, 25, 24]
+					display_names = ["-tf-a", "-tf-b", "-tf-c"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_block", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.#", "3"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.0", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.1", "10.0.2.0/25"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.2", "10.0.3.0/24"),
+					resource.TestCheckResourceAttr(s.ResourceName, "total_addresses", "65536"),
+					resource.TestCheckResourceAttr(s.ResourceName, "used_addresses", "256"),
+					resource.TestCheckResourceAttr(s.ResourceName, "used_percent", "0.390625"),
+				),
+			},
+			// A request named after an existing subnet keeps that subnet's
+			// block, so creating the subnets does not move the blocks.
+			{
+				Config: s.Config + `
+				data "oci_core_vcn_cidr_allocation" "t" {
+					vcn_id = "${oci_core_virtual_network.t.id}"
+					prefix_lengths = [24, 24]
+					display_names = ["-tf-subnet", "-tf-other"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.0", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.1", "10.0.0.0/24"),
+				),
+			},
+			// Subnets of the VCN in the given compartment are not free.
+			{
+				Config: s.Config + `
+				resource "oci_identity_compartment" "other" {
+					name = "-tf-cidr-allocation"
+					description = "compartment of another subnet of the VCN"
+				}
+
+				resource "oci_core_subnet" "other" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${oci_identity_compartment.other.id}"
+					vcn_id = "${oci_core_virtual_network.t.id}"
+					security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+					route_table_id = "${oci_core_virtual_network.t.default_route_table_id}"
+					dhcp_options_id = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+					cidr_block = "10.0.0.0/24"
+				}
+
+				data "oci_core_vcn_cidr_allocation" "t" {
+					vcn_id = "${oci_core_subnet.other.vcn_id}"
+					compartment_id = "${oci_core_subnet.other.compartment_id}"
+					prefix_lengths = [24]
+					display_names = ["-tf-a"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_blocks.0", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr(s.ResourceName, "used_addresses", "512"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceCoreVcnCIDRAllocationTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceCoreVcnCIDRAllocationTestSuite))
+}
+
+func TestAllocateCIDRBlocks(t *testing.T) {
+	subnets := []baremetal.Subnet{
+		{ID: "a", DisplayName: "web", CIDRBlock: "10.0.0.0/24"},
+		{ID: "b", DisplayName: "db", CIDRBlock: "10.0.1.128/25"},
+	}
+
+	cases := []struct {
+		prefixLengths []int
+		displayNames  []string
+		expected      []string
+	}{
+		// Blocks are aligned and skip past the subnets in the way.
+		{[]int{24, 25, 23}, []string{"x", "y", "z"}, []string{"10.0.2.0/24", "10.0.1.0/25", "10.0.4.0/23"}},
+		// Appending a request leaves the earlier ones where they were.
+		{[]int{24, 25, 23, 26}, []string{"x", "y", "z", "w"}, []string{"10.0.2.0/24", "10.0.1.0/25", "10.0.4.0/23", "10.0.3.0/26"}},
+		// A /16 no longer fits.
+		{[]int{24, 25, 23, 16}, []string{"x", "y", "z", "w"}, nil},
+		// Requests named after subnets get their blocks.
+		{[]int{24, 25}, []string{"web", "db"}, []string{"10.0.0.0/24", "10.0.1.128/25"}},
+		// A subnet is only reused for a request of the same prefix length.
+		{[]int{25, 24}, []string{"web", "db"}, []string{"10.0.1.0/25", "10.0.2.0/24"}},
+	}
+	for _, c := range cases {
+		blocks, err := allocateCIDRBlocks("10.0.0.0/16", subnets, c.prefixLengths, c.displayNames)
+		if c.expected == nil {
+			if err == nil {
+				t.Errorf("Expected %v to fail, got %v", c.prefixLengths, blocks)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected %v to succeed, got %s", c.prefixLengths, err)
+			continue
+		}
+		if !reflect.DeepEqual(blocks, c.expected) {
+			t.Errorf("Expected %v for %v, got %v", c.expected, c.prefixLengths, blocks)
+		}
+	}
+
+	// Creating subnets for the blocks does not move them.
+	prefixLengths, displayNames := []int{24, 26, 24}, []string{"x", "y", "z"}
+	blocks, err := allocateCIDRBlocks("10.0.0.0/16", subnets, prefixLengths, displayNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := append([]baremetal.Subnet{}, subnets...)
+	for i, block := range blocks {
+		created = append(created, baremetal.Subnet{ID: displayNames[i], DisplayName: displayNames[i], CIDRBlock: block})
+	}
+	if again, err := allocateCIDRBlocks("10.0.0.0/16", created, prefixLengths, displayNames); err != nil || !reflect.DeepEqual(again, blocks) {
+		t.Errorf("Expected %v once the subnets are created, got %v, %v", blocks, again, err)
+	}
+
+	if _, err := allocateCIDRBlocks("10.0.0.0/24", nil, []int{25, 25, 25}, []string{"x", "y", "z"}); err == nil {
+		t.Error("Expected a full VCN to fail")
+	}
+}
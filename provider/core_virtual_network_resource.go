@@ -21,9 +21,10 @@ func VirtualNetworkResource() *schema.Resource {
 		Delete:   deleteVirtualNetwork,
 		Schema: map[string]*schema.Schema{
 			"cidr_block": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRBlockPrefix(16, 30),
 			},
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -52,6 +53,7 @@ func VirtualNetworkResource() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: crud.EqualIgnoreCaseSuppressDiff,
+				ValidateFunc:     validateDNSLabel,
 			},
 			"id": {
 				Type:     schema.TypeString,
@@ -114,6 +114,47 @@ func (s *ResourceCoreVirtualNetworkTestSuite) TestAccResourceCoreVirtualNetwork_
 	})
 }
 
+func (s *ResourceCoreVirtualNetworkTestSuite) TestAccResourceCoreVirtualNetwork_region() {
+	config := s.Config + `
+		resource "oci_core_virtual_network" "t" {
+			cidr_block = "10.0.0.0/16"
+			compartment_id = "${var.compartment_id}"
+			region = "us-ashburn-1"
+		}`
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// test create outside of the provider's region
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "region", "us-ashburn-1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			// test import with the region prefixed to the ID
+			{
+				Config:              config,
+				ImportState:         true,
+				ImportStateIdPrefix: "us-ashburn-1/",
+				ImportStateVerify:   true,
+				ResourceName:        s.ResourceName,
+			},
+			// test moving the vcn to the provider's region replaces it
+			{
+				Config: s.Config + `
+					resource "oci_core_virtual_network" "t" {
+						cidr_block = "10.0.0.0/16"
+						compartment_id = "${var.compartment_id}"
+						region = "us-phoenix-1"
+					}`,
+				ExpectNonEmptyPlan: true,
+				PlanOnly:           true,
+			},
+		},
+	})
+}
+
 func TestResourceCoreVirtualNetworkTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreVirtualNetworkTestSuite))
 }
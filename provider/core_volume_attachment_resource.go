@@ -3,6 +3,8 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/oracle/bmcs-go-sdk"
@@ -18,6 +20,7 @@ func VolumeAttachmentResource() *schema.Resource {
 		Timeouts: crud.DefaultTimeout,
 		Create:   createVolumeAttachment,
 		Read:     readVolumeAttachment,
+		Update:   updateVolumeAttachment,
 		Delete:   deleteVolumeAttachment,
 		Schema: map[string]*schema.Schema{
 			//// Required ////
@@ -42,6 +45,12 @@ func VolumeAttachmentResource() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			//// Optional ////
+			"wait_for_attached": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			//// Computed ////
 			"id": {
 				Type:     schema.TypeString,
@@ -66,8 +75,9 @@ func VolumeAttachmentResource() *schema.Resource {
 			},
 			// The following are only computed if type == "iscsi"
 			"chap_secret": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"chap_username": {
 				Type:     schema.TypeString,
@@ -86,6 +96,18 @@ func VolumeAttachmentResource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			// The attach commands include the CHAP secret.
+			"iscsi_attach_commands": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString, Sensitive: true},
+			},
+			"iscsi_detach_commands": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -106,6 +128,12 @@ func readVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
 	return crud.ReadResource(sync)
 }
 
+// updateVolumeAttachment only has wait_for_attached to change, which is not
+// sent to the service.
+func updateVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
+	return readVolumeAttachment(d, m)
+}
+
 func deleteVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(*OracleClients)
 	sync := &VolumeAttachmentResourceCrud{}
@@ -124,10 +152,18 @@ func (s *VolumeAttachmentResourceCrud) ID() string {
 }
 
 func (s *VolumeAttachmentResourceCrud) CreatedPending() []string {
+	if !s.D.Get("wait_for_attached").(bool) {
+		return []string{}
+	}
 	return []string{baremetal.ResourceAttaching}
 }
 
+// CreatedTarget accepts ATTACHING as well when wait_for_attached is off, so
+// creation returns as soon as the attach request is accepted.
 func (s *VolumeAttachmentResourceCrud) CreatedTarget() []string {
+	if !s.D.Get("wait_for_attached").(bool) {
+		return []string{baremetal.ResourceAttaching, baremetal.ResourceAttached}
+	}
 	return []string{baremetal.ResourceAttached}
 }
 
@@ -175,8 +211,44 @@ func (s *VolumeAttachmentResourceCrud) SetData() {
 	s.D.Set("ipv4", s.Res.IPv4)
 	s.D.Set("iqn", s.Res.IQN)
 	s.D.Set("port", s.Res.Port)
+	s.D.Set("iscsi_attach_commands", iscsiAttachCommands(s.Res))
+	s.D.Set("iscsi_detach_commands", iscsiDetachCommands(s.Res))
 }
 
 func (s *VolumeAttachmentResourceCrud) Delete() (e error) {
 	return s.Client.DetachVolume(s.D.Id(), nil)
 }
+
+// iscsiAttachCommands returns the commands that connect an instance to an
+// iSCSI attachment, as the console shows them. The CHAP commands are only
+// included when the attachment has CHAP credentials.
+func iscsiAttachCommands(res *baremetal.VolumeAttachment) []string {
+	if res.IQN == "" {
+		return []string{}
+	}
+	node := fmt.Sprintf("sudo iscsiadm -m node -T %s -p %s:%d", res.IQN, res.IPv4, res.Port)
+	commands := []string{
+		fmt.Sprintf("sudo iscsiadm -m node -o new -T %s -p %s:%d", res.IQN, res.IPv4, res.Port),
+		fmt.Sprintf("sudo iscsiadm -m node -o update -T %s -n node.startup -v automatic", res.IQN),
+	}
+	if res.CHAPUsername != "" {
+		commands = append(commands,
+			node+" -o update -n node.session.auth.authmethod -v CHAP",
+			node+" -o update -n node.session.auth.username -v "+res.CHAPUsername,
+			node+" -o update -n node.session.auth.password -v "+res.CHAPSecret,
+		)
+	}
+	return append(commands, node+" -l")
+}
+
+// iscsiDetachCommands returns the commands that disconnect an instance from
+// an iSCSI attachment. Run them before the attachment is destroyed.
+func iscsiDetachCommands(res *baremetal.VolumeAttachment) []string {
+	if res.IQN == "" {
+		return []string{}
+	}
+	return []string{
+		fmt.Sprintf("sudo iscsiadm -m node -T %s -p %s:%d -u", res.IQN, res.IPv4, res.Port),
+		fmt.Sprintf("sudo iscsiadm -m node -o delete -T %s -p %s:%d", res.IQN, res.IPv4, res.Port),
+	}
+}
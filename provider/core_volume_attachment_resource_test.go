@@ -3,6 +3,8 @@
 package provider
 
 import (
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -106,6 +108,65 @@ func (s *ResourceCoreVolumeAttachmentTestSuite) TestResourceCoreVolumeAttachment
 					resource.TestCheckResourceAttrSet(s.ResourceName, "port"),
 					resource.TestCheckResourceAttr(s.ResourceName, "attachment_type", "iscsi"),
 					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAttached),
+					resource.TestCheckResourceAttr(s.ResourceName, "wait_for_attached", "true"),
+					resource.TestCheckResourceAttr(s.ResourceName, "iscsi_attach_commands.#", "3"),
+					resource.TestMatchResourceAttr(s.ResourceName, "iscsi_attach_commands.2", regexp.MustCompile(`^sudo iscsiadm -m node -T iqn\.\S+ -p [0-9.]+:[0-9]+ -l$`)),
+					resource.TestCheckResourceAttr(s.ResourceName, "iscsi_detach_commands.#", "2"),
+				),
+			},
+			// Changing wait_for_attached does not replace the attachment.
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_attachment" "t" {
+					attachment_type = "iscsi"
+					compartment_id = "${var.compartment_id}"
+					instance_id = "${oci_core_instance.t.id}"
+					volume_id = "${oci_core_volume.t.id}"
+					wait_for_attached = false
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "wait_for_attached", "false"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAttached),
+				),
+			},
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_attachment" "t" {
+					attachment_type = "iscsi"
+					compartment_id = "${var.compartment_id}"
+					instance_id = "${oci_core_instance.t.id}"
+					volume_id = "${oci_core_volume.t.id}"
+					wait_for_attached = false
+				}`,
+				ResourceName:            s.ResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_attached"},
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreVolumeAttachmentTestSuite) TestResourceCoreVolumeAttachment_noWait() {
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_attachment" "t" {
+					attachment_type = "iscsi"
+					compartment_id = "${var.compartment_id}"
+					instance_id = "${oci_core_instance.t.id}"
+					volume_id = "${oci_core_volume.t.id}"
+					wait_for_attached = false
+					timeouts {
+						create = "10m"
+						delete = "10m"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAttaching),
+					resource.TestCheckResourceAttr(s.ResourceName, "iscsi_attach_commands.#", "3"),
 				),
 			},
 		},
@@ -115,3 +176,45 @@ func (s *ResourceCoreVolumeAttachmentTestSuite) TestResourceCoreVolumeAttachment
 func TestResourceCoreVolumeAttachmentTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreVolumeAttachmentTestSuite))
 }
+
+func TestISCSICommands(t *testing.T) {
+	res := &baremetal.VolumeAttachment{IQN: "iqn.2015-12.com.oracleiaas:abc", IPv4: "169.254.2.2", Port: 3260}
+
+	attach := iscsiAttachCommands(res)
+	expected := []string{
+		"sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.startup -v automatic",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -l",
+	}
+	if !reflect.DeepEqual(attach, expected) {
+		t.Errorf("Expected %q, got %q", expected, attach)
+	}
+
+	detach := iscsiDetachCommands(res)
+	expected = []string{
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -u",
+		"sudo iscsiadm -m node -o delete -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+	}
+	if !reflect.DeepEqual(detach, expected) {
+		t.Errorf("Expected %q, got %q", expected, detach)
+	}
+
+	res.CHAPUsername = "user"
+	res.CHAPSecret = "secret"
+	attach = iscsiAttachCommands(res)
+	expected = []string{
+		"sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.startup -v automatic",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -o update -n node.session.auth.authmethod -v CHAP",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -o update -n node.session.auth.username -v user",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -o update -n node.session.auth.password -v secret",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -l",
+	}
+	if !reflect.DeepEqual(attach, expected) {
+		t.Errorf("Expected %q, got %q", expected, attach)
+	}
+
+	if len(iscsiAttachCommands(&baremetal.VolumeAttachment{})) != 0 {
+		t.Error("Expected no commands without an IQN")
+	}
+}
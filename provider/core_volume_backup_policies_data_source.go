@@ -0,0 +1,102 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-oci/options"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func VolumeBackupPolicyDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readVolumeBackupPolicies,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"volume_backup_policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     VolumeBackupPolicyResource(),
+			},
+		},
+	}
+}
+
+func readVolumeBackupPolicies(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type VolumeBackupPolicyDatasourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.ListVolumeBackupPolicies
+}
+
+// Get lists the policies of compartment_id, or the Oracle-defined policies
+// when it is not set.
+func (s *VolumeBackupPolicyDatasourceCrud) Get() (e error) {
+	opts := &sdk.ListVolumeBackupPoliciesOptions{}
+	if val, ok := s.D.GetOk("compartment_id"); ok {
+		opts.CompartmentID = val.(string)
+	}
+
+	s.Res = &sdk.ListVolumeBackupPolicies{
+		VolumeBackupPolicies: []sdk.VolumeBackupPolicy{},
+	}
+
+	for {
+		var list *sdk.ListVolumeBackupPolicies
+		if list, e = s.Client.ListVolumeBackupPolicies(opts); e != nil {
+			break
+		}
+
+		s.Res.VolumeBackupPolicies = append(s.Res.VolumeBackupPolicies, list.VolumeBackupPolicies...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *VolumeBackupPolicyDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.VolumeBackupPolicies {
+		policy := map[string]interface{}{
+			"compartment_id": v.CompartmentID,
+			"display_name":   v.DisplayName,
+			"id":             v.ID,
+			"schedules":      volumeBackupSchedulesToMap(v.Schedules),
+			"time_created":   v.TimeCreated.String(),
+		}
+		resources = append(resources, policy)
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	if err := s.D.Set("volume_backup_policies", resources); err != nil {
+		panic(err)
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceCoreVolumeBackupPolicyTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceCoreVolumeBackupPolicyTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	resource "oci_core_volume_backup_policy" "t" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-backup-policy"
+		schedules {
+			backup_type = "INCREMENTAL"
+			period = "ONE_DAY"
+			retention_seconds = 604800
+		}
+	}`
+	s.ResourceName = "data.oci_core_volume_backup_policies.t"
+}
+
+func (s *DatasourceCoreVolumeBackupPolicyTestSuite) TestAccDatasourceCoreVolumeBackupPolicies_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_core_volume_backup_policies" "t" {
+					compartment_id = "${oci_core_volume_backup_policy.t.compartment_id}"
+					filter {
+						name = "id"
+						values = ["${oci_core_volume_backup_policy.t.id}"]
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.0.display_name", "-tf-backup-policy"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "volume_backup_policies.0.time_created"),
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.0.schedules.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.0.schedules.0.period", "ONE_DAY"),
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.0.schedules.0.retention_seconds", "604800"),
+				),
+			},
+			// Without a compartment the Oracle-defined policies are listed.
+			{
+				Config: s.Config + `
+				data "oci_core_volume_backup_policies" "t" {
+					filter {
+						name = "display_name"
+						values = ["silver"]
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policies.0.compartment_id", ""),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "volume_backup_policies.0.id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "volume_backup_policies.0.schedules.0.period"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceCoreVolumeBackupPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceCoreVolumeBackupPolicyTestSuite))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func VolumeBackupPolicyAssignmentResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createVolumeBackupPolicyAssignment,
+		Read:     readVolumeBackupPolicyAssignment,
+		Delete:   deleteVolumeBackupPolicyAssignment,
+		Schema: map[string]*schema.Schema{
+			"asset_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createVolumeBackupPolicyAssignment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyAssignmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.CreateResource(d, sync)
+}
+
+func readVolumeBackupPolicyAssignment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyAssignmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+func deleteVolumeBackupPolicyAssignment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyAssignmentResourceCrud{}
+	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
+	return sync.Delete()
+}
+
+type VolumeBackupPolicyAssignmentResourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.VolumeBackupPolicyAssignment
+}
+
+func (s *VolumeBackupPolicyAssignmentResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *VolumeBackupPolicyAssignmentResourceCrud) Create() (e error) {
+	assetID := s.D.Get("asset_id").(string)
+	policyID := s.D.Get("policy_id").(string)
+
+	s.Res, e = s.Client.CreateVolumeBackupPolicyAssignment(assetID, policyID, nil)
+	return
+}
+
+func (s *VolumeBackupPolicyAssignmentResourceCrud) Get() (e error) {
+	res, e := s.Client.GetVolumeBackupPolicyAssignment(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *VolumeBackupPolicyAssignmentResourceCrud) SetData() {
+	s.D.Set("asset_id", s.Res.AssetID)
+	s.D.Set("policy_id", s.Res.PolicyID)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *VolumeBackupPolicyAssignmentResourceCrud) Delete() (e error) {
+	return s.Client.DeleteVolumeBackupPolicyAssignment(s.D.Id(), nil)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+type ResourceCoreVolumeBackupPolicyAssignmentTestSuite struct {
+	suite.Suite
+	Client       *sdk.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreVolumeBackupPolicyAssignmentTestSuite) SetupTest() {
+	s.Client = GetTestProvider().client
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+	resource "oci_core_volume" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-volume"
+	}
+	resource "oci_core_volume_backup_policy" "t" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-backup-policy"
+		schedules {
+			backup_type = "INCREMENTAL"
+			period = "ONE_DAY"
+			retention_seconds = 604800
+		}
+	}
+	resource "oci_core_volume_backup_policy" "weekly" {
+		compartment_id = "${var.compartment_id}"
+		display_name = "-tf-backup-policy-weekly"
+		schedules {
+			backup_type = "FULL"
+			period = "ONE_WEEK"
+			retention_seconds = 2419200
+		}
+	}`
+	s.ResourceName = "oci_core_volume_backup_policy_assignment.t"
+}
+
+func (s *ResourceCoreVolumeBackupPolicyAssignmentTestSuite) TestAccResourceCoreVolumeBackupPolicyAssignment_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify create
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy_assignment" "t" {
+					asset_id = "${oci_core_volume.t.id}"
+					policy_id = "${oci_core_volume_backup_policy.t.id}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "asset_id", "oci_core_volume.t", "id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "policy_id", "oci_core_volume_backup_policy.t", "id"),
+					// A volume can have only one policy assigned.
+					func(ts *terraform.State) (err error) {
+						volumeID, _ := fromInstanceState(ts, "oci_core_volume.t", "id")
+						policyID, _ := fromInstanceState(ts, "oci_core_volume_backup_policy.weekly", "id")
+						if _, err = s.Client.CreateVolumeBackupPolicyAssignment(volumeID, policyID, nil); err == nil {
+							return fmt.Errorf("Expected a second assignment to volume %s to fail", volumeID)
+						}
+						return nil
+					},
+				),
+			},
+			// verify changing the policy replaces the assignment
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy_assignment" "t" {
+					asset_id = "${oci_core_volume.t.id}"
+					policy_id = "${oci_core_volume_backup_policy.weekly.id}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "policy_id", "oci_core_volume_backup_policy.weekly", "id"),
+				),
+			},
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy_assignment" "t" {
+					asset_id = "${oci_core_volume.t.id}"
+					policy_id = "${oci_core_volume_backup_policy.weekly.id}"
+				}`,
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceCoreVolumeBackupPolicyAssignmentTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreVolumeBackupPolicyAssignmentTestSuite))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/options"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func VolumeBackupPolicyAssignmentDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readVolumeBackupPolicyAssignments,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"asset_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"volume_backup_policy_assignments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     VolumeBackupPolicyAssignmentResource(),
+			},
+		},
+	}
+}
+
+func readVolumeBackupPolicyAssignments(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyAssignmentDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type VolumeBackupPolicyAssignmentDatasourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.ListVolumeBackupPolicyAssignments
+}
+
+func (s *VolumeBackupPolicyAssignmentDatasourceCrud) Get() (e error) {
+	assetID := s.D.Get("asset_id").(string)
+
+	opts := &baremetal.ListOptions{}
+
+	s.Res = &sdk.ListVolumeBackupPolicyAssignments{
+		VolumeBackupPolicyAssignments: []sdk.VolumeBackupPolicyAssignment{},
+	}
+
+	for {
+		var list *sdk.ListVolumeBackupPolicyAssignments
+		if list, e = s.Client.ListVolumeBackupPolicyAssignments(assetID, opts); e != nil {
+			break
+		}
+
+		s.Res.VolumeBackupPolicyAssignments = append(s.Res.VolumeBackupPolicyAssignments, list.VolumeBackupPolicyAssignments...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *VolumeBackupPolicyAssignmentDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.VolumeBackupPolicyAssignments {
+		assignment := map[string]interface{}{
+			"asset_id":     v.AssetID,
+			"id":           v.ID,
+			"policy_id":    v.PolicyID,
+			"time_created": v.TimeCreated.String(),
+		}
+		resources = append(resources, assignment)
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	if err := s.D.Set("volume_backup_policy_assignments", resources); err != nil {
+		panic(err)
+	}
+
+	return
+}
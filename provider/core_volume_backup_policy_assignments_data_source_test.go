@@ -0,0 +1,76 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceCoreVolumeBackupPolicyAssignmentTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceCoreVolumeBackupPolicyAssignmentTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+	resource "oci_core_volume" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+	}
+	resource "oci_core_volume_backup_policy" "t" {
+		compartment_id = "${var.compartment_id}"
+		schedules {
+			backup_type = "INCREMENTAL"
+			period = "ONE_DAY"
+			retention_seconds = 604800
+		}
+	}
+	resource "oci_core_volume_backup_policy_assignment" "t" {
+		asset_id = "${oci_core_volume.t.id}"
+		policy_id = "${oci_core_volume_backup_policy.t.id}"
+	}`
+	s.ResourceName = "data.oci_core_volume_backup_policy_assignments.t"
+}
+
+func (s *DatasourceCoreVolumeBackupPolicyAssignmentTestSuite) TestAccDatasourceCoreVolumeBackupPolicyAssignments_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_core_volume_backup_policy_assignments" "t" {
+					asset_id = "${oci_core_volume_backup_policy_assignment.t.asset_id}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "volume_backup_policy_assignments.#", "1"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "volume_backup_policy_assignments.0.id", "oci_core_volume_backup_policy_assignment.t", "id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "volume_backup_policy_assignments.0.policy_id", "oci_core_volume_backup_policy.t", "id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "volume_backup_policy_assignments.0.asset_id", "oci_core_volume.t", "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "volume_backup_policy_assignments.0.time_created"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceCoreVolumeBackupPolicyAssignmentTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceCoreVolumeBackupPolicyAssignmentTestSuite))
+}
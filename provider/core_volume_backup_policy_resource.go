@@ -0,0 +1,199 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+var volumeBackupScheduleSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"backup_type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				sdk.VolumeBackupTypeFull,
+				sdk.VolumeBackupTypeIncremental,
+			}, false),
+		},
+		"period": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				sdk.VolumeBackupPeriodOneDay,
+				sdk.VolumeBackupPeriodOneWeek,
+				sdk.VolumeBackupPeriodOneMonth,
+				sdk.VolumeBackupPeriodOneYear,
+			}, false),
+		},
+		"offset_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+		},
+		"retention_seconds": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+		},
+	},
+}
+
+func VolumeBackupPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createVolumeBackupPolicy,
+		Read:     readVolumeBackupPolicy,
+		Update:   updateVolumeBackupPolicy,
+		Delete:   deleteVolumeBackupPolicy,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"schedules": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     volumeBackupScheduleSchema,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.CreateResource(d, sync)
+}
+
+func readVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+func updateVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
+	return sync.Delete()
+}
+
+type VolumeBackupPolicyResourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.VolumeBackupPolicy
+}
+
+func (s *VolumeBackupPolicyResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Create() (e error) {
+	compartmentID := s.D.Get("compartment_id").(string)
+
+	opts := &baremetal.CreateOptions{}
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		opts.DisplayName = displayName.(string)
+	}
+
+	s.Res, e = s.Client.CreateVolumeBackupPolicy(compartmentID, s.schedules(), opts)
+	return
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Get() (e error) {
+	res, e := s.Client.GetVolumeBackupPolicy(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Update() (e error) {
+	opts := &sdk.UpdateVolumeBackupPolicyOptions{}
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		opts.DisplayName = displayName.(string)
+	}
+	if s.D.HasChange("schedules") {
+		opts.Schedules = s.schedules()
+	}
+
+	s.Res, e = s.Client.UpdateVolumeBackupPolicy(s.D.Id(), opts)
+	return
+}
+
+func (s *VolumeBackupPolicyResourceCrud) SetData() {
+	s.D.Set("compartment_id", s.Res.CompartmentID)
+	s.D.Set("display_name", s.Res.DisplayName)
+	s.D.Set("schedules", volumeBackupSchedulesToMap(s.Res.Schedules))
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Delete() (e error) {
+	return s.Client.DeleteVolumeBackupPolicy(s.D.Id(), nil)
+}
+
+func (s *VolumeBackupPolicyResourceCrud) schedules() []sdk.VolumeBackupSchedule {
+	schedules := []sdk.VolumeBackupSchedule{}
+	for _, v := range s.D.Get("schedules").([]interface{}) {
+		schedule := v.(map[string]interface{})
+		schedules = append(schedules, sdk.VolumeBackupSchedule{
+			BackupType:       schedule["backup_type"].(string),
+			OffsetSeconds:    schedule["offset_seconds"].(int),
+			Period:           schedule["period"].(string),
+			RetentionSeconds: schedule["retention_seconds"].(int),
+		})
+	}
+	return schedules
+}
+
+func volumeBackupSchedulesToMap(schedules []sdk.VolumeBackupSchedule) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, schedule := range schedules {
+		res = append(res, map[string]interface{}{
+			"backup_type":       schedule.BackupType,
+			"offset_seconds":    schedule.OffsetSeconds,
+			"period":            schedule.Period,
+			"retention_seconds": schedule.RetentionSeconds,
+		})
+	}
+	return res
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceCoreVolumeBackupPolicyTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreVolumeBackupPolicyTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig()
+	s.ResourceName = "oci_core_volume_backup_policy.t"
+}
+
+func (s *ResourceCoreVolumeBackupPolicyTestSuite) TestAccResourceCoreVolumeBackupPolicy_basic() {
+	defer onFakeOCI()()
+	var resId string
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify create
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy" "t" {
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-backup-policy"
+					schedules {
+						backup_type = "INCREMENTAL"
+						period = "ONE_DAY"
+						retention_seconds = 604800
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "-tf-backup-policy"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.0.backup_type", "INCREMENTAL"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.0.period", "ONE_DAY"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.0.offset_seconds", "0"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.0.retention_seconds", "604800"),
+					func(ts *terraform.State) (err error) {
+						resId, err = fromInstanceState(ts, s.ResourceName, "id")
+						return err
+					},
+				),
+			},
+			// verify update of the schedules in place
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy" "t" {
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-backup-policy"
+					schedules {
+						backup_type = "INCREMENTAL"
+						period = "ONE_DAY"
+						retention_seconds = 604800
+					}
+					schedules {
+						backup_type = "FULL"
+						period = "ONE_WEEK"
+						offset_seconds = 7200
+						retention_seconds = 2419200
+					}
+					schedules {
+						backup_type = "FULL"
+						period = "ONE_MONTH"
+						retention_seconds = 31536000
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.#", "3"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.1.backup_type", "FULL"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.1.period", "ONE_WEEK"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.1.offset_seconds", "7200"),
+					resource.TestCheckResourceAttr(s.ResourceName, "schedules.2.period", "ONE_MONTH"),
+					func(ts *terraform.State) (err error) {
+						resId2, err := fromInstanceState(ts, s.ResourceName, "id")
+						if resId != resId2 {
+							return fmt.Errorf("Expected the policy to be updated in place, got a new policy %s", resId2)
+						}
+						return err
+					},
+				),
+			},
+			{
+				Config: s.Config + `
+				resource "oci_core_volume_backup_policy" "t" {
+					compartment_id = "${var.compartment_id}"
+					display_name = "-tf-backup-policy"
+					schedules {
+						backup_type = "INCREMENTAL"
+						period = "ONE_DAY"
+						retention_seconds = 604800
+					}
+					schedules {
+						backup_type = "FULL"
+						period = "ONE_WEEK"
+						offset_seconds = 7200
+						retention_seconds = 2419200
+					}
+					schedules {
+						backup_type = "FULL"
+						period = "ONE_MONTH"
+						retention_seconds = 31536000
+					}
+				}`,
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceCoreVolumeBackupPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreVolumeBackupPolicyTestSuite))
+}
@@ -4,11 +4,15 @@ package provider
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func VolumeResource() *schema.Resource {
@@ -39,10 +43,10 @@ func VolumeResource() *schema.Resource {
 				Computed:   true,
 				Deprecated: "This property is deprecated, please use size_in_gbs",
 			},
+			// Growing a volume resizes it in place. Volumes cannot shrink.
 			"size_in_gbs": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"display_name": {
@@ -84,6 +88,10 @@ func VolumeResource() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								volumeSourceTypeVolume,
+								volumeSourceTypeVolumeBackup,
+							}, false),
 						},
 					},
 				},
@@ -92,6 +100,13 @@ func VolumeResource() *schema.Resource {
 	}
 }
 
+const (
+	// volumeSourceTypeVolume clones another volume.
+	volumeSourceTypeVolume = "volume"
+	// volumeSourceTypeVolumeBackup restores a volume backup.
+	volumeSourceTypeVolumeBackup = "volumeBackup"
+)
+
 func createVolume(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(*OracleClients)
 	sync := &VolumeResourceCrud{}
@@ -129,6 +144,27 @@ type VolumeResourceCrud struct {
 	Res *baremetal.Volume
 }
 
+// checkVolumeDiff rejects a smaller size_in_gbs during plan, as volumes cannot
+// shrink. A volume that is replaced anyway can take any size.
+func checkVolumeDiff(d *terraform.InstanceDiff) error {
+	attr, ok := d.Attributes["size_in_gbs"]
+	if !ok || attr.NewComputed || d.RequiresNew() {
+		return nil
+	}
+	oldSize, err := strconv.Atoi(attr.Old)
+	if err != nil {
+		return nil
+	}
+	newSize, err := strconv.Atoi(attr.New)
+	if err != nil {
+		return nil
+	}
+	if newSize < oldSize {
+		return fmt.Errorf("size_in_gbs cannot be reduced from %d to %d, volumes can only grow", oldSize, newSize)
+	}
+	return nil
+}
+
 func (s *VolumeResourceCrud) ID() string {
 	return s.Res.ID
 }
@@ -141,6 +177,15 @@ func (s *VolumeResourceCrud) CreatedTarget() []string {
 	return []string{baremetal.ResourceAvailable}
 }
 
+// A volume being resized is PROVISIONING until the new size is available.
+func (s *VolumeResourceCrud) UpdatedPending() []string {
+	return []string{baremetal.ResourceProvisioning}
+}
+
+func (s *VolumeResourceCrud) UpdatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
 func (s *VolumeResourceCrud) DeletedPending() []string {
 	return []string{baremetal.ResourceTerminating}
 }
@@ -184,16 +229,59 @@ func (s *VolumeResourceCrud) Create() (e error) {
 		sourceDetailsItem := sourceDetailsList.([]interface{})[0] // if listOk this is assured to have exactly 1 item
 		sdItem := sourceDetailsItem.(map[string]interface{})
 		opts.VolumeSourceDetails = &baremetal.VolumeSourceDetails{
-			sdItem["id"].(string),
-			sdItem["type"].(string),
+			Id:   sdItem["id"].(string),
+			Type: sdItem["type"].(string),
 		}
 	}
 
+	if e = s.checkSourceSize(opts); e != nil {
+		return
+	}
+
 	s.Res, e = s.Client.CreateVolume(availabilityDomain, compartmentID, opts)
 
 	return
 }
 
+// checkSourceSize fails early, with an error naming size_in_gbs, when a
+// volume cloned or restored from a source is set smaller than the source.
+func (s *VolumeResourceCrud) checkSourceSize(opts *baremetal.CreateVolumeOptions) (e error) {
+	if opts.SizeInGBs == 0 {
+		return
+	}
+
+	var sourceType, sourceID string
+	switch {
+	case opts.VolumeSourceDetails != nil:
+		sourceType, sourceID = opts.VolumeSourceDetails.Type, opts.VolumeSourceDetails.Id
+	case opts.VolumeBackupID != "":
+		sourceType, sourceID = "volumeBackup", opts.VolumeBackupID
+	default:
+		return
+	}
+
+	var sourceSize int
+	switch sourceType {
+	case "volume":
+		var source *baremetal.Volume
+		if source, e = s.Client.GetVolume(sourceID); e != nil {
+			return
+		}
+		sourceSize = source.SizeInGBs
+	case "volumeBackup":
+		var source *baremetal.VolumeBackup
+		if source, e = s.Client.GetVolumeBackup(sourceID); e != nil {
+			return
+		}
+		sourceSize = int(source.SizeInGBs)
+	}
+
+	if opts.SizeInGBs < sourceSize {
+		return fmt.Errorf("size_in_gbs %d is smaller than the %d GB %s %s it is created from", opts.SizeInGBs, sourceSize, sourceType, sourceID)
+	}
+	return
+}
+
 func (s *VolumeResourceCrud) Get() (e error) {
 	res, e := s.Client.GetVolume(s.D.Id())
 	if e == nil {
@@ -203,12 +291,17 @@ func (s *VolumeResourceCrud) Get() (e error) {
 }
 
 func (s *VolumeResourceCrud) Update() (e error) {
-	opts := &baremetal.UpdateOptions{}
+	opts := &sdk.UpdateVolumeOptions{}
 	displayName, ok := s.D.GetOk("display_name")
 	if ok {
 		opts.DisplayName = displayName.(string)
 	}
 
+	// Shrinking is rejected by checkVolumeDiff during plan
+	if s.D.HasChange("size_in_gbs") {
+		opts.SizeInGBs = s.D.Get("size_in_gbs").(int)
+	}
+
 	s.Res, e = s.Client.UpdateVolume(s.D.Id(), opts)
 
 	return
@@ -227,7 +320,7 @@ func (s *VolumeResourceCrud) SetData() {
 		vsd := make(map[string]interface{})
 		vsd["id"] = vsdRaw.Id
 		vsd["type"] = vsdRaw.Type
-		s.D.Set("source_details", vsd)
+		s.D.Set("source_details", []interface{}{vsd})
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
@@ -181,6 +182,159 @@ func (s *ResourceCoreVolumeTestSuite) TestCreateResourceCoreVolume_destructive()
 	})
 }
 
+func (s *ResourceCoreVolumeTestSuite) TestCreateResourceCoreVolume_resizeAndClone() {
+	var resId string
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 50
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "size_in_gbs", "50"),
+					func(s *terraform.State) (err error) {
+						resId, err = fromInstanceState(s, "oci_core_volume.t", "id")
+						return err
+					},
+				),
+			},
+			// verify growing the volume resizes it in place
+			{
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 100
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "size_in_gbs", "100"),
+					resource.TestCheckResourceAttr(s.ResourceName, "size_in_mbs", "102400"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+					func(s *terraform.State) (err error) {
+						resId2, err := fromInstanceState(s, "oci_core_volume.t", "id")
+						if resId != resId2 {
+							return fmt.Errorf("Expected the volume to be resized in place, got a new volume %s", resId2)
+						}
+						return err
+					},
+				),
+			},
+			// verify shrinking the volume is rejected during plan
+			{
+				PlanOnly: true,
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 50
+				}`,
+				ExpectError: regexp.MustCompile("size_in_gbs cannot be reduced from 100 to 50"),
+			},
+			// verify a clone without a size takes the size of its source
+			{
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 100
+				}
+				resource "oci_core_volume" "u" {
+					availability_domain = "${oci_core_volume.t.availability_domain}"
+					compartment_id = "${var.compartment_id}"
+					source_details {
+						type = "volume"
+						id = "${oci_core_volume.t.id}"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_volume.u", "size_in_gbs", "100"),
+					resource.TestCheckResourceAttr("oci_core_volume.u", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("oci_core_volume.u", "source_details.#", "1"),
+					resource.TestCheckResourceAttrPair("oci_core_volume.u", "source_details.0.id", "oci_core_volume.t", "id"),
+				),
+			},
+			// verify the clone can grow past its source
+			{
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 100
+				}
+				resource "oci_core_volume" "u" {
+					availability_domain = "${oci_core_volume.t.availability_domain}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 150
+					source_details {
+						type = "volume"
+						id = "${oci_core_volume.t.id}"
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_core_volume.u", "size_in_gbs", "150"),
+					resource.TestCheckResourceAttr(s.ResourceName, "size_in_gbs", "100"),
+				),
+			},
+			// verify a clone smaller than its source is rejected before it is created
+			{
+				Config: s.Config + `
+				resource "oci_core_volume" "t" {
+					availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 100
+				}
+				resource "oci_core_volume" "v" {
+					availability_domain = "${oci_core_volume.t.availability_domain}"
+					compartment_id = "${var.compartment_id}"
+					size_in_gbs = 60
+					source_details {
+						type = "volume"
+						id = "${oci_core_volume.t.id}"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("size_in_gbs 60 is smaller than the 100 GB volume"),
+			},
+		},
+	})
+}
+
+func TestVolumeDiffRejectsShrinking(t *testing.T) {
+	p := Provider(nil)
+	info := &terraform.InstanceInfo{Id: "oci_core_volume.t", Type: "oci_core_volume"}
+	state := &terraform.InstanceState{
+		ID: "ocid1.volume.oc1..x",
+		Attributes: map[string]string{
+			"availability_domain": "ad",
+			"compartment_id":      "ocid1.compartment.oc1..x",
+			"size_in_gbs":         "100",
+			"size_in_mbs":         "102400",
+		},
+	}
+	configWithSize := func(size int) *terraform.ResourceConfig {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"availability_domain": "ad",
+			"compartment_id":      "ocid1.compartment.oc1..x",
+			"size_in_gbs":         size,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return terraform.NewResourceConfig(raw)
+	}
+
+	if _, err := p.Diff(info, state, configWithSize(150)); err != nil {
+		t.Errorf("Expected growing the volume to plan, got %s", err)
+	}
+	_, err := p.Diff(info, state, configWithSize(50))
+	if err == nil || err.Error() != "oci_core_volume.t: size_in_gbs cannot be reduced from 100 to 50, volumes can only grow" {
+		t.Errorf("Expected shrinking the volume to fail the plan, got %v", err)
+	}
+}
+
 func TestResourceCoreVolumeTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreVolumeTestSuite))
 }
@@ -0,0 +1,211 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+// DBNodeActionResource applies a lifecycle action to an existing DB node.
+// Destroying the resource leaves the node in its current state.
+func DBNodeActionResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importDBNodeAction,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createDBNodeAction,
+		Read:     readDBNodeAction,
+		Update:   updateDBNodeAction,
+		Delete:   deleteDBNodeAction,
+		Schema: map[string]*schema.Schema{
+			"db_node_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(baremetal.DBNodeActionStart),
+					string(baremetal.DBNodeActionStop),
+					string(baremetal.DBNodeActionSoftReset),
+					string(baremetal.DBNodeActionReset),
+				}, false),
+			},
+			"trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"db_system_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vnic_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"backup_vnic_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.CreateResource(d, sync)
+}
+
+func readDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.ReadResource(sync)
+}
+
+func updateDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.DeleteResource(d, sync)
+}
+
+// importDBNodeAction imports by DB node OCID. The action is inferred from
+// the current state of the node.
+func importDBNodeAction(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	node, e := m.(*OracleClients).client.GetDBNode(d.Id())
+	if e != nil {
+		return nil, e
+	}
+
+	d.Set("db_node_id", node.ID)
+	if node.State == baremetal.ResourceStopped || node.State == baremetal.ResourceStopping {
+		d.Set("action", string(baremetal.DBNodeActionStop))
+	} else {
+		d.Set("action", string(baremetal.DBNodeActionStart))
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+type DBNodeActionResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.DBNode
+}
+
+func (s *DBNodeActionResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *DBNodeActionResourceCrud) CreatedPending() []string {
+	return s.UpdatedPending()
+}
+
+func (s *DBNodeActionResourceCrud) CreatedTarget() []string {
+	return s.UpdatedTarget()
+}
+
+func (s *DBNodeActionResourceCrud) UpdatedPending() []string {
+	return []string{
+		baremetal.ResourceProvisioning,
+		baremetal.ResourceStarting,
+		baremetal.ResourceStopping,
+	}
+}
+
+// UpdatedTarget is the state the node settles in after the configured
+// action. Resets return the node to AVAILABLE.
+func (s *DBNodeActionResourceCrud) UpdatedTarget() []string {
+	if baremetal.DBNodeAction(s.D.Get("action").(string)) == baremetal.DBNodeActionStop {
+		return []string{baremetal.ResourceStopped}
+	}
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *DBNodeActionResourceCrud) Create() (e error) {
+	return s.applyAction(s.D.Get("db_node_id").(string))
+}
+
+func (s *DBNodeActionResourceCrud) Get() (e error) {
+	res, e := s.Client.GetDBNode(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *DBNodeActionResourceCrud) Update() (e error) {
+	if !s.D.HasChange("action") && !s.D.HasChange("trigger") {
+		return s.Get()
+	}
+	return s.applyAction(s.D.Id())
+}
+
+// applyAction performs the configured action on the node. Starting or
+// stopping a node which is already in, or moving to, the target state is a
+// no-op, so changing trigger only repeats resets.
+func (s *DBNodeActionResourceCrud) applyAction(id string) (e error) {
+	if s.Res, e = s.Client.GetDBNode(id); e != nil {
+		return
+	}
+
+	action := baremetal.DBNodeAction(s.D.Get("action").(string))
+	switch {
+	case action == baremetal.DBNodeActionStart && (s.Res.State == baremetal.ResourceAvailable || s.Res.State == baremetal.ResourceStarting),
+		action == baremetal.DBNodeActionStop && (s.Res.State == baremetal.ResourceStopped || s.Res.State == baremetal.ResourceStopping):
+		log.Printf("[DEBUG] DB node already in target state. DB node ID: %q, State: %q", id, s.Res.State)
+		return
+	}
+
+	s.Res, e = s.Client.DBNodeAction(id, action, nil)
+	return
+}
+
+func (s *DBNodeActionResourceCrud) SetData() {
+	s.D.Set("db_node_id", s.Res.ID)
+	s.D.Set("db_system_id", s.Res.DBSystemID)
+	s.D.Set("hostname", s.Res.Hostname)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+	s.D.Set("vnic_id", s.Res.VnicID)
+	s.D.Set("backup_vnic_id", s.Res.BackupVnicID)
+}
+
+// Delete leaves the DB node running or stopped as it is; only the resource
+// is removed from state.
+func (s *DBNodeActionResourceCrud) Delete() (e error) {
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceDatabaseDBNodeActionTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceDatabaseDBNodeActionTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+
+	resource "oci_core_virtual_network" "t" {
+		compartment_id = "${var.compartment_id}"
+		cidr_block = "10.0.0.0/16"
+		display_name = "-tf-vcn"
+		dns_label = "tfvcn"
+	}
+
+	resource "oci_core_subnet" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		cidr_block          = "10.0.1.0/24"
+		display_name        = "-tf-subnet"
+		compartment_id      = "${var.compartment_id}"
+		vcn_id              = "${oci_core_virtual_network.t.id}"
+		route_table_id      = "${oci_core_virtual_network.t.default_route_table_id}"
+		dhcp_options_id     = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+		security_list_ids   = ["${oci_core_virtual_network.t.default_security_list_id}"]
+		dns_label           = "tfsubnet"
+	}
+
+	resource "oci_database_db_system" "t" {
+		availability_domain = "${data.oci_identity_availability_domains.ADs.availability_domains.0.name}"
+		compartment_id = "${var.compartment_id}"
+		subnet_id = "${oci_core_subnet.t.id}"
+		database_edition = "ENTERPRISE_EDITION"
+		disk_redundancy = "NORMAL"
+		shape = "VM.Standard1.1"
+		cpu_core_count = "1"
+		ssh_public_keys = ["${var.ssh_public_key}"]
+		display_name = "-tf-db-system"
+		domain = "${oci_core_subnet.t.dns_label}.${oci_core_virtual_network.t.dns_label}.oraclevcn.com"
+		hostname = "myOracleDB"
+		data_storage_size_in_gb = "256"
+		license_model = "LICENSE_INCLUDED"
+		node_count = "1"
+		db_home {
+			db_version = "12.1.0.2"
+			display_name = "-tf-db-home"
+			database {
+				"admin_password" = "BEstrO0ng_#11"
+				"db_name" = "aTFdb"
+			}
+		}
+	}
+
+	data "oci_database_db_nodes" "t" {
+		compartment_id = "${var.compartment_id}"
+		db_system_id = "${oci_database_db_system.t.id}"
+	}`
+	s.ResourceName = "oci_database_db_node_action.t"
+}
+
+func (s *ResourceDatabaseDBNodeActionTestSuite) TestAccResourceDatabaseDBNodeAction_basic() {
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify stop
+			{
+				Config: s.Config + `
+				resource "oci_database_db_node_action" "t" {
+					db_node_id = "${lookup(data.oci_database_db_nodes.t.db_nodes[0], "id")}"
+					action = "STOP"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "db_system_id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "hostname"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceStopped),
+				),
+			},
+			// verify start on the same node
+			{
+				Config: s.Config + `
+				resource "oci_database_db_node_action" "t" {
+					db_node_id = "${lookup(data.oci_database_db_nodes.t.db_nodes[0], "id")}"
+					action = "START"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "action", "START"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			// verify soft reset returns the node to available
+			{
+				Config: s.Config + `
+				resource "oci_database_db_node_action" "t" {
+					db_node_id = "${lookup(data.oci_database_db_nodes.t.db_nodes[0], "id")}"
+					action = "SOFTRESET"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			// verify changing trigger repeats the soft reset
+			{
+				Config: s.Config + `
+				resource "oci_database_db_node_action" "t" {
+					db_node_id = "${lookup(data.oci_database_db_nodes.t.db_nodes[0], "id")}"
+					action = "SOFTRESET"
+					trigger = "2"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "trigger", "2"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			// verify import
+			{
+				Config: s.Config + `
+				resource "oci_database_db_node_action" "t" {
+					db_node_id = "${lookup(data.oci_database_db_nodes.t.db_nodes[0], "id")}"
+					action = "SOFTRESET"
+					trigger = "2"
+				}`,
+				ResourceName:            s.ResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"action", "trigger"},
+			},
+		},
+	})
+}
+
+func TestResourceDatabaseDBNodeActionTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceDatabaseDBNodeActionTestSuite))
+}
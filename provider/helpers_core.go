@@ -3,7 +3,14 @@
 package provider
 
 import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 )
 
@@ -21,9 +28,10 @@ var createVnicDetailsSchema = &schema.Resource{
 			Computed: true,
 		},
 		"hostname_label": {
-			Type:     schema.TypeString,
-			Optional: true,
-			Computed: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateHostnameLabel,
 		},
 		"private_ip": {
 			Type:     schema.TypeString,
@@ -115,3 +123,143 @@ func RefreshCreateVnicDetails(resourceData *schema.ResourceData, vnic *baremetal
 	vnicDetails["skip_source_dest_check"] = vnic.SkipSourceDestCheck
 	resourceData.Set("create_vnic_details", []interface{}{vnicDetails})
 }
+
+// canonicalCIDR returns cidr with its host bits cleared, the way OCI stores
+// it: 10.0.0.5/24 becomes 10.0.0.0/24. Anything that is not a CIDR block is
+// returned unchanged.
+func canonicalCIDR(cidr string) string {
+	if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+		return ipNet.String()
+	}
+	return cidr
+}
+
+func suppressEquivalentCIDRDiff(k, old, new string, d *schema.ResourceData) bool {
+	return canonicalCIDR(old) == canonicalCIDR(new)
+}
+
+var (
+	// dnsLabelRegexp matches the labels OCI accepts for VCNs and subnets.
+	dnsLabelRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]{0,14}$`)
+	// hostnameLabelRegexp matches RFC 952 and RFC 1123 host names.
+	hostnameLabelRegexp = regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// validateCIDRBlock checks that an attribute is an IPv4 CIDR block.
+func validateCIDRBlock(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	ip, _, err := net.ParseCIDR(v)
+	if err != nil || ip.To4() == nil {
+		es = append(es, fmt.Errorf("expected %s to be an IPv4 CIDR block such as 10.0.0.0/16, got %q", k, v))
+	}
+	return
+}
+
+// validateCIDRBlockPrefix returns a ValidateFunc that checks that an
+// attribute is an IPv4 CIDR block with a prefix length between min and max.
+func validateCIDRBlockPrefix(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		if s, es = validateCIDRBlock(i, k); len(es) > 0 {
+			return
+		}
+
+		v := i.(string)
+		_, ipNet, _ := net.ParseCIDR(v)
+		if ones, _ := ipNet.Mask.Size(); ones < min || ones > max {
+			es = append(es, fmt.Errorf("expected %s to have a prefix length between /%d and /%d, got %q", k, min, max, v))
+		}
+		return
+	}
+}
+
+func validateDNSLabel(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !dnsLabelRegexp.MatchString(v) {
+		es = append(es, fmt.Errorf("expected %s to start with a letter and contain only letters and numbers, at most 15 characters, got %q", k, v))
+	}
+	return
+}
+
+func validateHostnameLabel(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !hostnameLabelRegexp.MatchString(v) {
+		es = append(es, fmt.Errorf("expected %s to start with a letter, contain only letters, numbers and hyphens, not end with a hyphen and be at most 63 characters, got %q", k, v))
+	}
+	return
+}
+
+// cidrContains reports whether every address of inner is also in outer.
+func cidrContains(outer, inner string) bool {
+	_, outerNet, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false
+	}
+	_, innerNet, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false
+	}
+	outerOnes, _ := outerNet.Mask.Size()
+	innerOnes, _ := innerNet.Mask.Size()
+	return outerOnes <= innerOnes && outerNet.Contains(innerNet.IP)
+}
+
+// cidrsOverlap reports whether a and b share any address.
+func cidrsOverlap(a, b string) bool {
+	return cidrContains(a, b) || cidrContains(b, a)
+}
+
+// migrateListToSet rewrites the state of an attribute that changed from a
+// list of elem to a set of elem, re-keying each element from its index to
+// its hash.
+func migrateListToSet(is *terraform.InstanceState, key string, elem *schema.Resource, hash schema.SchemaSetFunc) {
+	prefix := key + "."
+	elements := map[string]map[string]string{}
+	for k, v := range is.Attributes {
+		if !strings.HasPrefix(k, prefix) || k == prefix+"#" {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(k, prefix), ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if elements[parts[0]] == nil {
+			elements[parts[0]] = map[string]string{}
+		}
+		elements[parts[0]][parts[1]] = v
+		delete(is.Attributes, k)
+	}
+
+	codes := map[string]bool{}
+	for _, attrs := range elements {
+		reader := &schema.MapFieldReader{Schema: elem.Schema, Map: schema.BasicMapReader(attrs)}
+		value := map[string]interface{}{}
+		for field := range elem.Schema {
+			if res, err := reader.ReadField([]string{field}); err == nil && res.Exists {
+				value[field] = res.Value
+			}
+		}
+		code := strconv.Itoa(hash(value))
+		codes[code] = true
+		for k, v := range attrs {
+			is.Attributes[prefix+code+"."+k] = v
+		}
+	}
+	if _, ok := is.Attributes[prefix+"#"]; ok {
+		is.Attributes[prefix+"#"] = strconv.Itoa(len(codes))
+	}
+}
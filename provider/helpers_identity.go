@@ -2,7 +2,13 @@
 
 package provider
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-oci/policy"
+)
 
 // User and group happen to have the same schema and share this
 var baseIdentitySchema = map[string]*schema.Schema{
@@ -41,3 +47,18 @@ var baseIdentitySchema = map[string]*schema.Schema{
 		Computed: true,
 	},
 }
+
+// validatePolicyStatement checks a statement against the policy language at
+// plan time, rather than leaving typos to be rejected by the service.
+func validatePolicyStatement(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := policy.Parse(v); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
@@ -7,10 +7,19 @@ import (
 
 	"github.com/oracle/bmcs-go-sdk"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/oracle/terraform-provider-oci/crud"
 	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// States reported while waiting for a compartment to move, which does not
+// change its lifecycle state.
+const (
+	compartmentMoving = "MOVING"
+	compartmentMoved  = "MOVED"
 )
 
 // ResourceIdentityCompartment exposes an IdentityCompartment Resource
@@ -28,10 +37,20 @@ func CompartmentResource() *schema.Resource {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		// The parent compartment, which defaults to the tenancy. Changing it
+		// moves the compartment.
 		"compartment_id": {
 			Type:     schema.TypeString,
+			Optional: true,
 			Computed: true,
 		},
+		// Compartments are only deleted on destroy when enable_delete is
+		// set; otherwise they are left in place.
+		"enable_delete": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
 		"state": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -88,8 +107,15 @@ func updateCompartment(d *schema.ResourceData, m interface{}) (e error) {
 }
 
 func deleteCompartment(d *schema.ResourceData, m interface{}) (e error) {
+	if !d.Get("enable_delete").(bool) {
+		d.SetId("")
+		return
+	}
+
+	client := m.(*OracleClients)
 	sync := &CompartmentResourceCrud{}
 	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
 	return crud.DeleteResource(d, sync)
 }
 
@@ -115,9 +141,20 @@ func (s *CompartmentResourceCrud) CreatedTarget() []string {
 	return []string{baremetal.ResourceActive}
 }
 
+func (s *CompartmentResourceCrud) DeletedPending() []string {
+	return []string{baremetal.ResourceDeleting}
+}
+
+func (s *CompartmentResourceCrud) DeletedTarget() []string {
+	return []string{baremetal.ResourceDeleted}
+}
+
 func listAllCompartments(s *CompartmentResourceCrud) (result *baremetal.ListCompartments, e error) {
-	opts := &baremetal.ListOptions{}
-	options.SetListOptions(s.D, opts)
+	opts := &sdk.ListCompartmentsOptions{}
+	options.SetListOptions(s.D, &opts.ListOptions)
+	if parentID, ok := s.D.GetOk("compartment_id"); ok {
+		opts.CompartmentID = parentID.(string)
+	}
 
 	result = &baremetal.ListCompartments{Compartments: []baremetal.Compartment{}}
 
@@ -139,9 +176,15 @@ func listAllCompartments(s *CompartmentResourceCrud) (result *baremetal.ListComp
 func (s *CompartmentResourceCrud) Create() (e error) {
 	name := s.D.Get("name").(string)
 	description := s.D.Get("description").(string)
-	s.Res, e = s.Client.CreateCompartment(name, description, nil)
-	// Compartments can't be destroyed, so we shouldn't complain about them being created.
-	if e != nil && strings.Contains(e.Error(), "already exists") {
+	opts := &sdk.CreateCompartmentOptions{}
+	if parentID, ok := s.D.GetOk("compartment_id"); ok {
+		opts.CompartmentID = parentID.(string)
+	}
+	s.Res, e = s.Client.CreateCompartment(name, description, opts)
+	// Compartments are kept on destroy unless enable_delete is set, so an
+	// existing compartment is adopted rather than reported. A compartment
+	// which may be deleted must have been created by this resource.
+	if e != nil && strings.Contains(e.Error(), "already exists") && !s.D.Get("enable_delete").(bool) {
 		e = nil
 		list, err := listAllCompartments(s)
 		if err != nil {
@@ -167,6 +210,16 @@ func (s *CompartmentResourceCrud) Get() (e error) {
 }
 
 func (s *CompartmentResourceCrud) Update() (e error) {
+	if s.D.HasChange("compartment_id") {
+		if e = s.move(s.D.Get("compartment_id").(string)); e != nil {
+			return
+		}
+		s.D.SetPartial("compartment_id")
+	}
+	if !s.D.HasChange("name") && !s.D.HasChange("description") {
+		return s.Get()
+	}
+
 	opts := &baremetal.UpdateCompartmentOptions{}
 	if name, ok := s.D.GetOk("name"); ok {
 		opts.Name = name.(string)
@@ -178,12 +231,35 @@ func (s *CompartmentResourceCrud) Update() (e error) {
 	return
 }
 
-func (s *CompartmentResourceCrud) Delete() (e error) {
-	// Compartments cannot be deleted. Just pretend it worked.
-	e = nil
+// move moves the compartment below parentID, and waits for the move to
+// complete.
+func (s *CompartmentResourceCrud) move(parentID string) (e error) {
+	if e = s.Client.MoveCompartment(s.D.Id(), parentID, nil); e != nil {
+		return
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{compartmentMoving},
+		Target:  []string{compartmentMoved},
+		Refresh: func() (interface{}, string, error) {
+			if e := s.Get(); e != nil {
+				return nil, "", e
+			}
+			if s.Res.CompartmentID != parentID {
+				return s.Res, compartmentMoving, nil
+			}
+			return s.Res, compartmentMoved, nil
+		},
+		Timeout: s.D.Timeout(schema.TimeoutUpdate),
+	}
+	_, e = stateConf.WaitForState()
 	return
 }
 
+func (s *CompartmentResourceCrud) Delete() (e error) {
+	return s.Client.DeleteCompartment(s.D.Id(), nil)
+}
+
 func (s *CompartmentResourceCrud) SetData() {
 	s.D.Set("name", s.Res.Name)
 	s.D.Set("description", s.Res.Description)
@@ -3,6 +3,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -86,6 +87,97 @@ func (s *ResourceIdentityCompartmentTestSuite) TestAccResourceIdentityCompartmen
 	})
 }
 
+func (s *ResourceIdentityCompartmentTestSuite) TestAccResourceIdentityCompartment_nested() {
+	var childID string
+	_, tokenFn := tokenize()
+	parentsConfig := s.Config + tokenFn(`
+	resource "oci_identity_compartment" "parent" {
+		name = "-tf-parent-{{.token}}"
+		description = "tf test parent compartment"
+		enable_delete = true
+	}
+
+	resource "oci_identity_compartment" "parent2" {
+		name = "-tf-parent2-{{.token}}"
+		description = "tf test parent compartment"
+		enable_delete = true
+	}`, nil)
+	movedConfig := parentsConfig + `
+	resource "oci_identity_compartment" "t" {
+		compartment_id = "${oci_identity_compartment.parent2.id}"
+		name = "-tf-child"
+		description = "tf test child compartment"
+		enable_delete = true
+	}`
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		CheckDestroy: func(ts *terraform.State) error {
+			for _, rs := range ts.RootModule().Resources {
+				if rs.Type != "oci_identity_compartment" {
+					continue
+				}
+				res, err := s.Client.GetCompartment(rs.Primary.ID)
+				if err == nil && res.State != baremetal.ResourceDeleted {
+					return fmt.Errorf("Expected compartment %s to be deleted, got %s", rs.Primary.ID, res.State)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// verify create below a parent compartment
+			{
+				Config: parentsConfig + `
+				resource "oci_identity_compartment" "t" {
+					compartment_id = "${oci_identity_compartment.parent.id}"
+					name = "-tf-child"
+					description = "tf test child compartment"
+					enable_delete = true
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceActive),
+					resource.TestCheckResourceAttr(s.ResourceName, "enable_delete", "true"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "compartment_id", "oci_identity_compartment.parent", "id"),
+					resource.TestCheckResourceAttr("oci_identity_compartment.parent", "compartment_id", getRequiredEnvSetting("tenancy_ocid")),
+					func(ts *terraform.State) (err error) {
+						childID, err = fromInstanceState(ts, s.ResourceName, "id")
+						return err
+					},
+				),
+			},
+			// verify the compartment moves in place
+			{
+				Config: movedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "compartment_id", "oci_identity_compartment.parent2", "id"),
+					func(ts *terraform.State) error {
+						return resource.TestCheckResourceAttr(s.ResourceName, "id", childID)(ts)
+					},
+				),
+			},
+			// verify a compartment which may be deleted is not adopted
+			{
+				Config: movedConfig + `
+				resource "oci_identity_compartment" "duplicate" {
+					compartment_id = "${oci_identity_compartment.parent2.id}"
+					name = "-tf-child"
+					description = "tf test child compartment"
+					enable_delete = true
+				}`,
+				ExpectError: regexp.MustCompile("already exists"),
+			},
+			// verify resource import
+			{
+				Config:                  movedConfig,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"enable_delete"},
+				ResourceName:            s.ResourceName,
+			},
+		},
+	})
+}
+
 func TestResourceIdentityCompartmentTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceIdentityCompartmentTestSuite))
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-oci/crud"
 	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func CompartmentDatasource() *schema.Resource {
@@ -44,8 +45,8 @@ type CompartmentDatasourceCrud struct {
 }
 
 func (s *CompartmentDatasourceCrud) Get() (e error) {
-	opts := &baremetal.ListOptions{}
-	options.SetListOptions(s.D, opts)
+	opts := &sdk.ListCompartmentsOptions{}
+	options.SetListOptions(s.D, &opts.ListOptions)
 
 	s.Res = &baremetal.ListCompartments{Compartments: []baremetal.Compartment{}}
 
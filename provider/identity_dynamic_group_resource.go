@@ -0,0 +1,173 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// DynamicGroupResource manages a group of instances selected by a matching
+// rule, which policies can then grant access to.
+func DynamicGroupResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createDynamicGroup,
+		Read:     readDynamicGroup,
+		Update:   updateDynamicGroup,
+		Delete:   deleteDynamicGroup,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"matching_rule": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"inactive_state": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDynamicGroup(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &DynamicGroupSync{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.CreateResource(d, sync)
+}
+
+func readDynamicGroup(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &DynamicGroupSync{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+func updateDynamicGroup(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &DynamicGroupSync{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteDynamicGroup(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &DynamicGroupSync{}
+	sync.D = d
+	sync.Client = client.clientWithoutNotFoundRetries
+	return sync.Delete()
+}
+
+type DynamicGroupSync struct {
+	*crud.IdentitySync
+	crud.BaseCrud
+	Res *sdk.DynamicGroup
+}
+
+func (s *DynamicGroupSync) ID() string {
+	return s.Res.ID
+}
+
+func (s *DynamicGroupSync) State() string {
+	return s.Res.State
+}
+
+func (s *DynamicGroupSync) CreatedPending() []string {
+	return []string{baremetal.ResourceCreating}
+}
+
+func (s *DynamicGroupSync) CreatedTarget() []string {
+	return []string{baremetal.ResourceActive}
+}
+
+func (s *DynamicGroupSync) DeletedPending() []string {
+	return []string{baremetal.ResourceDeleting}
+}
+
+func (s *DynamicGroupSync) DeletedTarget() []string {
+	return []string{baremetal.ResourceDeleted}
+}
+
+func (s *DynamicGroupSync) ExtraWaitPostCreateDelete() time.Duration {
+	return time.Duration(2 * time.Second)
+}
+
+func (s *DynamicGroupSync) Create() (e error) {
+	name := s.D.Get("name").(string)
+	matchingRule := s.D.Get("matching_rule").(string)
+	description := s.D.Get("description").(string)
+	s.Res, e = s.Client.CreateDynamicGroup(name, matchingRule, description, nil)
+	return
+}
+
+func (s *DynamicGroupSync) Get() (e error) {
+	res, e := s.Client.GetDynamicGroup(s.D.Id())
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *DynamicGroupSync) Update() (e error) {
+	opts := &sdk.UpdateDynamicGroupOptions{}
+	if description, ok := s.D.GetOk("description"); ok {
+		opts.Description = description.(string)
+	}
+	if matchingRule, ok := s.D.GetOk("matching_rule"); ok {
+		opts.MatchingRule = matchingRule.(string)
+	}
+
+	s.Res, e = s.Client.UpdateDynamicGroup(s.D.Id(), opts)
+	return
+}
+
+func (s *DynamicGroupSync) SetData() {
+	s.D.Set("name", s.Res.Name)
+	s.D.Set("description", s.Res.Description)
+	s.D.Set("matching_rule", s.Res.MatchingRule)
+	s.D.Set("compartment_id", s.Res.CompartmentID)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("inactive_state", s.Res.InactiveStatus)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *DynamicGroupSync) Delete() (e error) {
+	return s.Client.DeleteDynamicGroup(s.D.Id(), nil)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceIdentityDynamicGroupTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceIdentityDynamicGroupTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig()
+	s.ResourceName = "oci_identity_dynamic_group.t"
+}
+
+func (s *ResourceIdentityDynamicGroupTestSuite) TestAccResourceIdentityDynamicGroup_basic() {
+	defer onFakeOCI()()
+	token, tokenFn := tokenize()
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify create
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				Config: s.Config + tokenFn(`
+				resource "oci_identity_dynamic_group" "t" {
+					name = "{{.token}}"
+					description = "tf test dynamic group"
+					matching_rule = "instance.compartment.id = '${var.compartment_id}'"
+				}`, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "name", token),
+					resource.TestCheckResourceAttr(s.ResourceName, "description", "tf test dynamic group"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_rule", "instance.compartment.id = '"+getEnvSetting("compartment_id", "")+"'"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceActive),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "compartment_id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+				),
+			},
+			// verify update
+			{
+				Config: s.Config + tokenFn(`
+				resource "oci_identity_dynamic_group" "t" {
+					name = "{{.token}}"
+					description = "tf test dynamic group (updated)"
+					matching_rule = "ANY {instance.compartment.id = '${var.compartment_id}', instance.id = 'ocid1.instance.oc1..fake'}"
+				}`, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "description", "tf test dynamic group (updated)"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_rule", "ANY {instance.compartment.id = '"+getEnvSetting("compartment_id", "")+"', instance.id = 'ocid1.instance.oc1..fake'}"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceIdentityDynamicGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceIdentityDynamicGroupTestSuite))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+func DynamicGroupDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readDynamicGroups,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"dynamic_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     DynamicGroupResource(),
+			},
+		},
+	}
+}
+
+func readDynamicGroups(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &DynamicGroupDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type DynamicGroupDatasourceCrud struct {
+	crud.BaseCrud
+	Res *sdk.ListDynamicGroups
+}
+
+func (s *DynamicGroupDatasourceCrud) Get() (e error) {
+	opts := &baremetal.ListOptions{}
+	options.SetListOptions(s.D, opts)
+
+	s.Res = &sdk.ListDynamicGroups{DynamicGroups: []sdk.DynamicGroup{}}
+
+	for {
+		var list *sdk.ListDynamicGroups
+		if list, e = s.Client.ListDynamicGroups(opts); e != nil {
+			break
+		}
+
+		s.Res.DynamicGroups = append(s.Res.DynamicGroups, list.DynamicGroups...)
+
+		if hasNexPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNexPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *DynamicGroupDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.DynamicGroups {
+		res := map[string]interface{}{
+			"compartment_id": v.CompartmentID,
+			"description":    v.Description,
+			"id":             v.ID,
+			"inactive_state": v.InactiveStatus,
+			"matching_rule":  v.MatchingRule,
+			"name":           v.Name,
+			"state":          v.State,
+			"time_created":   v.TimeCreated.String(),
+		}
+
+		resources = append(resources, res)
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	if err := s.D.Set("dynamic_groups", resources); err != nil {
+		panic(err)
+	}
+
+	return
+}
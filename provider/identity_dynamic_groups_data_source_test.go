@@ -0,0 +1,76 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceIdentityDynamicGroupsTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+	Token        string
+	TokenFn      TokenFn
+}
+
+func (s *DatasourceIdentityDynamicGroupsTestSuite) SetupTest() {
+	s.Token, s.TokenFn = tokenize()
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + s.TokenFn(`
+	resource "oci_identity_dynamic_group" "t" {
+		name = "{{.token}}"
+		description = "automated test dynamic group"
+		matching_rule = "instance.compartment.id = '${var.compartment_id}'"
+	}`, nil)
+	s.ResourceName = "data.oci_identity_dynamic_groups.t"
+}
+
+func (s *DatasourceIdentityDynamicGroupsTestSuite) TestAccDatasourceIdentityDynamicGroups_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+			},
+			{
+				Config: s.Config + s.TokenFn(`
+				data "oci_identity_dynamic_groups" "t" {
+					compartment_id = "${var.tenancy_ocid}"
+					filter {
+						name   = "name"
+						values = ["{{.token}}"]
+					}
+				}`, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "dynamic_groups.#", "1"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "dynamic_groups.0.id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "dynamic_groups.0.compartment_id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "dynamic_groups.0.time_created"),
+					resource.TestCheckResourceAttr(s.ResourceName, "dynamic_groups.0.name", s.Token),
+					resource.TestCheckResourceAttr(s.ResourceName, "dynamic_groups.0.description", "automated test dynamic group"),
+					resource.TestCheckResourceAttr(s.ResourceName, "dynamic_groups.0.matching_rule", "instance.compartment.id = '"+getEnvSetting("compartment_id", "")+"'"),
+					resource.TestCheckResourceAttr(s.ResourceName, "dynamic_groups.0.state", "ACTIVE"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceIdentityDynamicGroupsTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceIdentityDynamicGroupsTestSuite))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-oci/crud"
 	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func IdentityPolicyDatasource() *schema.Resource {
@@ -49,15 +50,22 @@ func (s *IdentityPolicyDatasourceCrud) Get() (e error) {
 	opts := &baremetal.ListOptions{}
 	options.SetListOptions(s.D, opts)
 
-	s.Res = &baremetal.ListPolicies{Policies: []baremetal.Policy{}}
+	s.Res, e = listPolicies(s.Client, compartment_id, opts)
+	return
+}
+
+// listPolicies lists the policies attached to a compartment, following
+// pages from opts.
+func listPolicies(client *sdk.Client, compartmentID string, opts *baremetal.ListOptions) (result *baremetal.ListPolicies, e error) {
+	result = &baremetal.ListPolicies{Policies: []baremetal.Policy{}}
 
 	for {
 		var list *baremetal.ListPolicies
-		if list, e = s.Client.ListPolicies(compartment_id, opts); e != nil {
+		if list, e = client.ListPolicies(compartmentID, opts); e != nil {
 			break
 		}
 
-		s.Res.Policies = append(s.Res.Policies, list.Policies...)
+		result.Policies = append(result.Policies, list.Policies...)
 
 		if hasNexPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNexPage {
 			break
@@ -0,0 +1,208 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/policy"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// PolicyEvaluationDatasource evaluates the policies of a tenancy locally, to
+// check whether a group has an access in a compartment.
+func PolicyEvaluationDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readPolicyEvaluation,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"verb": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(policy.Verbs, false),
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compartment_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"allowed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"matching_statements": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"compartment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"statement": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conditional": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readPolicyEvaluation(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &PolicyEvaluationDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type PolicyEvaluationDatasourceCrud struct {
+	crud.BaseCrud
+	Res []policy.Match
+}
+
+// Get fetches the active policies attached to each compartment from the
+// tenancy down to the requested compartment, as policies elsewhere in the
+// tree cannot grant access to it.
+func (s *PolicyEvaluationDatasourceCrud) Get() (e error) {
+	request := &policy.Request{
+		GroupName:    s.D.Get("group").(string),
+		Verb:         s.D.Get("verb").(string),
+		ResourceType: strings.ToLower(s.D.Get("resource_type").(string)),
+		Path:         []policy.Compartment{{ID: s.D.Get("compartment_id").(string)}},
+	}
+
+	if request.GroupID, e = s.findGroup(request.GroupName); e != nil {
+		return
+	}
+
+	if path := s.D.Get("compartment_path").(string); path != "" {
+		for _, name := range strings.Split(path, ":") {
+			var compartment *baremetal.Compartment
+			if compartment, e = s.findCompartment(request.Path[len(request.Path)-1].ID, name); e != nil {
+				return
+			}
+			request.Path = append(request.Path, policy.Compartment{ID: compartment.ID, Name: compartment.Name})
+		}
+	}
+
+	grants := []policy.Grant{}
+	for _, compartment := range request.Path {
+		var list *baremetal.ListPolicies
+		if list, e = listPolicies(s.Client, compartment.ID, &baremetal.ListOptions{}); e != nil {
+			return
+		}
+		for _, p := range list.Policies {
+			if p.State != baremetal.ResourceActive {
+				continue
+			}
+			for _, statement := range p.Statements {
+				grants = append(grants, policy.Grant{
+					PolicyID:      p.ID,
+					PolicyName:    p.Name,
+					CompartmentID: p.CompartmentID,
+					Statement:     statement,
+				})
+			}
+		}
+	}
+
+	s.Res = policy.Evaluate(request, grants)
+	return
+}
+
+// findGroup returns the OCID of the named group, so statements naming the
+// group by OCID are matched as well.
+func (s *PolicyEvaluationDatasourceCrud) findGroup(name string) (id string, e error) {
+	opts := &baremetal.ListOptions{}
+	for {
+		var list *baremetal.ListGroups
+		if list, e = s.Client.ListGroups(opts); e != nil {
+			return
+		}
+		for _, group := range list.Groups {
+			if strings.EqualFold(group.Name, name) && group.State == baremetal.ResourceActive {
+				return group.ID, nil
+			}
+		}
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNextPage {
+			return "", fmt.Errorf("No active group named %q", name)
+		}
+	}
+}
+
+// findCompartment returns the active compartment with the given name
+// directly below parentID.
+func (s *PolicyEvaluationDatasourceCrud) findCompartment(parentID, name string) (compartment *baremetal.Compartment, e error) {
+	opts := &sdk.ListCompartmentsOptions{CompartmentID: parentID}
+	for {
+		var list *baremetal.ListCompartments
+		if list, e = s.Client.ListCompartments(opts); e != nil {
+			return
+		}
+		for i, c := range list.Compartments {
+			if strings.EqualFold(c.Name, name) && c.State == baremetal.ResourceActive {
+				return &list.Compartments[i], nil
+			}
+		}
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.PageListOptions); !hasNextPage {
+			return nil, fmt.Errorf("No active compartment named %q in %s", name, parentID)
+		}
+	}
+}
+
+func (s *PolicyEvaluationDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+
+	s.D.SetId(time.Now().UTC().String())
+	s.D.Set("allowed", policy.Allowed(s.Res))
+
+	statements := []map[string]interface{}{}
+	for _, match := range s.Res {
+		statements = append(statements, map[string]interface{}{
+			"policy_id":      match.PolicyID,
+			"policy_name":    match.PolicyName,
+			"compartment_id": match.CompartmentID,
+			"statement":      match.Statement,
+			"conditional":    match.Conditional,
+		})
+	}
+	if err := s.D.Set("matching_statements", statements); err != nil {
+		panic(err)
+	}
+	return
+}
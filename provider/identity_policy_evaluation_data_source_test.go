@@ -0,0 +1,168 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceIdentityPolicyEvaluationTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+	Token        string
+	TokenFn      TokenFn
+}
+
+func (s *DatasourceIdentityPolicyEvaluationTestSuite) SetupTest() {
+	s.Token, s.TokenFn = tokenize()
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + s.TokenFn(`
+	resource "oci_identity_compartment" "t" {
+		name = "-tf-compartment"
+		description = "tf test compartment"
+	}
+
+	resource "oci_identity_compartment" "child" {
+		compartment_id = "${oci_identity_compartment.t.id}"
+		name = "-tf-policy-evaluation"
+		description = "tf test compartment"
+	}
+
+	resource "oci_identity_group" "t" {
+		name = "{{.token}}"
+		description = "automated test group"
+	}
+
+	resource "oci_identity_policy" "tenancy" {
+		compartment_id = "${var.tenancy_ocid}"
+		name = "{{.token}}-tenancy"
+		description = "automated test policy"
+		statements = ["Allow group ${oci_identity_group.t.name} to read instance-family in compartment ${oci_identity_compartment.t.name}"]
+	}
+
+	resource "oci_identity_policy" "parent" {
+		compartment_id = "${oci_identity_compartment.t.id}"
+		name = "{{.token}}-parent"
+		description = "automated test policy"
+		statements = [
+			"Allow group ${oci_identity_group.t.name} to manage volumes in compartment ${oci_identity_compartment.child.name}",
+			"Allow group ${oci_identity_group.t.name} to use buckets in compartment ${oci_identity_compartment.child.name} where request.operation = 'GetBucket'"
+		]
+	}`, nil)
+	s.ResourceName = "data.oci_identity_policy_evaluation.t"
+}
+
+func (s *DatasourceIdentityPolicyEvaluationTestSuite) TestAccDatasourceIdentityPolicyEvaluation_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+			},
+			// verify access inherited from the parent compartment, and verb implication
+			{
+				Config: s.Config + `
+				data "oci_identity_policy_evaluation" "t" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "inspect"
+					resource_type = "instances"
+					compartment_path = "${oci_identity_compartment.t.name}:${oci_identity_compartment.child.name}"
+				}
+
+				data "oci_identity_policy_evaluation" "use_instances" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "use"
+					resource_type = "instances"
+					compartment_path = "${oci_identity_compartment.t.name}:${oci_identity_compartment.child.name}"
+				}
+
+				data "oci_identity_policy_evaluation" "tenancy" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "read"
+					resource_type = "instances"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "allowed", "true"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_statements.#", "1"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "matching_statements.0.policy_id", "oci_identity_policy.tenancy", "id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_statements.0.policy_name", s.Token+"-tenancy"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "matching_statements.0.compartment_id", "oci_identity_policy.tenancy", "compartment_id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_statements.0.statement", "Allow group "+s.Token+" to read instance-family in compartment -tf-compartment"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_statements.0.conditional", "false"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.use_instances", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.use_instances", "matching_statements.#", "0"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.tenancy", "allowed", "false"),
+				),
+			},
+			// verify policies attached to a compartment, and conditional statements
+			{
+				Config: s.Config + `
+				data "oci_identity_policy_evaluation" "t" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "manage"
+					resource_type = "volumes"
+					compartment_path = "${oci_identity_compartment.t.name}:${oci_identity_compartment.child.name}"
+				}
+
+				data "oci_identity_policy_evaluation" "parent" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "manage"
+					resource_type = "volumes"
+					compartment_path = "${oci_identity_compartment.t.name}"
+				}
+
+				data "oci_identity_policy_evaluation" "buckets" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "read"
+					resource_type = "buckets"
+					compartment_path = "${oci_identity_compartment.t.name}:${oci_identity_compartment.child.name}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "allowed", "true"),
+					resource.TestCheckResourceAttr(s.ResourceName, "matching_statements.#", "1"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "matching_statements.0.policy_id", "oci_identity_policy.parent", "id"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.parent", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.buckets", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.buckets", "matching_statements.#", "1"),
+					resource.TestCheckResourceAttr("data.oci_identity_policy_evaluation.buckets", "matching_statements.0.conditional", "true"),
+				),
+			},
+			// verify an unknown compartment is reported
+			{
+				Config: s.Config + `
+				data "oci_identity_policy_evaluation" "t" {
+					compartment_id = "${var.tenancy_ocid}"
+					group = "${oci_identity_group.t.name}"
+					verb = "read"
+					resource_type = "instances"
+					compartment_path = "${oci_identity_compartment.t.name}:-tf-missing"
+				}`,
+				ExpectError: regexp.MustCompile(`No active compartment named .*-tf-missing`),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceIdentityPolicyEvaluationTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceIdentityPolicyEvaluationTestSuite))
+}
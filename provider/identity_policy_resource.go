@@ -4,13 +4,11 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
-	"crypto/md5"
-	"encoding/hex"
-	"strings"
-
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/policy"
 )
 
 func PolicyResource() *schema.Resource {
@@ -49,20 +47,15 @@ func PolicyResource() *schema.Resource {
 			Type:             schema.TypeList,
 			Required:         true,
 			DiffSuppressFunc: ignorePolicyFormatDiff,
-			Elem:             &schema.Schema{Type: schema.TypeString},
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validatePolicyStatement,
+			},
 		},
 		"ETag": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
-		"policyHash": {
-			Type:     schema.TypeString,
-			Computed: true,
-		},
-		"lastUpdateETag": {
-			Type:     schema.TypeString,
-			Computed: true,
-		},
 		"inactive_state": {
 			Type:     schema.TypeInt,
 			Computed: true,
@@ -77,32 +70,48 @@ func PolicyResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createPolicy,
-		Read:     readPolicy,
-		Update:   updatePolicy,
-		Delete:   deletePolicy,
-		Schema:   policySchema,
+		Timeouts:      crud.DefaultTimeout,
+		Create:        createPolicy,
+		Read:          readPolicy,
+		Update:        updatePolicy,
+		Delete:        deletePolicy,
+		Schema:        policySchema,
+		SchemaVersion: 1,
+		MigrateState:  migratePolicyState,
 	}
 }
 
-func ignorePolicyFormatDiff(k string, old string, new string, d *schema.ResourceData) bool {
-	oldHash := getOrDefault(d, "policyHash", "")
-	newHash := getMD5Hash(toStringArray(d.Get("statements")))
-	oldETag := getOrDefault(d, "lastUpdateETag", "")
-	currentETag := getOrDefault(d, "ETag", "")
-	suppressDiff := strings.EqualFold(oldHash, newHash) && strings.EqualFold(oldETag, currentETag)
+// migratePolicyState upgrades state from before statements were compared
+// by meaning. Version 0 also stored a hash of the statements and the ETag
+// of the last update to detect changes.
+func migratePolicyState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		return is, nil
+	}
+	switch v {
+	case 0:
+		delete(is.Attributes, "policyHash")
+		delete(is.Attributes, "lastUpdateETag")
+	}
+	return is, nil
+}
 
-	return suppressDiff
+// ignorePolicyFormatDiff compares statements by meaning, as the service may
+// change the whitespace and case of the statements it is given. Statements
+// which do not parse, such as values not yet computed, are always diffed.
+func ignorePolicyFormatDiff(k string, old string, new string, d *schema.ResourceData) bool {
+	return policy.Equivalent(old, new)
 }
 
-func getOrDefault(d *schema.ResourceData, key string, defaultValue string) string {
-	valueString := defaultValue
-	if value, ok := d.GetOk(key); ok {
-		valueString = value.(string)
+// parsePolicyStatements rejects statements interpolated from values which
+// were not known at plan time, and so could not be validated then.
+func parsePolicyStatements(statements []string) error {
+	for _, statement := range statements {
+		if _, err := policy.Parse(statement); err != nil {
+			return err
+		}
 	}
-
-	return valueString
+	return nil
 }
 
 func createPolicy(d *schema.ResourceData, m interface{}) (e error) {
@@ -181,14 +190,11 @@ func (s *PolicyResourceCrud) Create() (e error) {
 	description := s.D.Get("description").(string)
 	compartmentID := s.D.Get("compartment_id").(string)
 	statements := toStringArray(s.D.Get("statements"))
-
-	s.Res, e = s.Client.CreatePolicy(name, description, compartmentID, statements, nil)
-
-	if e == nil {
-		s.D.Set("policyHash", getMD5Hash(statements))
-		s.D.Set("lastUpdateETag", s.Res.ETag)
+	if e = parsePolicyStatements(statements); e != nil {
+		return
 	}
 
+	s.Res, e = s.Client.CreatePolicy(name, description, compartmentID, statements, nil)
 	return
 }
 
@@ -206,18 +212,15 @@ func (s *PolicyResourceCrud) Update() (e error) {
 		opts.Description = description.(string)
 	}
 
-	policyHash := ""
 	if rawStatements, ok := s.D.GetOk("statements"); ok {
 		statements := toStringArray(rawStatements)
+		if e = parsePolicyStatements(statements); e != nil {
+			return
+		}
 		opts.Statements = statements
-		policyHash = getMD5Hash(statements)
 	}
 
 	s.Res, e = s.Client.UpdatePolicy(s.D.Id(), opts)
-	if e == nil {
-		s.D.Set("policyHash", policyHash)
-		s.D.Set("lastUpdateETag", s.Res.ETag)
-	}
 	return
 }
 
@@ -231,11 +234,6 @@ func (s *PolicyResourceCrud) SetData() {
 	s.D.Set("time_created", s.Res.TimeCreated.String())
 }
 
-func getMD5Hash(values []string) string {
-	hash := md5.Sum([]byte(strings.Join(values, "#")))
-	return hex.EncodeToString(hash[:])
-}
-
 func (s *PolicyResourceCrud) Delete() (e error) {
 	return s.Client.DeletePolicy(s.D.Id(), nil)
 }
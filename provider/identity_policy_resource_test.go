@@ -3,14 +3,14 @@
 package provider
 
 import (
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
-	"fmt"
-
 	"github.com/stretchr/testify/suite"
 )
 
@@ -44,7 +44,6 @@ func (s *ResourceIdentityPolicyTestSuite) SetupTest() {
 }
 
 func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_basic() {
-	var policyHash string
 	resource.Test(s.T(), resource.TestCase{
 		Providers: s.Providers,
 		Steps: []resource.TestStep{
@@ -64,15 +63,9 @@ func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_basic()
 					resource.TestCheckResourceAttrSet(s.ResourceName, "compartment_id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "ETag"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "lastUpdateETag"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "policyHash"),
 					resource.TestCheckResourceAttr(s.ResourceName, "name", "p1-"+s.Token),
 					resource.TestCheckResourceAttr(s.ResourceName, "description", "automated test policy"),
 					resource.TestCheckResourceAttr(s.ResourceName, "statements.#", "1"),
-					func(s *terraform.State) (err error) {
-						policyHash, err = fromInstanceState(s, "oci_identity_policy.p", "policyHash")
-						return err
-					},
 				),
 			},
 			// verify update
@@ -91,13 +84,6 @@ func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_basic()
 					resource.TestCheckResourceAttr(s.ResourceName, "name", "p2-"+s.Token),
 					resource.TestCheckResourceAttr(s.ResourceName, "description", "automated test policy (updated)"),
 					resource.TestCheckResourceAttr(s.ResourceName, "statements.#", "2"),
-					func(s *terraform.State) (err error) {
-						newHash, err := fromInstanceState(s, "oci_identity_policy.p", "policyHash")
-						if policyHash == newHash {
-							return fmt.Errorf("Expected new hash, got same")
-						}
-						return err
-					},
 				),
 			},
 		},
@@ -106,32 +92,61 @@ func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_basic()
 }
 
 func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_formattingDiff() {
-	var lastUpdateETag, policyHash string
+	var eTag string
+	config := s.Config + s.TokenFn(`
+	resource "oci_identity_policy" "p" {
+		compartment_id = "${oci_identity_compartment.t.id}"
+		name = "{{.token}}"
+		description = "automated test policy"
+		statements = ["allow  GROUP ${oci_identity_group.t.name} to READ instances IN compartment ${oci_identity_compartment.t.name} where request.operation='ListInstances'"]
+	}`, nil)
 	resource.Test(s.T(), resource.TestCase{
 		Providers: s.Providers,
 		Steps: []resource.TestStep{
-			// create policy with bad formatting
+			// create policy with unusual formatting, which the service normalizes
 			{
 				ImportState:       true,
 				ImportStateVerify: true,
+				Config:            config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "statements.0", "Allow group "+s.Token+" to read instances in compartment -tf-compartment where request.operation = 'ListInstances'"),
+					func(s *terraform.State) (err error) {
+						eTag, err = fromInstanceState(s, "oci_identity_policy.p", "ETag")
+						return err
+					},
+				),
+			},
+			// verify the normalized statements do not cause an update, and the
+			// ETag is unchanged
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr("oci_identity_policy.p", "ETag", eTag)(s)
+					},
+				),
+			},
+		},
+	},
+	)
+}
+
+func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_invalidStatement() {
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify literal statements are validated at plan time
+			{
 				Config: s.Config + s.TokenFn(`
 				resource "oci_identity_policy" "p" {
 					compartment_id = "${oci_identity_compartment.t.id}"
 					name = "{{.token}}"
 					description = "automated test policy"
-					statements = ["Allow group ${oci_identity_group.t.name} to read instances in >> compartment ${oci_identity_compartment.t.name}"]
+					statements = ["Allow group Administrators to raed instances in tenancy"]
 				}`, nil),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(s.ResourceName, "statements.0", "Allow group "+s.Token+" to read instances in compartment -tf-compartment"),
-					func(s *terraform.State) (err error) {
-						if policyHash, err = fromInstanceState(s, "oci_identity_policy.p", "policyHash"); err == nil {
-							lastUpdateETag, err = fromInstanceState(s, "oci_identity_policy.p", "lastUpdateETag")
-						}
-						return err
-					},
-				),
+				ExpectError: regexp.MustCompile(`statements.0: invalid policy statement .*unexpected .*raed.* at column 31, expected inspect, read, use or manage`),
 			},
-			// verify update does not change the hash and ETag value
+			// verify interpolated statements are validated before they are sent
 			{
 				Config: s.Config + s.TokenFn(`
 				resource "oci_identity_policy" "p" {
@@ -140,19 +155,42 @@ func (s *ResourceIdentityPolicyTestSuite) TestAccResourceIdentityPolicy_formatti
 					description = "automated test policy"
 					statements = ["Allow group ${oci_identity_group.t.name} to read instances in >> compartment ${oci_identity_compartment.t.name}"]
 				}`, nil),
-				Check: resource.ComposeTestCheckFunc(
-					func(s *terraform.State) (err error) {
-						resource.TestCheckResourceAttr("oci_identity_policy.p", "policyHash", policyHash)
-						resource.TestCheckResourceAttr("oci_identity_policy.p", "lastUpdateETag", lastUpdateETag)
-						return err
-					},
-				),
+				ExpectError: regexp.MustCompile(`unexpected .*>>.* at column \d+, expected tenancy or compartment`),
 			},
 		},
 	},
 	)
 }
 
+func TestPolicyMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "ocid1.policy.oc1..policy",
+		Attributes: map[string]string{
+			"id":             "ocid1.policy.oc1..policy",
+			"statements.#":   "1",
+			"statements.0":   "Allow group Administrators to read instances in tenancy",
+			"ETag":           "etag",
+			"policyHash":     "hash",
+			"lastUpdateETag": "etag",
+		},
+	}
+
+	is, err := PolicyResource().MigrateState(0, is, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"id":           "ocid1.policy.oc1..policy",
+		"statements.#": "1",
+		"statements.0": "Allow group Administrators to read instances in tenancy",
+		"ETag":         "etag",
+	}
+	if !reflect.DeepEqual(expected, is.Attributes) {
+		t.Fatalf("Expected:\n%#v\n\nGot:\n%#v", expected, is.Attributes)
+	}
+}
+
 func TestResourceIdentityPolicyTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceIdentityPolicyTestSuite))
 }
@@ -0,0 +1,68 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceIdentityRegionsTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceIdentityRegionsTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig()
+	s.ResourceName = "data.oci_identity_regions.t"
+}
+
+func (s *DatasourceIdentityRegionsTestSuite) TestAccIdentityRegions_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_identity_regions" "t" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "regions.#"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "regions.0.key"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "regions.0.name"),
+				),
+			},
+			// Verify filtering
+			{
+				Config: s.Config + `
+				data "oci_identity_regions" "t" {
+					filter {
+						name = "name"
+						values = ["us-phoenix-1"]
+					}
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "regions.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "regions.0.key", "PHX"),
+					resource.TestCheckResourceAttr(s.ResourceName, "regions.0.name", "us-phoenix-1"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceIdentityRegionsTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceIdentityRegionsTestSuite))
+}
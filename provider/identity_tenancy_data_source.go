@@ -0,0 +1,83 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+func TenancyDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readTenancy,
+		Schema: map[string]*schema.Schema{
+			"tenancy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"home_region_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"home_region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readTenancy(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &TenancyDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type TenancyDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.Tenancy
+	// HomeRegion is the name of the region identified by Res.HomeRegionKey.
+	HomeRegion string
+}
+
+func (s *TenancyDatasourceCrud) Get() (e error) {
+	id := s.D.Get("tenancy_id").(string)
+	if s.Res, e = s.Client.GetTenancy(id); e != nil {
+		return
+	}
+
+	// The tenancy only reports the key of its home region, e.g. "PHX".
+	var regions *baremetal.ListRegions
+	if regions, e = s.Client.ListRegions(); e != nil {
+		return
+	}
+	for _, region := range regions.Regions {
+		if region.Key == s.Res.HomeRegionKey {
+			s.HomeRegion = region.Name
+		}
+	}
+	return
+}
+
+func (s *TenancyDatasourceCrud) SetData() {
+	if s.Res != nil {
+		s.D.SetId(s.Res.ID)
+		s.D.Set("name", s.Res.Name)
+		s.D.Set("description", s.Res.Description)
+		s.D.Set("home_region_key", s.Res.HomeRegionKey)
+		s.D.Set("home_region", s.HomeRegion)
+	}
+	return
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceIdentityTenancyTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *DatasourceIdentityTenancyTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig()
+	s.ResourceName = "data.oci_identity_tenancy.t"
+}
+
+func (s *DatasourceIdentityTenancyTestSuite) TestAccIdentityTenancy_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_identity_tenancy" "t" {
+					tenancy_id = "${var.tenancy_ocid}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "name"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "home_region_key"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "home_region"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestDatasourceIdentityTenancyTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceIdentityTenancyTestSuite))
+}
@@ -167,7 +167,6 @@ func (s *LoadBalancerBackendSetResourceCrud) Get() (e error) {
 func (s *LoadBalancerBackendSetResourceCrud) Update() (e error) {
 	opts := &baremetal.UpdateLoadBalancerBackendSetOptions{}
 
-	opts.HealthChecker = s.healthChecker()
 	opts.SSLConfig = s.sslConfig()
 	opts.Policy = s.D.Get("policy").(string)
 
@@ -178,6 +177,14 @@ func (s *LoadBalancerBackendSetResourceCrud) Update() (e error) {
 	}
 	opts.Backends = bes.Backends
 
+	// Keep the current health checker unless the block changed, so updates
+	// don't revert changes made by oci_load_balancer_health_checker.
+	if s.D.HasChange("health_checker") {
+		opts.HealthChecker = s.healthChecker()
+	} else {
+		opts.HealthChecker = bes.HealthChecker
+	}
+
 	log.Printf("BACKENDS: %v\n", opts.Backends)
 	var workReqID string
 	workReqID, e = s.Client.UpdateBackendSet(s.D.Get("load_balancer_id").(string), s.D.Id(), opts)
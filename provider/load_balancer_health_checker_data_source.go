@@ -0,0 +1,98 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+func HealthCheckerDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readHealthChecker,
+		Schema: map[string]*schema.Schema{
+			"load_balancer_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"backendset_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"interval_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"response_body_regex": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"retries": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"return_code": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timeout_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readHealthChecker(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &HealthCheckerDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type HealthCheckerDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.HealthChecker
+}
+
+func (s *HealthCheckerDatasourceCrud) Get() (e error) {
+	res, e := s.Client.GetHealthChecker(
+		s.D.Get("load_balancer_id").(string),
+		s.D.Get("backendset_name").(string),
+		nil,
+	)
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *HealthCheckerDatasourceCrud) SetData() {
+	if s.Res != nil {
+		s.D.SetId(s.D.Get("load_balancer_id").(string) + "/" + s.D.Get("backendset_name").(string))
+		s.D.Set("protocol", s.Res.Protocol)
+		s.D.Set("url_path", s.Res.URLPath)
+		s.D.Set("interval_ms", s.Res.IntervalInMS)
+		s.D.Set("port", s.Res.Port)
+		s.D.Set("response_body_regex", s.Res.ResponseBodyRegex)
+		s.D.Set("retries", s.Res.Retries)
+		s.D.Set("return_code", s.Res.ReturnCode)
+		s.D.Set("timeout_ms", s.Res.TimeoutInMS)
+	}
+	return
+}
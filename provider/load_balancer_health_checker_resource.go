@@ -0,0 +1,206 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+// LoadBalancerHealthCheckerResource manages the health checker of an existing
+// backend set. The backend set always has a health checker, so destroying the
+// resource only removes it from state.
+func LoadBalancerHealthCheckerResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancerHealthChecker,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createLoadBalancerHealthChecker,
+		Read:     readLoadBalancerHealthChecker,
+		Update:   updateLoadBalancerHealthChecker,
+		Delete:   deleteLoadBalancerHealthChecker,
+		Schema: map[string]*schema.Schema{
+			"load_balancer_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"backendset_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"url_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"interval_ms": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"response_body_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"return_code": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"timeout_ms": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createLoadBalancerHealthChecker(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &LoadBalancerHealthCheckerResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.CreateResource(d, sync)
+}
+
+func readLoadBalancerHealthChecker(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &LoadBalancerHealthCheckerResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.ReadResource(sync)
+}
+
+func updateLoadBalancerHealthChecker(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &LoadBalancerHealthCheckerResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteLoadBalancerHealthChecker(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &LoadBalancerHealthCheckerResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).clientWithoutNotFoundRetries
+	return crud.DeleteResource(d, sync)
+}
+
+// importLoadBalancerHealthChecker imports by an ID of the form
+// {load_balancer_id}/{backendset_name}.
+func importLoadBalancerHealthChecker(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Unexpected health checker ID %q, expected {load_balancer_id}/{backendset_name}", d.Id())
+	}
+	d.Set("load_balancer_id", parts[0])
+	d.Set("backendset_name", parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+type LoadBalancerHealthCheckerResourceCrud struct {
+	crud.BaseCrud
+	WorkRequest *baremetal.WorkRequest
+	Resource    *baremetal.HealthChecker
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) ID() string {
+	return s.D.Get("load_balancer_id").(string) + "/" + s.D.Get("backendset_name").(string)
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) Create() (e error) {
+	return s.apply()
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) Get() (e error) {
+	res, e := s.Client.GetHealthChecker(
+		s.D.Get("load_balancer_id").(string),
+		s.D.Get("backendset_name").(string),
+		nil,
+	)
+	if e == nil {
+		s.Resource = res
+	}
+	return
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) Update() (e error) {
+	return s.apply()
+}
+
+// apply replaces the health checker of the backend set and waits for the
+// work request to finish.
+func (s *LoadBalancerHealthCheckerResourceCrud) apply() (e error) {
+	var workReqID string
+	workReqID, e = s.Client.UpdateHealthChecker(
+		s.D.Get("load_balancer_id").(string),
+		s.D.Get("backendset_name").(string),
+		s.healthChecker(),
+		nil,
+	)
+	if e != nil {
+		return
+	}
+	s.WorkRequest, e = s.Client.GetWorkRequest(workReqID, nil)
+	if e != nil {
+		return
+	}
+	e = crud.LoadBalancerWaitForWorkRequest(s.Client, s.D, s.WorkRequest)
+	if e != nil {
+		return
+	}
+	return s.Get()
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) SetData() {
+	if s.Resource == nil {
+		return
+	}
+	s.D.Set("protocol", s.Resource.Protocol)
+	s.D.Set("url_path", s.Resource.URLPath)
+	s.D.Set("interval_ms", s.Resource.IntervalInMS)
+	s.D.Set("port", s.Resource.Port)
+	s.D.Set("response_body_regex", s.Resource.ResponseBodyRegex)
+	s.D.Set("retries", s.Resource.Retries)
+	s.D.Set("return_code", s.Resource.ReturnCode)
+	s.D.Set("timeout_ms", s.Resource.TimeoutInMS)
+}
+
+// Delete leaves the health checker of the backend set as it is; only the
+// resource is removed from state.
+func (s *LoadBalancerHealthCheckerResourceCrud) Delete() (e error) {
+	return
+}
+
+func (s *LoadBalancerHealthCheckerResourceCrud) healthChecker() baremetal.HealthChecker {
+	return baremetal.HealthChecker{
+		Protocol:          s.D.Get("protocol").(string),
+		URLPath:           s.D.Get("url_path").(string),
+		IntervalInMS:      s.D.Get("interval_ms").(int),
+		Port:              s.D.Get("port").(int),
+		ResponseBodyRegex: s.D.Get("response_body_regex").(string),
+		Retries:           s.D.Get("retries").(int),
+		ReturnCode:        s.D.Get("return_code").(int),
+		TimeoutInMS:       s.D.Get("timeout_ms").(int),
+	}
+}
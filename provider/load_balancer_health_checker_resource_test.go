@@ -0,0 +1,145 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceLoadBalancerHealthCheckerTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceLoadBalancerHealthCheckerTestSuite) SetupTest() {
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+	
+	resource "oci_core_virtual_network" "t" {
+		compartment_id = "${var.compartment_id}"
+		cidr_block = "10.0.0.0/16"
+		display_name = "-tf-vcn"
+	}
+	
+	resource "oci_core_subnet" "t" {
+		compartment_id      = "${var.compartment_id}"
+		vcn_id              = "${oci_core_virtual_network.t.id}"
+		availability_domain = "${lookup(data.oci_identity_availability_domains.ADs.availability_domains[0],"name")}"
+		route_table_id      = "${oci_core_virtual_network.t.default_route_table_id}"
+		security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+		dhcp_options_id     = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+		cidr_block          = "10.0.0.0/24"
+		display_name        = "-tf-subnet"
+	}
+	
+	resource "oci_load_balancer" "t" {
+		shape = "100Mbps"
+		compartment_id = "${var.compartment_id}"
+		subnet_ids = ["${oci_core_subnet.t.id}"]
+		display_name = "-tf-lb"
+		is_private = true
+	}
+	
+	resource "oci_load_balancer_backendset" "t" {
+		load_balancer_id = "${oci_load_balancer.t.id}"
+		name = "-tf-backend-set"
+		policy = "ROUND_ROBIN"
+		health_checker {
+			port = 80
+			protocol = "TCP"
+			response_body_regex = ".*"
+		}
+		lifecycle {
+			ignore_changes = ["health_checker"]
+		}
+	}`
+	s.ResourceName = "oci_load_balancer_health_checker.t"
+}
+
+func (s *ResourceLoadBalancerHealthCheckerTestSuite) TestAccResourceLoadBalancerHealthChecker_basic() {
+	updateConfig := s.Config + `
+	resource "oci_load_balancer_health_checker" "t" {
+		load_balancer_id = "${oci_load_balancer.t.id}"
+		backendset_name = "${oci_load_balancer_backendset.t.name}"
+		protocol = "HTTP"
+		port = 8080
+		url_path = "/status"
+		interval_ms = 15000
+		retries = 5
+		return_code = 204
+	}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// test create
+			{
+				Config: s.Config + `
+				resource "oci_load_balancer_health_checker" "t" {
+					load_balancer_id = "${oci_load_balancer.t.id}"
+					backendset_name = "${oci_load_balancer_backendset.t.name}"
+					protocol = "HTTP"
+					port = 8080
+					url_path = "/health"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "load_balancer_id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "backendset_name", "-tf-backend-set"),
+					resource.TestCheckResourceAttr(s.ResourceName, "protocol", "HTTP"),
+					resource.TestCheckResourceAttr(s.ResourceName, "port", "8080"),
+					resource.TestCheckResourceAttr(s.ResourceName, "url_path", "/health"),
+					resource.TestCheckResourceAttr(s.ResourceName, "response_body_regex", ".*"),
+					resource.TestCheckResourceAttr(s.ResourceName, "retries", "3"),
+					resource.TestCheckResourceAttr(s.ResourceName, "return_code", "200"),
+				),
+			},
+			// test update
+			{
+				Config: updateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "url_path", "/status"),
+					resource.TestCheckResourceAttr(s.ResourceName, "interval_ms", "15000"),
+					resource.TestCheckResourceAttr(s.ResourceName, "retries", "5"),
+					resource.TestCheckResourceAttr(s.ResourceName, "return_code", "204"),
+				),
+			},
+			// verify updating the backend set keeps the health checker
+			{
+				Config: strings.Replace(updateConfig, `policy = "ROUND_ROBIN"`, `policy = "LEAST_CONNECTIONS"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oci_load_balancer_backendset.t", "policy", "LEAST_CONNECTIONS"),
+					resource.TestCheckResourceAttr(s.ResourceName, "protocol", "HTTP"),
+					resource.TestCheckResourceAttr(s.ResourceName, "url_path", "/status"),
+				),
+			},
+			// test import
+			{
+				Config:            updateConfig,
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceLoadBalancerHealthCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceLoadBalancerHealthCheckerTestSuite))
+}
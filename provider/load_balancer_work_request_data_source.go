@@ -0,0 +1,132 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+// loadBalancerWorkRequestSchema holds the attributes of a work request,
+// shared by the singular and plural data sources.
+func loadBalancerWorkRequestSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"load_balancer_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"message": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"error_details": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"error_code": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"message": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"time_accepted": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"time_finished": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// loadBalancerWorkRequestMap flattens a work request. time_finished is left
+// empty while the work request is still running.
+func loadBalancerWorkRequestMap(wr baremetal.WorkRequest) map[string]interface{} {
+	errorDetails := []map[string]interface{}{}
+	for _, v := range wr.ErrorDetails {
+		errorDetails = append(errorDetails, map[string]interface{}{
+			"error_code": v.ErrorCode,
+			"message":    v.Message,
+		})
+	}
+
+	res := map[string]interface{}{
+		"id":               wr.ID,
+		"load_balancer_id": wr.LoadBalancerID,
+		"type":             wr.Type,
+		"state":            wr.State,
+		"message":          wr.Message,
+		"error_details":    errorDetails,
+		"time_accepted":    wr.TimeAccepted.String(),
+		"time_finished":    "",
+	}
+	if !wr.TimeFinished.IsZero() {
+		res["time_finished"] = wr.TimeFinished.String()
+	}
+	return res
+}
+
+func LoadBalancerWorkRequestDatasource() *schema.Resource {
+	s := loadBalancerWorkRequestSchema()
+	s["work_request_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	return &schema.Resource{
+		Read:   readLoadBalancerWorkRequest,
+		Schema: s,
+	}
+}
+
+func readLoadBalancerWorkRequest(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &LoadBalancerWorkRequestDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type LoadBalancerWorkRequestDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.WorkRequest
+}
+
+func (s *LoadBalancerWorkRequestDatasourceCrud) Get() (e error) {
+	id := s.D.Get("work_request_id").(string)
+	res, e := s.Client.GetWorkRequest(id, nil)
+	if e == nil {
+		s.Res = res
+	}
+	return
+}
+
+func (s *LoadBalancerWorkRequestDatasourceCrud) SetData() {
+	if s.Res != nil {
+		s.D.SetId(s.Res.ID)
+		for k, v := range loadBalancerWorkRequestMap(*s.Res) {
+			s.D.Set(k, v)
+		}
+	}
+	return
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/options"
+)
+
+func LoadBalancerWorkRequestsDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readLoadBalancerWorkRequests,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+			"load_balancer_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"page": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"work_requests": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: loadBalancerWorkRequestSchema(),
+				},
+			},
+		},
+	}
+}
+
+func readLoadBalancerWorkRequests(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	sync := &LoadBalancerWorkRequestsDatasourceCrud{}
+	sync.D = d
+	sync.Client = client.client
+	return crud.ReadResource(sync)
+}
+
+type LoadBalancerWorkRequestsDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.ListWorkRequests
+}
+
+func (s *LoadBalancerWorkRequestsDatasourceCrud) Get() (e error) {
+	lbID := s.D.Get("load_balancer_id").(string)
+
+	opts := &baremetal.ListLoadBalancerPolicyOptions{}
+	options.SetListOptions(s.D, &opts.ListOptions)
+
+	s.Res = &baremetal.ListWorkRequests{WorkRequests: []baremetal.WorkRequest{}}
+
+	for {
+		var list *baremetal.ListWorkRequests
+		if list, e = s.Client.ListWorkRequests(lbID, opts); e != nil {
+			break
+		}
+
+		s.Res.WorkRequests = append(s.Res.WorkRequests, list.WorkRequests...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	return
+}
+
+func (s *LoadBalancerWorkRequestsDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+	s.D.SetId(time.Now().UTC().String())
+	resources := []map[string]interface{}{}
+	for _, v := range s.Res.WorkRequests {
+		resources = append(resources, loadBalancerWorkRequestMap(v))
+	}
+
+	if f, fOk := s.D.GetOk("filter"); fOk {
+		resources = ApplyFilters(f.(*schema.Set), resources)
+	}
+
+	s.D.Set("work_requests", resources)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+func TestAccDatasourceLoadBalancerWorkRequests_basic(t *testing.T) {
+	providers := testAccProviders
+	config := testProviderConfig() + `
+	data "oci_identity_availability_domains" "ADs" {
+		compartment_id = "${var.compartment_id}"
+	}
+	
+	resource "oci_core_virtual_network" "t" {
+		compartment_id = "${var.compartment_id}"
+		cidr_block = "10.0.0.0/16"
+		display_name = "-tf-vcn"
+	}
+	
+	resource "oci_core_subnet" "t" {
+		compartment_id      = "${var.compartment_id}"
+		vcn_id              = "${oci_core_virtual_network.t.id}"
+		availability_domain = "${lookup(data.oci_identity_availability_domains.ADs.availability_domains[0],"name")}"
+		route_table_id      = "${oci_core_virtual_network.t.default_route_table_id}"
+		security_list_ids = ["${oci_core_virtual_network.t.default_security_list_id}"]
+		dhcp_options_id     = "${oci_core_virtual_network.t.default_dhcp_options_id}"
+		cidr_block          = "10.0.0.0/24"
+		display_name        = "-tf-subnet"
+	}
+	
+	resource "oci_load_balancer" "t" {
+		shape = "100Mbps"
+		compartment_id = "${var.compartment_id}"
+		subnet_ids = ["${oci_core_subnet.t.id}"]
+		display_name = "-tf-lb"
+		is_private = true
+	}
+	
+	resource "oci_load_balancer_backendset" "t" {
+		load_balancer_id = "${oci_load_balancer.t.id}"
+		name = "-tf-backend-set"
+		policy = "ROUND_ROBIN"
+		health_checker {
+			interval_ms = 30000
+			port = 1234
+			protocol = "TCP"
+			response_body_regex = ".*"
+			url_path = "/"
+		}
+	}
+	
+	data "oci_load_balancer_work_requests" "t" {
+		load_balancer_id = "${oci_load_balancer_backendset.t.load_balancer_id}"
+	}`
+
+	resourceName := "data.oci_load_balancer_work_requests.t"
+
+	resource.Test(t, resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 providers,
+		Steps: []resource.TestStep{
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				Config:            config,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "load_balancer_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "work_requests.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "work_requests.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "work_requests.0.type"),
+					resource.TestCheckResourceAttrSet(resourceName, "work_requests.0.time_accepted"),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.0.state", baremetal.WorkRequestSucceeded),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.0.error_details.#", "0"),
+				),
+			},
+		},
+	})
+}
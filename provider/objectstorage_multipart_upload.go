@@ -0,0 +1,276 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/options"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+const (
+	defaultMultipartThresholdInMBs  = 128
+	defaultMultipartPartSizeInMBs   = 64
+	defaultMultipartParallelUploads = 4
+
+	// maxUploadParts is the most parts an object can be assembled from.
+	maxUploadParts = 10000
+	// partUploadAttempts is how many times a part is sent before the upload
+	// is given up on and left to be resumed.
+	partUploadAttempts = 3
+
+	bytesPerMB = 1 << 20
+
+	objectMetadataPrefix = "opc-meta-"
+)
+
+// multipartUpload uploads a local file to an object in parts. An unfinished
+// upload of the same object, left behind by an earlier failure, is resumed:
+// parts that were already uploaded with the same content are not sent again.
+type multipartUpload struct {
+	client    *sdk.Client
+	namespace baremetal.Namespace
+	bucket    string
+	object    string
+	source    string
+	partSize  int64
+	parallel  int
+	opts      *sdk.CreateMultipartUploadOptions
+}
+
+func (u *multipartUpload) upload() (e error) {
+	f, e := os.Open(u.source)
+	if e != nil {
+		return
+	}
+	defer f.Close()
+
+	info, e := f.Stat()
+	if e != nil {
+		return
+	}
+	count := partCount(info.Size(), u.partSize)
+	if count > maxUploadParts {
+		return fmt.Errorf("%s needs %d parts of %d bytes, more than the %d allowed. Increase multipart_part_size_in_mbs.",
+			u.source, count, u.partSize, maxUploadParts)
+	}
+
+	uploadID, uploaded, e := u.resume()
+	if e != nil {
+		return
+	}
+	if uploadID == "" {
+		var res *sdk.MultipartUpload
+		if res, e = u.client.CreateMultipartUpload(u.namespace, u.bucket, u.object, u.opts); e != nil {
+			return
+		}
+		uploadID = res.UploadID
+	}
+
+	parts, e := u.uploadParts(f, info.Size(), count, uploadID, uploaded)
+	if e != nil {
+		return fmt.Errorf("Upload of %s to %s/%s did not finish and will be resumed on the next apply: %s",
+			u.source, u.bucket, u.object, e)
+	}
+
+	// Parts left over from an upload of a larger file are dropped.
+	exclude := []int{}
+	for n := range uploaded {
+		if n > count {
+			exclude = append(exclude, n)
+		}
+	}
+	sort.Ints(exclude)
+
+	commitOpts := &sdk.CommitMultipartUploadOptions{PartsToExclude: exclude}
+	if _, e = u.client.CommitMultipartUpload(u.namespace, u.bucket, u.object, uploadID, parts, commitOpts); e != nil {
+		// The parts could not be assembled, so start over next time rather
+		// than resume.
+		if abortErr := u.client.AbortMultipartUpload(u.namespace, u.bucket, u.object, uploadID, nil); abortErr != nil {
+			log.Printf("[WARN] Could not abort upload %s of %s: %s", uploadID, u.object, abortErr)
+		}
+	}
+	return
+}
+
+// resume finds the most recent unfinished upload of the object and the
+// parts uploaded to it so far. uploadID is empty if there is none.
+func (u *multipartUpload) resume() (uploadID string, parts map[int]sdk.MultipartUploadPartSummary, e error) {
+	parts = map[int]sdk.MultipartUploadPartSummary{}
+
+	var latest *sdk.MultipartUpload
+	opts := &sdk.ListMultipartUploadsOptions{}
+	for {
+		var list *sdk.ListMultipartUploads
+		if list, e = u.client.ListMultipartUploads(u.namespace, u.bucket, opts); e != nil {
+			return
+		}
+		for i, v := range list.MultipartUploads {
+			if v.Object == u.object && (latest == nil || v.TimeCreated.After(latest.TimeCreated.Time)) {
+				latest = &list.MultipartUploads[i]
+			}
+		}
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	opts = &sdk.ListMultipartUploadsOptions{}
+	for {
+		var list *sdk.ListMultipartUploadParts
+		if list, e = u.client.ListMultipartUploadParts(u.namespace, u.bucket, u.object, latest.UploadID, opts); e != nil {
+			return
+		}
+		for _, v := range list.Parts {
+			parts[v.PartNumber] = v
+		}
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	log.Printf("[INFO] Resuming upload %s of %s with %d parts already uploaded", latest.UploadID, u.object, len(parts))
+	return latest.UploadID, parts, nil
+}
+
+// uploadParts sends every part of f that is not already in uploaded, using
+// up to u.parallel concurrent requests. Every part is attempted even if
+// others fail, so as much as possible is kept for the next attempt.
+func (u *multipartUpload) uploadParts(
+	f *os.File,
+	size int64,
+	count int,
+	uploadID string,
+	uploaded map[int]sdk.MultipartUploadPartSummary,
+) ([]sdk.CommitMultipartUploadPartDetails, error) {
+	parts := make([]sdk.CommitMultipartUploadPartDetails, count)
+	nums := make(chan int)
+	errs := make(chan error, count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < u.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, u.partSize)
+			for n := range nums {
+				etag, err := u.uploadPart(f, size, uploadID, n, buf, uploaded)
+				if err != nil {
+					errs <- fmt.Errorf("part %d: %s", n, err)
+					continue
+				}
+				parts[n-1] = sdk.CommitMultipartUploadPartDetails{PartNum: n, ETag: etag}
+			}
+		}()
+	}
+	for n := 1; n <= count; n++ {
+		nums <- n
+	}
+	close(nums)
+	wg.Wait()
+	close(errs)
+
+	var failed []string
+	for err := range errs {
+		failed = append(failed, err.Error())
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return nil, fmt.Errorf("%d of %d parts failed: %s", len(failed), count, strings.Join(failed, "; "))
+	}
+	return parts, nil
+}
+
+// uploadPart reads part n of f into buf and uploads it, unless a part with
+// the same content was uploaded before. It returns the part's ETag.
+func (u *multipartUpload) uploadPart(
+	f *os.File,
+	size int64,
+	uploadID string,
+	n int,
+	buf []byte,
+	uploaded map[int]sdk.MultipartUploadPartSummary,
+) (etag string, e error) {
+	offset := int64(n-1) * u.partSize
+	length := u.partSize
+	if offset+length > size {
+		length = size - offset
+	}
+	body := buf[:length]
+	if _, e = f.ReadAt(body, offset); e != nil && e != io.EOF {
+		return
+	}
+
+	sum := md5.Sum(body)
+	digest := base64.StdEncoding.EncodeToString(sum[:])
+	if p, ok := uploaded[n]; ok && p.MD5 == digest && int64(p.Size) == length {
+		return p.ETag, nil
+	}
+
+	opts := &sdk.UploadPartOptions{ContentMD5: digest}
+	for attempt := 1; ; attempt++ {
+		var res *sdk.MultipartUploadPart
+		if res, e = u.client.UploadPart(u.namespace, u.bucket, u.object, uploadID, n, body, opts); e == nil {
+			return res.ETag, nil
+		}
+		if attempt == partUploadAttempts {
+			return
+		}
+		log.Printf("[WARN] Upload of part %d of %s failed, retrying: %s", n, u.object, e)
+	}
+}
+
+func partCount(size, partSize int64) int {
+	return int((size + partSize - 1) / partSize)
+}
+
+// sourceMD5 returns the digest object storage reports for source once
+// uploaded: the base64 MD5 of the file, or for a multipart upload the MD5 of
+// its parts' MD5s followed by the number of parts.
+func sourceMD5(source string, multipart bool, partSize int64) (string, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if !multipart {
+		h := md5.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+	}
+
+	digests := md5.New()
+	count := 0
+	for {
+		h := md5.New()
+		n, err := io.CopyN(h, f, partSize)
+		if n > 0 {
+			digests.Write(h.Sum(nil))
+			count++
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s-%d", base64.StdEncoding.EncodeToString(digests.Sum(nil)), count), nil
+}
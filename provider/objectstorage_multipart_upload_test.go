@@ -0,0 +1,131 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/oracle/bmcs-go-sdk"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-oci/fakeoci"
+	"github.com/oracle/terraform-provider-oci/sdk"
+)
+
+// MultipartUploadTestSuite uploads against an in-memory fake, which lets
+// part uploads be failed on purpose.
+type MultipartUploadTestSuite struct {
+	suite.Suite
+	Server  *fakeoci.Server
+	Client  *sdk.Client
+	Source  string
+	Content []byte
+	Upload  *multipartUpload
+}
+
+func (s *MultipartUploadTestSuite) SetupTest() {
+	s.Server = fakeoci.NewServer()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.Client, err = sdk.NewClient("ocid1.user.oc1..fakeuser", s.Server.TenancyID, "aa:bb", key,
+		baremetal.Region(s.Server.Region),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.DisableAutoRetries(true),
+		baremetal.CustomTransport(&http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}),
+	)
+	s.Require().NoError(err)
+
+	namespace := baremetal.Namespace(s.Server.Namespace)
+	_, err = s.Client.CreateBucket("ocid1.compartment.oc1..fake", "uploads", namespace, nil)
+	s.Require().NoError(err)
+
+	// Six parts, the last one short.
+	s.Content = make([]byte, 5*1024+100)
+	_, err = rand.Read(s.Content)
+	s.Require().NoError(err)
+	f, err := ioutil.TempFile("", "multipart")
+	s.Require().NoError(err)
+	_, err = f.Write(s.Content)
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+	s.Source = f.Name()
+
+	s.Upload = &multipartUpload{
+		client:    s.Client,
+		namespace: namespace,
+		bucket:    "uploads",
+		object:    "large-object",
+		source:    s.Source,
+		partSize:  1024,
+		parallel:  1,
+		opts: &sdk.CreateMultipartUploadOptions{
+			ContentType: "application/octet-stream",
+			Metadata:    map[string]string{"opc-meta-version": "1"},
+		},
+	}
+}
+
+func (s *MultipartUploadTestSuite) TearDownTest() {
+	os.Remove(s.Source)
+	s.Server.Close()
+}
+
+func (s *MultipartUploadTestSuite) uploads() []sdk.MultipartUpload {
+	list, err := s.Client.ListMultipartUploads(s.Upload.namespace, "uploads", nil)
+	s.Require().NoError(err)
+	return list.MultipartUploads
+}
+
+func (s *MultipartUploadTestSuite) TestUpload() {
+	s.Upload.parallel = 3
+	s.Require().NoError(s.Upload.upload())
+	s.Empty(s.uploads())
+
+	object, err := s.Client.GetObject(s.Upload.namespace, "uploads", "large-object", nil)
+	s.Require().NoError(err)
+	s.True(bytes.Equal(s.Content, object.Body))
+	s.Equal("1", object.Metadata["version"])
+
+	digest, err := sourceMD5(s.Source, true, s.Upload.partSize)
+	s.Require().NoError(err)
+	s.Equal(digest, object.ContentMD5)
+	s.Contains(digest, "-6")
+}
+
+func (s *MultipartUploadTestSuite) TestUploadResumesAfterFailure() {
+	// Every attempt at the first part fails.
+	for i := 0; i < partUploadAttempts; i++ {
+		s.Server.InjectError(http.MethodPut, "/u/large-object", http.StatusServiceUnavailable, "ServiceUnavailable")
+	}
+	err := s.Upload.upload()
+	s.Require().Error(err)
+	s.Contains(err.Error(), "1 of 6 parts failed")
+
+	uploads := s.uploads()
+	s.Require().Len(uploads, 1)
+	parts, err := s.Client.ListMultipartUploadParts(s.Upload.namespace, "uploads", "large-object", uploads[0].UploadID, nil)
+	s.Require().NoError(err)
+	s.Len(parts.Parts, 5)
+
+	// Only the missing part is sent again.
+	puts := s.Server.RequestCount(http.MethodPut, "/u/large-object")
+	s.Require().NoError(s.Upload.upload())
+	s.Equal(puts+1, s.Server.RequestCount(http.MethodPut, "/u/large-object"))
+	s.Empty(s.uploads())
+
+	object, err := s.Client.GetObject(s.Upload.namespace, "uploads", "large-object", nil)
+	s.Require().NoError(err)
+	s.True(bytes.Equal(s.Content, object.Body))
+}
+
+func TestMultipartUploadTestSuite(t *testing.T) {
+	suite.Run(t, new(MultipartUploadTestSuite))
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/crud"
+)
+
+// defaultObjectContentLengthLimit keeps large objects from being pulled
+// into the state by accident.
+const defaultObjectContentLengthLimit = 1 * bytesPerMB
+
+func ObjectContentDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readObjectContent,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"object": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"content_length_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultObjectContentLengthLimit,
+			},
+			"base64_encode_content": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readObjectContent(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(*OracleClients)
+	reader := &ObjectContentDatasourceCrud{}
+	reader.D = d
+	reader.Client = client.client
+
+	return crud.ReadResource(reader)
+}
+
+type ObjectContentDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.Object
+}
+
+func (s *ObjectContentDatasourceCrud) Get() (e error) {
+	namespace := baremetal.Namespace(s.D.Get("namespace").(string))
+	bucket := s.D.Get("bucket").(string)
+	object := s.D.Get("object").(string)
+
+	// Check the size before downloading anything.
+	head, e := s.Client.HeadObject(namespace, bucket, object, &baremetal.HeadObjectOptions{})
+	if e != nil {
+		return
+	}
+	limit := uint64(s.D.Get("content_length_limit").(int))
+	if head.ContentLength > limit {
+		return fmt.Errorf("Object %s/%s is %d bytes, larger than content_length_limit of %d bytes",
+			bucket, object, head.ContentLength, limit)
+	}
+
+	// Fetch the version that was measured; a concurrent overwrite fails
+	// the read instead of returning content past the limit.
+	opts := &baremetal.GetObjectOptions{}
+	opts.IfMatch = head.ETag
+	if s.Res, e = s.Client.GetObject(namespace, bucket, object, opts); e != nil {
+		return
+	}
+
+	if !s.D.Get("base64_encode_content").(bool) && !utf8.Valid(s.Res.Body) {
+		return fmt.Errorf("Object %s/%s is not valid UTF-8 text. Set base64_encode_content to read binary content.",
+			bucket, object)
+	}
+	return
+}
+
+func (s *ObjectContentDatasourceCrud) SetData() {
+	if s.Res == nil {
+		return
+	}
+	// Important, if you don't have an ID, make one up for your datasource
+	// or things will end in tears
+	s.D.SetId(time.Now().UTC().String())
+
+	if s.D.Get("base64_encode_content").(bool) {
+		s.D.Set("content", base64.StdEncoding.EncodeToString(s.Res.Body))
+	} else {
+		s.D.Set("content", string(s.Res.Body))
+	}
+	s.D.Set("content_md5", s.Res.ContentMD5)
+	s.D.Set("content_length", len(s.Res.Body))
+	s.D.Set("content_type", s.Res.ContentType)
+	s.D.Set("content_language", s.Res.ContentLanguage)
+	s.D.Set("content_encoding", s.Res.ContentEncoding)
+	s.D.Set("etag", s.Res.ETag)
+	s.D.Set("metadata", s.Res.Metadata)
+	return
+}
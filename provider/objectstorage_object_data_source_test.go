@@ -0,0 +1,146 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatasourceObjectstorageObjectContentTestSuite struct {
+	suite.Suite
+	Client       *baremetal.Client
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+	Token        string
+	TokenFn      func(string, map[string]string) string
+}
+
+func (s *DatasourceObjectstorageObjectContentTestSuite) SetupTest() {
+	s.Token, s.TokenFn = tokenize()
+	s.Client = testAccClient
+	s.Provider = testAccProvider
+	s.Providers = testAccProviders
+	s.Config = testProviderConfig() + s.TokenFn(`
+	data "oci_objectstorage_namespace" "t" {
+	}
+
+	resource "oci_objectstorage_bucket" "t" {
+		compartment_id = "${var.compartment_id}"
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		name = "{{.token}}"
+		access_type="ObjectRead"
+	}
+
+	resource "oci_objectstorage_object" "t" {
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		bucket = "${oci_objectstorage_bucket.t.name}"
+		object = "-tf-object"
+		content = "test content"
+		content_type = "text/plain"
+		metadata = {
+			"version" = "1"
+		}
+	}`, nil)
+	s.ResourceName = "data.oci_objectstorage_object.t"
+}
+
+func (s *DatasourceObjectstorageObjectContentTestSuite) TestAccDatasourceObjectstorageObject_basic() {
+	defer onFakeOCI()()
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				data "oci_objectstorage_object" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					object = "${oci_objectstorage_object.t.object}"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "content", "test content"),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_length", "12"),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_md5", "lHP90NiApDwht3eNNIchVw=="),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_type", "text/plain"),
+					resource.TestCheckResourceAttr(s.ResourceName, "metadata.version", "1"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "etag"),
+				),
+			},
+			{
+				Config: s.Config + `
+				data "oci_objectstorage_object" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					object = "${oci_objectstorage_object.t.object}"
+					base64_encode_content = true
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "content", "dGVzdCBjb250ZW50"),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_length", "12"),
+				),
+			},
+		},
+	})
+}
+
+// A failed step drops the test state, so the bucket for the error cases is
+// managed outside of terraform.
+func (s *DatasourceObjectstorageObjectContentTestSuite) TestAccDatasourceObjectstorageObject_binary() {
+	defer onFakeOCI()()
+	if os.Getenv(resource.TestEnvVar) == "" {
+		s.T().Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+
+	namespace, err := s.Client.GetNamespace()
+	s.Require().NoError(err)
+	_, err = s.Client.CreateBucket(getRequiredEnvSetting("compartment_ocid"), s.Token, *namespace, nil)
+	s.Require().NoError(err)
+	defer s.Client.DeleteBucket(s.Token, *namespace, nil)
+	_, err = s.Client.PutObject(*namespace, s.Token, "-tf-binary", []byte{0x00, 0xff, 0xfe, 0x80}, &baremetal.PutObjectOptions{})
+	s.Require().NoError(err)
+	defer s.Client.DeleteObject(*namespace, s.Token, "-tf-binary", nil)
+
+	config := func(args string) string {
+		return testProviderConfig() + s.TokenFn(`
+		data "oci_objectstorage_object" "t" {
+			namespace = "{{.namespace}}"
+			bucket = "{{.token}}"
+			object = "-tf-binary"
+			{{.args}}
+		}`, map[string]string{"namespace": string(*namespace), "args": args})
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config("base64_encode_content = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "content", "AP/+gA=="),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_length", "4"),
+				),
+			},
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile("not valid UTF-8"),
+			},
+			{
+				Config:      config("base64_encode_content = true\ncontent_length_limit = 2"),
+				ExpectError: regexp.MustCompile("larger than content_length_limit"),
+			},
+		},
+	})
+}
+
+func TestDatasourceObjectstorageObjectContentTestSuite(t *testing.T) {
+	suite.Run(t, new(DatasourceObjectstorageObjectContentTestSuite))
+}
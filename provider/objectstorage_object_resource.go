@@ -3,15 +3,22 @@
 package provider
 
 import (
+	"io/ioutil"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 
 	"crypto/md5"
+	"encoding/base64"
 	"encoding/hex"
 
 	"github.com/oracle/terraform-provider-oci/crud"
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 func ObjectResource() *schema.Resource {
@@ -32,33 +39,32 @@ func ObjectResource() *schema.Resource {
 			ForceNew: true,
 		},
 		"content": {
-			Type:     schema.TypeString,
-			Optional: true,
-			ForceNew: true,
-			StateFunc: func(body interface{}) string {
-				v := body.(string)
-				if v == "" {
-					return ""
-				}
-				h := md5.Sum([]byte(v))
-				return hex.EncodeToString(h[:])
-			},
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"source"},
+			StateFunc:     hashObjectContent,
 		},
 		"content_encoding": {
 			Type:     schema.TypeString,
 			Optional: true,
-			ForceNew: true,
 		},
 		"content_language": {
 			Type:     schema.TypeString,
 			Optional: true,
-			ForceNew: true,
 		},
 		"content_length": {
 			Type:     schema.TypeInt,
 			Computed: true,
 		},
+		// content_md5 is not meant to be set. It is optional so that the
+		// digest of the source file can be compared with it on every plan,
+		// uploading the file again when it has changed.
 		"content_md5": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressUnchangedSourceDiff,
+		},
+		"etag": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
@@ -66,12 +72,33 @@ func ObjectResource() *schema.Resource {
 			Type:     schema.TypeString,
 			Optional: true,
 			Computed: true,
-			ForceNew: true,
 		},
 		"metadata": {
 			Type:     schema.TypeMap,
 			Optional: true,
-			ForceNew: true,
+		},
+		"source": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"content"},
+		},
+		"multipart_threshold_in_mbs": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultMultipartThresholdInMBs,
+			ValidateFunc: validation.IntBetween(0, 50*1024),
+		},
+		"multipart_part_size_in_mbs": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultMultipartPartSizeInMBs,
+			ValidateFunc: validation.IntBetween(10, 50*1024),
+		},
+		"multipart_parallel_uploads": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultMultipartParallelUploads,
+			ValidateFunc: validation.IntBetween(1, 32),
 		},
 	}
 
@@ -79,12 +106,41 @@ func ObjectResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createObject,
-		Read:     readObject,
-		Delete:   deleteObject,
-		Schema:   objectSchema,
+		SchemaVersion: 1,
+		MigrateState:  migrateObjectState,
+		Timeouts:      crud.DefaultTimeout,
+		Create:        createObject,
+		Read:          readObject,
+		Update:        updateObject,
+		Delete:        deleteObject,
+		Schema:        objectSchema,
+	}
+}
+
+// hashObjectContent stores content as the hex MD5 of the body rather than
+// the body itself.
+func hashObjectContent(body interface{}) string {
+	v := body.(string)
+	if v == "" {
+		return ""
+	}
+	h := md5.Sum([]byte(v))
+	return hex.EncodeToString(h[:])
+}
+
+// migrateObjectState upgrades state from before content was stored as a
+// hash. Version 0 stored the body itself.
+func migrateObjectState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		return is, nil
 	}
+	switch v {
+	case 0:
+		if content, ok := is.Attributes["content"]; ok {
+			is.Attributes["content"] = hashObjectContent(content)
+		}
+	}
+	return is, nil
 }
 
 func createObject(d *schema.ResourceData, m interface{}) (e error) {
@@ -101,6 +157,13 @@ func readObject(d *schema.ResourceData, m interface{}) (e error) {
 	return crud.ReadResource(sync)
 }
 
+func updateObject(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &ObjectResourceCrud{}
+	sync.D = d
+	sync.Client = m.(*OracleClients).client
+	return crud.UpdateResource(d, sync)
+}
+
 func deleteObject(d *schema.ResourceData, m interface{}) (e error) {
 	sync := &ObjectResourceCrud{}
 	sync.D = d
@@ -122,7 +185,10 @@ func (s *ObjectResourceCrud) SetData() {
 	s.D.Set("namespace", s.Res.Namespace)
 	s.D.Set("bucket", s.Res.Bucket)
 	s.D.Set("object", s.Res.ID)
-	s.D.Set("content", s.Res.Body)
+	if _, ok := s.D.GetOk("source"); !ok {
+		s.D.Set("content", remoteContentHash(s.Res.ContentMD5, s.Res.ContentLength))
+	}
+	s.D.Set("etag", s.Res.ETag)
 	s.D.Set("metadata", s.Res.Metadata)
 	s.D.Set("content_encoding", s.Res.ContentEncoding)
 	s.D.Set("content_language", s.Res.ContentLanguage)
@@ -132,11 +198,19 @@ func (s *ObjectResourceCrud) SetData() {
 }
 
 func (s *ObjectResourceCrud) Create() (e error) {
+	if e = s.put(&baremetal.PutObjectOptions{}); e == nil {
+		e = s.Get()
+	}
+	return
+}
+
+// put uploads the body of the object from content or source, together
+// with its headers.
+func (s *ObjectResourceCrud) put(opts *baremetal.PutObjectOptions) (e error) {
 	namespace := s.D.Get("namespace").(string)
 	bucket := s.D.Get("bucket").(string)
 	object := s.D.Get("object").(string)
 	content := s.D.Get("content").(string)
-	opts := &baremetal.PutObjectOptions{}
 
 	if contentEncoding, ok := s.D.GetOk("content_encoding"); ok {
 		opts.ContentEncoding = contentEncoding.(string)
@@ -154,24 +228,95 @@ func (s *ObjectResourceCrud) Create() (e error) {
 		metadata := resourceObjectStorageMapToMetadata(rawMetadata.(map[string]interface{}))
 		opts.Metadata = metadata
 	}
-	_, e = s.Client.PutObject(baremetal.Namespace(namespace), bucket, object, []byte(content), opts)
-	if e == nil {
-		s.Res, e = s.Client.GetObject(baremetal.Namespace(namespace), bucket, object, &baremetal.GetObjectOptions{})
+
+	if source, ok := s.D.GetOk("source"); ok {
+		return s.putSource(source.(string), opts)
 	}
+	_, e = s.Client.PutObject(baremetal.Namespace(namespace), bucket, object, []byte(content), opts)
 	return
 }
 
+// putSource uploads the source file in a single request, or in parts once
+// it is larger than multipart_threshold_in_mbs.
+func (s *ObjectResourceCrud) putSource(source string, opts *baremetal.PutObjectOptions) (e error) {
+	namespace := s.D.Get("namespace").(string)
+	bucket := s.D.Get("bucket").(string)
+	object := s.D.Get("object").(string)
+
+	info, e := os.Stat(source)
+	if e != nil {
+		return
+	}
+
+	if info.Size() <= int64(s.D.Get("multipart_threshold_in_mbs").(int))*bytesPerMB {
+		var content []byte
+		if content, e = ioutil.ReadFile(source); e != nil {
+			return
+		}
+		_, e = s.Client.PutObject(baremetal.Namespace(namespace), bucket, object, content, opts)
+		return
+	}
+
+	// Multipart metadata keys carry the prefix PutObject adds to headers.
+	metadata := map[string]string{}
+	for k, v := range opts.Metadata {
+		metadata[objectMetadataPrefix+k] = v
+	}
+	upload := &multipartUpload{
+		client:    s.Client,
+		namespace: baremetal.Namespace(namespace),
+		bucket:    bucket,
+		object:    object,
+		source:    source,
+		partSize:  int64(s.D.Get("multipart_part_size_in_mbs").(int)) * bytesPerMB,
+		parallel:  s.D.Get("multipart_parallel_uploads").(int),
+		opts: &sdk.CreateMultipartUploadOptions{
+			IfMatchOptions:  opts.IfMatchOptions,
+			ContentType:     opts.ContentType,
+			ContentLanguage: opts.ContentLanguage,
+			ContentEncoding: opts.ContentEncoding,
+			Metadata:        metadata,
+		},
+	}
+	if upload.opts.ContentType == "" {
+		upload.opts.ContentType = "application/octet-stream"
+	}
+	return upload.upload()
+}
+
+// Get reads the headers of the object. Its body is never downloaded;
+// changes to it are seen through its MD5.
 func (s *ObjectResourceCrud) Get() (e error) {
 	namespace := s.D.Get("namespace").(string)
 	bucket := s.D.Get("bucket").(string)
 	object := s.D.Get("object").(string)
-	res, e := s.Client.GetObject(baremetal.Namespace(namespace), bucket, object, &baremetal.GetObjectOptions{})
+
+	head, e := s.Client.HeadObject(baremetal.Namespace(namespace), bucket, object, &baremetal.HeadObjectOptions{})
 	if e == nil {
-		s.Res = res
+		s.Res = &baremetal.Object{HeadObject: *head}
 	}
 	return
 }
 
+// Update uploads the object again, keeping its name, when its body or
+// headers have changed. Object storage cannot change headers alone. The
+// upload only succeeds if the object has not been changed since it was
+// last read.
+func (s *ObjectResourceCrud) Update() (e error) {
+	changed := false
+	for _, k := range []string{"content", "source", "content_md5", "content_encoding", "content_language", "content_type", "metadata"} {
+		changed = changed || s.D.HasChange(k)
+	}
+	if changed {
+		opts := &baremetal.PutObjectOptions{}
+		opts.IfMatch = s.D.Get("etag").(string)
+		if e = s.put(opts); e != nil {
+			return
+		}
+	}
+	return s.Get()
+}
+
 func (s *ObjectResourceCrud) Delete() (e error) {
 	namespace := s.D.Get("namespace").(string)
 	bucket := s.D.Get("bucket").(string)
@@ -181,3 +326,43 @@ func (s *ObjectResourceCrud) Delete() (e error) {
 	_, e = s.Client.DeleteObject(baremetal.Namespace(namespace), bucket, object, opts)
 	return
 }
+
+// suppressUnchangedSourceDiff hides the content_md5 diff unless the source
+// file no longer matches the uploaded object. The file's digest is worked
+// out the same way as the stored one: whole, or per part using the part
+// size the object was uploaded with.
+func suppressUnchangedSourceDiff(k, old, new string, d *schema.ResourceData) bool {
+	source, ok := d.GetOk("source")
+	if !ok || old == "" {
+		return true
+	}
+
+	multipart := strings.Contains(old, "-")
+	oldPartSize, newPartSize := d.GetChange("multipart_part_size_in_mbs")
+	partSize := oldPartSize.(int)
+	if partSize == 0 {
+		partSize = newPartSize.(int)
+	}
+
+	digest, err := sourceMD5(source.(string), multipart, int64(partSize)*bytesPerMB)
+	if err != nil {
+		log.Printf("[WARN] Could not read %s: %s", source, err)
+		return false
+	}
+	return digest == old
+}
+
+// remoteContentHash returns the hex MD5 of the object body, which is how
+// the content StateFunc stores it, so that a body changed outside of
+// Terraform shows up as a diff. Digests of objects uploaded in parts cannot
+// be converted and never match.
+func remoteContentHash(contentMD5 string, contentLength uint64) string {
+	if contentLength == 0 {
+		return ""
+	}
+	raw, err := base64.StdEncoding.DecodeString(contentMD5)
+	if err != nil {
+		return contentMD5
+	}
+	return hex.EncodeToString(raw)
+}
@@ -3,6 +3,10 @@
 package provider
 
 import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -44,7 +48,21 @@ func (s *ResourceObjectstorageObjectTestSuite) SetupTest() {
 }
 
 func (s *ResourceObjectstorageObjectTestSuite) TestAccResourceObjectstorageObject_basic() {
-	var resId, resId2 string
+	var resId, resId2, objectId string
+	updateConfig := s.Config + `
+				resource "oci_objectstorage_object" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					object = "-tf-object"
+					content = "{}"
+					content_type = "text/json"
+					content_language = "*"
+					content_encoding = "identity"
+					metadata = {
+						"version" = "2"
+						"modified" = "10-18-2017"
+					}
+				}`
 	resource.Test(s.T(), resource.TestCase{
 		Providers: s.Providers,
 		Steps: []resource.TestStep{
@@ -74,6 +92,9 @@ func (s *ResourceObjectstorageObjectTestSuite) TestAccResourceObjectstorageObjec
 					resource.TestCheckResourceAttrSet(s.ResourceName, "content_md5"),
 					resource.TestCheckResourceAttr(s.ResourceName, "metadata.version", "1"),
 					func(s *terraform.State) (err error) {
+						if objectId, err = fromInstanceState(s, "oci_objectstorage_object.t", "id"); err != nil {
+							return err
+						}
 						resId, err = fromInstanceState(s, "oci_objectstorage_object.t", "content")
 						return err
 					},
@@ -81,20 +102,7 @@ func (s *ResourceObjectstorageObjectTestSuite) TestAccResourceObjectstorageObjec
 			},
 			// verify update
 			{
-				Config: s.Config + `
-				resource "oci_objectstorage_object" "t" {
-					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
-					bucket = "${oci_objectstorage_bucket.t.name}"
-					object = "-tf-object"
-					content = "{}"
-					content_type = "text/json"
-					content_language = "*"
-					content_encoding = "identity"
-					metadata = {
-						"version" = "2"
-						"modified" = "10-18-2017"
-					}
-				}`,
+				Config: updateConfig,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(s.ResourceName, "content"),
 					resource.TestCheckResourceAttr(s.ResourceName, "content_type", "text/json"),
@@ -108,14 +116,153 @@ func (s *ResourceObjectstorageObjectTestSuite) TestAccResourceObjectstorageObjec
 						if resId == resId2 {
 							return fmt.Errorf("Expected different content hash, got same.")
 						}
+						if id, _ := fromInstanceState(s, "oci_objectstorage_object.t", "id"); id != objectId {
+							return fmt.Errorf("Expected the object to be updated in place, got new id %s", id)
+						}
+						return err
+					},
+				),
+			},
+			// verify a change made outside of terraform shows up as drift
+			{
+				PreConfig: func() {
+					namespace, err := s.Client.GetNamespace()
+					s.Require().NoError(err)
+					_, err = s.Client.PutObject(*namespace, s.Token, "-tf-object", []byte("changed"), &baremetal.PutObjectOptions{
+						ContentType:     "text/json",
+						ContentLanguage: "*",
+						ContentEncoding: "identity",
+						MetadataUnmarshaller: baremetal.MetadataUnmarshaller{
+							Metadata: map[string]string{"version": "2", "modified": "10-18-2017"},
+						},
+					})
+					s.Require().NoError(err)
+				},
+				Config:             updateConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// verify the drift is corrected in place
+			{
+				Config: updateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "content_length", "2"),
+					func(s *terraform.State) (err error) {
+						content, err := fromInstanceState(s, "oci_objectstorage_object.t", "content")
+						if content != resId2 {
+							return fmt.Errorf("Expected content hash %s, got %s", resId2, content)
+						}
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
+func (s *ResourceObjectstorageObjectTestSuite) TestAccResourceObjectstorageObject_source() {
+	// 21 MB uploads in three parts of 10 MB.
+	source, err := ioutil.TempFile("", "object-source")
+	s.Require().NoError(err)
+	defer os.Remove(source.Name())
+	s.Require().NoError(source.Truncate(21 * 1024 * 1024))
+	s.Require().NoError(source.Close())
+
+	config := s.Config + `
+		resource "oci_objectstorage_object" "t" {
+			namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+			bucket = "${oci_objectstorage_bucket.t.name}"
+			object = "-tf-object-source"
+			source = "` + source.Name() + `"
+			multipart_threshold_in_mbs = 10
+			multipart_part_size_in_mbs = 10
+		}`
+	var md5 string
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// verify a multipart upload
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "source", source.Name()),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_length", "22020096"),
+					resource.TestCheckResourceAttr(s.ResourceName, "content_type", "application/octet-stream"),
+					func(ts *terraform.State) (err error) {
+						if md5, err = fromInstanceState(ts, s.ResourceName, "content_md5"); err != nil {
+							return err
+						}
+						if !strings.HasSuffix(md5, "-3") {
+							return fmt.Errorf("Expected a digest of 3 parts, got %s", md5)
+						}
+						return nil
+					},
+				),
+			},
+			// verify an unchanged source file has no diff
+			{
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			// verify a changed source file is uploaded again
+			{
+				PreConfig: func() {
+					f, err := os.OpenFile(source.Name(), os.O_WRONLY, 0)
+					s.Require().NoError(err)
+					_, err = f.WriteAt([]byte("changed"), 15*1024*1024)
+					s.Require().NoError(err)
+					s.Require().NoError(f.Close())
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					func(ts *terraform.State) (err error) {
+						md5b, err := fromInstanceState(ts, s.ResourceName, "content_md5")
+						if md5b == md5 {
+							return fmt.Errorf("Expected different content_md5, got same.")
+						}
+						if !strings.HasSuffix(md5b, "-3") {
+							return fmt.Errorf("Expected a digest of 3 parts, got %s", md5b)
+						}
 						return err
 					},
 				),
 			},
+			// verify tuning the upload does not replace the object
+			{
+				Config: strings.Replace(config, "multipart_threshold_in_mbs = 10", "multipart_threshold_in_mbs = 10\nmultipart_parallel_uploads = 2", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "multipart_parallel_uploads", "2"),
+				),
+			},
 		},
 	})
 }
 
+func TestObjectMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "tfobm-object-namespace/bucket/object",
+		Attributes: map[string]string{
+			"id":      "tfobm-object-namespace/bucket/object",
+			"content": "the content",
+		},
+	}
+
+	is, err := ObjectResource().MigrateState(0, is, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"id":      "tfobm-object-namespace/bucket/object",
+		"content": "da619dfbf5572fc749b1496b0fffd76a",
+	}
+	if !reflect.DeepEqual(expected, is.Attributes) {
+		t.Fatalf("Expected:\n%#v\n\nGot:\n%#v", expected, is.Attributes)
+	}
+}
+
 func TestResourceObjectstorageObjectTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceObjectstorageObjectTestSuite))
 }
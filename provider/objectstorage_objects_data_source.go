@@ -37,6 +37,10 @@ func ObjectDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"limit": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -46,6 +50,11 @@ func ObjectDatasource() *schema.Resource {
 				Computed: true,
 				Elem:     resourceObjectSummary(),
 			},
+			"prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -104,11 +113,15 @@ func (s *ObjectDatasourceCrud) Get() (e error) {
 	if end, ok := s.D.GetOk("end"); ok {
 		opts.End = end.(string)
 	}
+	if delimiter, ok := s.D.GetOk("delimiter"); ok {
+		opts.Delimiter = delimiter.(string)
+	}
 	if limit, ok := s.D.GetOk("limit"); ok {
 		opts.Limit = uint64(limit.(int))
 	}
 
-	s.Res = &baremetal.ListObjects{Objects: []baremetal.ObjectSummary{}}
+	s.Res = &baremetal.ListObjects{Objects: []baremetal.ObjectSummary{}, Prefixes: []string{}}
+	seen := map[string]bool{}
 
 	for {
 		var list *baremetal.ListObjects
@@ -117,6 +130,12 @@ func (s *ObjectDatasourceCrud) Get() (e error) {
 		}
 
 		s.Res.Objects = append(s.Res.Objects, list.Objects...)
+		for _, p := range list.Prefixes {
+			if !seen[p] {
+				seen[p] = true
+				s.Res.Prefixes = append(s.Res.Prefixes, p)
+			}
+		}
 
 		if list.NextStartWith == "" {
 			break
@@ -145,6 +164,7 @@ func (s *ObjectDatasourceCrud) SetData() {
 			resources = append(resources, res)
 		}
 		s.D.Set("objects", resources)
+		s.D.Set("prefixes", s.Res.Prefixes)
 	}
 	return
 }
@@ -82,6 +82,70 @@ func (s *DatasourceObjectstorageObjectTestSuite) TestAccDatasourceObjectstorageO
 	})
 }
 
+func (s *DatasourceObjectstorageObjectTestSuite) TestAccDatasourceObjectstorageObjects_delimiter() {
+	config := s.Config + `
+	resource "oci_objectstorage_object" "nested" {
+		count = 3
+		namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+		bucket = "${oci_objectstorage_bucket.t.name}"
+		object = "${element(list("logs/2017/a", "logs/2017/b", "logs/2018/a"), count.index)}"
+		content = "${count.index}"
+	}`
+
+	resource.Test(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config: config + `
+				data "oci_objectstorage_objects" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					prefix = "logs/"
+					delimiter = "/"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.#", "0"),
+					resource.TestCheckResourceAttr(s.ResourceName, "prefixes.#", "2"),
+					resource.TestCheckResourceAttr(s.ResourceName, "prefixes.0", "logs/2017/"),
+					resource.TestCheckResourceAttr(s.ResourceName, "prefixes.1", "logs/2018/"),
+				),
+			},
+			{
+				Config: config + `
+				data "oci_objectstorage_objects" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					start = "logs/2017/b"
+					end = "logs/2018/b"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.#", "2"),
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.0.name", "logs/2017/b"),
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.1.name", "logs/2018/a"),
+					resource.TestCheckResourceAttr(s.ResourceName, "prefixes.#", "0"),
+				),
+			},
+			// limit is the page size; every page is still read.
+			{
+				Config: config + `
+				data "oci_objectstorage_objects" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					limit = 1
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.#", "4"),
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.0.name", "-tf-object"),
+					resource.TestCheckResourceAttr(s.ResourceName, "objects.3.name", "logs/2018/a"),
+				),
+			},
+		},
+	})
+}
+
 func TestDatasourceObjectstorageObjectTestSuite(t *testing.T) {
 	suite.Run(t, new(DatasourceObjectstorageObjectTestSuite))
 }
@@ -133,8 +133,8 @@ func (s *PreauthenticatedRequestResourceCrud) Get() (e error) {
 	}
 
 	s.Id = res.ID
-	//Access URI is only accessible on create
-	s.AccessURI = ""
+	// Access URI is only returned on create, so keep the one in state
+	s.AccessURI = s.D.Get("access_uri").(string)
 	s.TimeCreated = res.TimeCreated
 	s.TimeExpires = res.TimeExpires
 	s.AccessType = res.AccessType
@@ -63,6 +63,21 @@ func (s *ResourceObjectstoragePARTestSuite) TestAccResourceObjectstoragePAR_basi
 					resource.TestCheckResourceAttr(s.ResourceName, "bucket", s.Token),
 					resource.TestCheckResourceAttr(s.ResourceName, "access_type", "AnyObjectWrite"),
 					resource.TestCheckResourceAttr(s.ResourceName, "time_expires", "2019-11-10T23:00:00Z"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "access_uri"),
+				),
+			},
+			// verify the access URI, which is only returned on create, survives a refresh
+			{
+				Config: s.Config + `
+				resource "oci_objectstorage_preauthrequest" "t" {
+					namespace = "${data.oci_objectstorage_namespace.t.namespace}"
+					bucket = "${oci_objectstorage_bucket.t.name}"
+					name = "-tf-par"
+					access_type = "AnyObjectWrite"
+					time_expires = "2019-11-10T23:00:00Z"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "access_uri"),
 				),
 			},
 		},
@@ -3,6 +3,7 @@
 package provider
 
 import (
+	"crypto/rsa"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -10,20 +11,37 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"sync"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/go-homedir"
 	"github.com/oracle/bmcs-go-sdk"
+
+	"github.com/oracle/terraform-provider-oci/sdk"
 )
 
 var descriptions map[string]string
 
+const (
+	authAPIKey            = "ApiKey"
+	authInstancePrincipal = "InstancePrincipal"
+)
+
 func init() {
 	descriptions = map[string]string{
-		"tenancy_ocid": "(Required) The tenancy OCID for a user. The tenancy OCID can be found at the bottom of user settings in the Oracle Cloud Infrastructure console.",
-		"user_ocid":    "(Required) The user OCID. This can be found in user settings in the Oracle Cloud Infrastructure console.",
-		"fingerprint":  "(Required) The fingerprint for the user's RSA key. This can be found in user settings in the Oracle Cloud Infrastructure console.",
-		"region":       "(Required) The region for API connections (e.g. us-ashburn-1).",
+		"auth": "(Optional) The type of authentication to use, ApiKey (the default) or InstancePrincipal.\n" +
+			"InstancePrincipal authenticates as the Oracle Cloud Infrastructure instance Terraform runs on, so no user or key settings are needed.",
+		"tenancy_ocid": "(Required for ApiKey) The tenancy OCID for a user. The tenancy OCID can be found at the bottom of user settings in the Oracle Cloud Infrastructure console.",
+		"user_ocid":    "(Required for ApiKey) The user OCID. This can be found in user settings in the Oracle Cloud Infrastructure console.",
+		"fingerprint":  "(Required for ApiKey) The fingerprint for the user's RSA key. This can be found in user settings in the Oracle Cloud Infrastructure console.",
+		"region": "(Required) The region for API connections (e.g. us-ashburn-1).\n" +
+			"With InstancePrincipal authentication it defaults to the region of the instance.",
+		"config_file_path": "(Optional) The path to an OCI CLI config file to read settings missing from the provider configuration from.\n" +
+			"Defaults to ~/.oci/config when config_file_profile is set.",
+		"config_file_profile": "(Optional) The profile of the config file to read settings from.\n" +
+			"Defaults to DEFAULT when config_file_path is set.",
 		"private_key": "(Optional) A PEM formatted RSA private key for the user.\n" +
 			"A private_key or a private_key_path must be provided.",
 		"private_key_path": "(Optional) The path to the user's PEM formatted private key.\n" +
@@ -36,31 +54,65 @@ func init() {
 
 // Provider is the adapter for terraform, that gives access to all the resources
 func Provider(configfn schema.ConfigureFunc) terraform.ResourceProvider {
-	return &schema.Provider{
-		DataSourcesMap: dataSourcesMap(),
-		Schema:         schemaMap(),
-		ResourcesMap:   resourcesMap(),
-		ConfigureFunc:  configfn,
+	return &ociProvider{
+		Provider: &schema.Provider{
+			DataSourcesMap: regionalDataSources(dataSourcesMap()),
+			Schema:         schemaMap(),
+			ResourcesMap:   regionalResources(resourcesMap()),
+			ConfigureFunc:  configfn,
+		},
+		diffChecks: diffChecksMap(),
 	}
 }
 
+// ociProvider runs plan time checks on resource diffs. The vendored helper/schema
+// has no CustomizeDiff, so checks that need both the state and the planned
+// change, like refusing to shrink a volume, are registered in diffChecksMap.
+type ociProvider struct {
+	*schema.Provider
+	diffChecks map[string]diffCheckFunc
+}
+
+// A diffCheckFunc fails the plan of a resource by returning an error.
+type diffCheckFunc func(d *terraform.InstanceDiff) error
+
+func (p *ociProvider) Diff(info *terraform.InstanceInfo, s *terraform.InstanceState, c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	d, err := p.Provider.Diff(info, s, c)
+	if err != nil || d == nil {
+		return d, err
+	}
+	if check, ok := p.diffChecks[info.Type]; ok {
+		if err = check(d); err != nil {
+			return nil, fmt.Errorf("%s: %s", info.Id, err)
+		}
+	}
+	return d, nil
+}
+
 func schemaMap() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"auth": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  descriptions["auth"],
+			DefaultFunc:  schema.EnvDefaultFunc("OCI_AUTH", authAPIKey),
+			ValidateFunc: validation.StringInSlice([]string{authAPIKey, authInstancePrincipal}, false),
+		},
 		"tenancy_ocid": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["tenancy_ocid"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_TENANCY_OCID", nil),
 		},
 		"user_ocid": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["user_ocid"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_USER_OCID", nil),
 		},
 		"fingerprint": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["fingerprint"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_FINGERPRINT", nil),
 		},
@@ -68,7 +120,6 @@ func schemaMap() map[string]*schema.Schema {
 		"private_key": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Default:     "",
 			Sensitive:   true,
 			Description: descriptions["private_key"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_PRIVATE_KEY", nil),
@@ -83,16 +134,27 @@ func schemaMap() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Optional:    true,
 			Sensitive:   true,
-			Default:     "",
 			Description: descriptions["private_key_password"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_PRIVATE_KEY_PASSWORD", nil),
 		},
 		"region": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["region"],
 			DefaultFunc: schema.EnvDefaultFunc("OCI_REGION", nil),
 		},
+		"config_file_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: descriptions["config_file_path"],
+			DefaultFunc: schema.EnvDefaultFunc("OCI_CONFIG_FILE_PATH", nil),
+		},
+		"config_file_profile": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: descriptions["config_file_profile"],
+			DefaultFunc: schema.EnvDefaultFunc("OCI_CONFIG_FILE_PROFILE", nil),
+		},
 		"disable_auto_retries": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -105,97 +167,127 @@ func schemaMap() map[string]*schema.Schema {
 
 func dataSourcesMap() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"oci_core_console_history_data":       ConsoleHistoryDataDatasource(),
-		"oci_core_cpes":                       CpeDatasource(),
-		"oci_core_dhcp_options":               DHCPOptionsDatasource(),
-		"oci_core_drg_attachments":            DrgAttachmentDatasource(),
-		"oci_core_drgs":                       DrgDatasource(),
-		"oci_core_images":                     ImageDatasource(),
-		"oci_core_instance_credentials":       InstanceCredentialsDatasource(),
-		"oci_core_instances":                  InstanceDatasource(),
-		"oci_core_internet_gateways":          InternetGatewayDatasource(),
-		"oci_core_ipsec_config":               IPSecConnectionConfigDatasource(),
-		"oci_core_ipsec_connections":          IPSecConnectionsDatasource(),
-		"oci_core_ipsec_status":               IPSecConnectionStatusDatasource(),
-		"oci_core_private_ips":                PrivateIPDatasource(),
-		"oci_core_route_tables":               RouteTableDatasource(),
-		"oci_core_security_lists":             SecurityListDatasource(),
-		"oci_core_shape":                      InstanceShapeDatasource(),
-		"oci_core_subnets":                    SubnetDatasource(),
-		"oci_core_virtual_networks":           VirtualNetworkDatasource(),
-		"oci_core_vnic":                       VnicDatasource(),
-		"oci_core_vnic_attachments":           DatasourceCoreVnicAttachments(),
-		"oci_core_volume_attachments":         VolumeAttachmentDatasource(),
-		"oci_core_volume_backups":             VolumeBackupDatasource(),
-		"oci_core_volumes":                    VolumeDatasource(),
-		"oci_database_database":               DatabaseDatasource(),
-		"oci_database_databases":              DatabasesDatasource(),
-		"oci_database_db_home":                DBHomeDatasource(),
-		"oci_database_db_homes":               DBHomesDatasource(),
-		"oci_database_db_node":                DBNodeDatasource(),
-		"oci_database_db_nodes":               DBNodesDatasource(),
-		"oci_database_db_system_shapes":       DBSystemShapeDatasource(),
-		"oci_database_db_systems":             DBSystemDatasource(),
-		"oci_database_db_versions":            DBVersionDatasource(),
-		"oci_identity_api_keys":               APIKeyDatasource(),
-		"oci_identity_availability_domains":   AvailabilityDomainDatasource(),
-		"oci_identity_compartments":           CompartmentDatasource(),
-		"oci_identity_groups":                 GroupDatasource(),
-		"oci_identity_policies":               IdentityPolicyDatasource(),
-		"oci_identity_swift_passwords":        SwiftPasswordDatasource(),
-		"oci_identity_user_group_memberships": UserGroupMembershipDatasource(),
-		"oci_identity_users":                  UserDatasource(),
-		"oci_load_balancer_backends":          BackendDatasource(),
-		"oci_load_balancer_backendsets":       BackendSetDatasource(),
-		"oci_load_balancer_certificates":      CertificateDatasource(),
-		"oci_load_balancer_policies":          LoadBalancerPolicyDatasource(),
-		"oci_load_balancer_protocols":         ProtocolDatasource(),
-		"oci_load_balancer_shapes":            LoadBalancerShapeDatasource(),
-		"oci_load_balancers":                  LoadBalancerDatasource(),
-		"oci_objectstorage_bucket_summaries":  BucketSummaryDatasource(),
-		"oci_objectstorage_namespace":         NamespaceDatasource(),
-		"oci_objectstorage_object_head":       ObjectHeadDatasource(),
-		"oci_objectstorage_objects":           ObjectDatasource(),
+		"oci_core_boot_volume_attachments":          BootVolumeAttachmentDatasource(),
+		"oci_core_boot_volumes":                     BootVolumeDatasource(),
+		"oci_core_console_history_data":             ConsoleHistoryDataDatasource(),
+		"oci_core_cpes":                             CpeDatasource(),
+		"oci_core_dhcp_options":                     DHCPOptionsDatasource(),
+		"oci_core_drg_attachments":                  DrgAttachmentDatasource(),
+		"oci_core_drgs":                             DrgDatasource(),
+		"oci_core_images":                           ImageDatasource(),
+		"oci_core_instance_credentials":             InstanceCredentialsDatasource(),
+		"oci_core_instances":                        InstanceDatasource(),
+		"oci_core_internet_gateways":                InternetGatewayDatasource(),
+		"oci_core_ipsec_config":                     IPSecConnectionConfigDatasource(),
+		"oci_core_ipsec_connections":                IPSecConnectionsDatasource(),
+		"oci_core_ipsec_status":                     IPSecConnectionStatusDatasource(),
+		"oci_core_private_ip":                       PrivateIPAddressDatasource(),
+		"oci_core_private_ips":                      PrivateIPDatasource(),
+		"oci_core_route_tables":                     RouteTableDatasource(),
+		"oci_core_security_lists":                   SecurityListDatasource(),
+		"oci_core_shape":                            InstanceShapeDatasource(),
+		"oci_core_subnets":                          SubnetDatasource(),
+		"oci_core_vcn_cidr_allocation":              VcnCIDRAllocationDatasource(),
+		"oci_core_virtual_networks":                 VirtualNetworkDatasource(),
+		"oci_core_vnic":                             VnicDatasource(),
+		"oci_core_vnic_attachments":                 DatasourceCoreVnicAttachments(),
+		"oci_core_volume_attachments":               VolumeAttachmentDatasource(),
+		"oci_core_volume_backup_policies":           VolumeBackupPolicyDatasource(),
+		"oci_core_volume_backup_policy_assignments": VolumeBackupPolicyAssignmentDatasource(),
+		"oci_core_volume_backups":                   VolumeBackupDatasource(),
+		"oci_core_volumes":                          VolumeDatasource(),
+		"oci_database_database":                     DatabaseDatasource(),
+		"oci_database_databases":                    DatabasesDatasource(),
+		"oci_database_db_home":                      DBHomeDatasource(),
+		"oci_database_db_homes":                     DBHomesDatasource(),
+		"oci_database_db_node":                      DBNodeDatasource(),
+		"oci_database_db_nodes":                     DBNodesDatasource(),
+		"oci_database_db_system_shapes":             DBSystemShapeDatasource(),
+		"oci_database_db_systems":                   DBSystemDatasource(),
+		"oci_database_db_versions":                  DBVersionDatasource(),
+		"oci_identity_api_keys":                     APIKeyDatasource(),
+		"oci_identity_availability_domains":         AvailabilityDomainDatasource(),
+		"oci_identity_compartments":                 CompartmentDatasource(),
+		"oci_identity_dynamic_groups":               DynamicGroupDatasource(),
+		"oci_identity_groups":                       GroupDatasource(),
+		"oci_identity_policies":                     IdentityPolicyDatasource(),
+		"oci_identity_policy_evaluation":            PolicyEvaluationDatasource(),
+		"oci_identity_regions":                      RegionDatasource(),
+		"oci_identity_swift_passwords":              SwiftPasswordDatasource(),
+		"oci_identity_tenancy":                      TenancyDatasource(),
+		"oci_identity_user_group_memberships":       UserGroupMembershipDatasource(),
+		"oci_identity_users":                        UserDatasource(),
+		"oci_load_balancer_backends":                BackendDatasource(),
+		"oci_load_balancer_backendsets":             BackendSetDatasource(),
+		"oci_load_balancer_certificates":            CertificateDatasource(),
+		"oci_load_balancer_health_checker":          HealthCheckerDatasource(),
+		"oci_load_balancer_policies":                LoadBalancerPolicyDatasource(),
+		"oci_load_balancer_protocols":               ProtocolDatasource(),
+		"oci_load_balancer_shapes":                  LoadBalancerShapeDatasource(),
+		"oci_load_balancer_work_request":            LoadBalancerWorkRequestDatasource(),
+		"oci_load_balancer_work_requests":           LoadBalancerWorkRequestsDatasource(),
+		"oci_load_balancers":                        LoadBalancerDatasource(),
+		"oci_objectstorage_bucket_summaries":        BucketSummaryDatasource(),
+		"oci_objectstorage_namespace":               NamespaceDatasource(),
+		"oci_objectstorage_object":                  ObjectContentDatasource(),
+		"oci_objectstorage_object_head":             ObjectHeadDatasource(),
+		"oci_objectstorage_objects":                 ObjectDatasource(),
 	}
 }
 
 func resourcesMap() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"oci_core_console_history":           ConsoleHistoryResource(),
-		"oci_core_cpe":                       CpeResource(),
-		"oci_core_dhcp_options":              DHCPOptionsResource(),
-		"oci_core_drg":                       DrgResource(),
-		"oci_core_drg_attachment":            DrgAttachmentResource(),
-		"oci_core_image":                     ImageResource(),
-		"oci_core_instance":                  InstanceResource(),
-		"oci_core_internet_gateway":          InternetGatewayResource(),
-		"oci_core_ipsec":                     IPSecConnectionResource(),
-		"oci_core_private_ip":                PrivateIPResource(),
-		"oci_core_route_table":               RouteTableResource(),
-		"oci_core_security_list":             SecurityListResource(),
-		"oci_core_subnet":                    SubnetResource(),
-		"oci_core_virtual_network":           VirtualNetworkResource(),
-		"oci_core_vnic_attachment":           VnicAttachmentResource(),
-		"oci_core_volume":                    VolumeResource(),
-		"oci_core_volume_attachment":         VolumeAttachmentResource(),
-		"oci_core_volume_backup":             VolumeBackupResource(),
-		"oci_database_db_system":             DBSystemResource(),
-		"oci_identity_api_key":               APIKeyResource(),
-		"oci_identity_compartment":           CompartmentResource(),
-		"oci_identity_group":                 GroupResource(),
-		"oci_identity_policy":                PolicyResource(),
-		"oci_identity_swift_password":        SwiftPasswordResource(),
-		"oci_identity_ui_password":           UIPasswordResource(),
-		"oci_identity_user":                  UserResource(),
-		"oci_identity_user_group_membership": UserGroupMembershipResource(),
-		"oci_load_balancer":                  LoadBalancerResource(),
-		"oci_load_balancer_backend":          LoadBalancerBackendResource(),
-		"oci_load_balancer_backendset":       LoadBalancerBackendSetResource(),
-		"oci_load_balancer_certificate":      LoadBalancerCertificateResource(),
-		"oci_load_balancer_listener":         LoadBalancerListenerResource(),
-		"oci_objectstorage_bucket":           BucketResource(),
-		"oci_objectstorage_object":           ObjectResource(),
-		"oci_objectstorage_preauthrequest":   PreauthenticatedRequestResource(),
+		"oci_core_boot_volume":                     BootVolumeResource(),
+		"oci_core_boot_volume_attachment":          BootVolumeAttachmentResource(),
+		"oci_core_console_history":                 ConsoleHistoryResource(),
+		"oci_core_cpe":                             CpeResource(),
+		"oci_core_dhcp_options":                    DHCPOptionsResource(),
+		"oci_core_drg":                             DrgResource(),
+		"oci_core_drg_attachment":                  DrgAttachmentResource(),
+		"oci_core_image":                           ImageResource(),
+		"oci_core_image_export":                    ImageExportResource(),
+		"oci_core_instance":                        InstanceResource(),
+		"oci_core_internet_gateway":                InternetGatewayResource(),
+		"oci_core_ipsec":                           IPSecConnectionResource(),
+		"oci_core_private_ip":                      PrivateIPResource(),
+		"oci_core_route_table":                     RouteTableResource(),
+		"oci_core_security_list":                   SecurityListResource(),
+		"oci_core_security_list_egress_rule":       SecurityListEgressRuleResource(),
+		"oci_core_security_list_ingress_rule":      SecurityListIngressRuleResource(),
+		"oci_core_subnet":                          SubnetResource(),
+		"oci_core_virtual_network":                 VirtualNetworkResource(),
+		"oci_core_vnic_attachment":                 VnicAttachmentResource(),
+		"oci_core_volume":                          VolumeResource(),
+		"oci_core_volume_attachment":               VolumeAttachmentResource(),
+		"oci_core_volume_backup":                   VolumeBackupResource(),
+		"oci_core_volume_backup_policy":            VolumeBackupPolicyResource(),
+		"oci_core_volume_backup_policy_assignment": VolumeBackupPolicyAssignmentResource(),
+		"oci_database_db_node_action":              DBNodeActionResource(),
+		"oci_database_db_system":                   DBSystemResource(),
+		"oci_identity_api_key":                     APIKeyResource(),
+		"oci_identity_compartment":                 CompartmentResource(),
+		"oci_identity_dynamic_group":               DynamicGroupResource(),
+		"oci_identity_group":                       GroupResource(),
+		"oci_identity_policy":                      PolicyResource(),
+		"oci_identity_swift_password":              SwiftPasswordResource(),
+		"oci_identity_ui_password":                 UIPasswordResource(),
+		"oci_identity_user":                        UserResource(),
+		"oci_identity_user_group_membership":       UserGroupMembershipResource(),
+		"oci_load_balancer":                        LoadBalancerResource(),
+		"oci_load_balancer_backend":                LoadBalancerBackendResource(),
+		"oci_load_balancer_backendset":             LoadBalancerBackendSetResource(),
+		"oci_load_balancer_certificate":            LoadBalancerCertificateResource(),
+		"oci_load_balancer_health_checker":         LoadBalancerHealthCheckerResource(),
+		"oci_load_balancer_listener":               LoadBalancerListenerResource(),
+		"oci_objectstorage_bucket":                 BucketResource(),
+		"oci_objectstorage_object":                 ObjectResource(),
+		"oci_objectstorage_preauthrequest":         PreauthenticatedRequestResource(),
+	}
+}
+
+func diffChecksMap() map[string]diffCheckFunc {
+	return map[string]diffCheckFunc{
+		"oci_core_volume": checkVolumeDiff,
 	}
 }
 
@@ -224,6 +316,7 @@ func getRequiredEnvSetting(s string) string {
 }
 
 func ProviderConfig(d *schema.ResourceData) (clients interface{}, err error) {
+	auth, _ := d.Get("auth").(string)
 	tenancyOCID := d.Get("tenancy_ocid").(string)
 	userOCID := d.Get("user_ocid").(string)
 	fingerprint := d.Get("fingerprint").(string)
@@ -232,9 +325,12 @@ func ProviderConfig(d *schema.ResourceData) (clients interface{}, err error) {
 	privateKeyPassword, hasKeyPass := d.Get("private_key_password").(string)
 	region, hasRegion := d.Get("region").(string)
 	disableAutoRetries, hasDisableRetries := d.Get("disable_auto_retries").(bool)
+	configFilePath, _ := d.Get("config_file_path").(string)
+	profileName, _ := d.Get("config_file_profile").(string)
 
 	// for internal use
 	urlTemplate := getEnvSetting("url_template", "")
+	metadataURL := getEnvSetting("metadata_url", "")
 	allowInsecureTls := getEnvSetting("allow_insecure_tls", "")
 
 	clientOpts := []baremetal.NewClientOptionsFunc{
@@ -255,43 +351,183 @@ func ProviderConfig(d *schema.ResourceData) (clients interface{}, err error) {
 		)
 	}
 
-	if hasKey && privateKeyBuffer != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyBytes([]byte(privateKeyBuffer)))
-	} else if hasKeyPath && privateKeyPath != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyFilePath(privateKeyPath))
-	} else {
-		err = errors.New("One of private_key or private_key_path is required")
-		return
+	if hasDisableRetries {
+		clientOpts = append(clientOpts, baremetal.DisableAutoRetries(disableAutoRetries))
 	}
 
-	if hasKeyPass && privateKeyPassword != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyPassword(privateKeyPassword))
+	if urlTemplate != "" {
+		clientOpts = append(clientOpts, baremetal.UrlTemplate(urlTemplate))
 	}
 
-	if hasRegion && region != "" {
-		clientOpts = append(clientOpts, baremetal.Region(region))
+	if !hasRegion {
+		region = ""
 	}
 
-	if hasDisableRetries {
-		clientOpts = append(clientOpts, baremetal.DisableAutoRetries(disableAutoRetries))
+	// Settings left empty in the provider configuration are read from the
+	// OCI CLI config file, if one is given. sources records the settings
+	// taken from the environment or the file, so errors can name the value
+	// to fix.
+	sources := map[string]string{}
+	recordEnvSources(d, sources)
+	var profile *configFileProfile
+	if configFilePath != "" || profileName != "" {
+		if profile, err = loadConfigFileProfile(configFilePath, profileName); err != nil {
+			return
+		}
+		profile.fill(&tenancyOCID, "tenancy", sources, "tenancy_ocid")
+		profile.fill(&userOCID, "user", sources, "user_ocid")
+		profile.fill(&fingerprint, "fingerprint", sources, "fingerprint")
+		profile.fill(&region, "region", sources, "region")
+
+		// The pass phrase of a profile only applies to its own key file.
+		if privateKeyBuffer == "" && privateKeyPath == "" {
+			profile.fill(&privateKeyPath, "key_file", sources, "private_key_path")
+			if privateKeyPath != "" {
+				if privateKeyPath, err = homedir.Expand(privateKeyPath); err != nil {
+					return
+				}
+				profile.fill(&privateKeyPassword, "pass_phrase", sources, "private_key_password")
+			}
+		}
 	}
 
-	if urlTemplate != "" {
-		clientOpts = append(clientOpts, baremetal.UrlTemplate(urlTemplate))
+	// An instance principal defaults to the region of its instance.
+	if region == "" && auth == authInstancePrincipal {
+		if region, err = sdk.InstanceRegion(metadataURL, clientOpts...); err != nil {
+			err = fmt.Errorf("Could not get the region of the instance: %s", err)
+			return
+		}
+		sources["region"] = "the instance metadata service"
+	}
+	if region == "" {
+		err = missingSettingError(profile, "region", "region", "")
+		return
+	}
+	if !regionPattern.MatchString(region) {
+		err = fmt.Errorf("region %q from %s is not a region name such as us-phoenix-1", region, settingOrigin(sources, "region"))
+		return
 	}
 
-	client, err := baremetal.NewClient(userOCID, tenancyOCID, fingerprint, clientOpts...)
+	// credentials describes the settings requests are signed with, should
+	// the service reject them.
+	var credentials string
+	var newClient func(opts ...baremetal.NewClientOptionsFunc) (*sdk.Client, error)
+
+	switch auth {
+	case authInstancePrincipal:
+		// A single security token is shared by the clients of every region.
+		// It is obtained from the federation endpoint of the provider's region.
+		providerOpts := append([]baremetal.NewClientOptionsFunc{}, clientOpts...)
+		providerOpts = append(providerOpts, baremetal.Region(region))
+
+		var keyProvider *sdk.InstancePrincipalKeyProvider
+		if keyProvider, err = sdk.NewInstancePrincipalKeyProvider(metadataURL, providerOpts...); err != nil {
+			err = fmt.Errorf("Could not authenticate as an instance principal: %s", err)
+			return
+		}
+		if tenancyOCID == "" {
+			tenancyOCID = keyProvider.TenancyOCID()
+		}
+		newClient = func(opts ...baremetal.NewClientOptionsFunc) (*sdk.Client, error) {
+			return sdk.NewKeyProviderClient(tenancyOCID, keyProvider, opts...)
+		}
+	default:
+		switch {
+		case tenancyOCID == "":
+			err = missingSettingError(profile, "tenancy_ocid", "tenancy", " for ApiKey authentication")
+		case userOCID == "":
+			err = missingSettingError(profile, "user_ocid", "user", " for ApiKey authentication")
+		case fingerprint == "":
+			err = missingSettingError(profile, "fingerprint", "fingerprint", " for ApiKey authentication")
+		}
+		if err != nil {
+			return
+		}
+
+		var password *string
+		if hasKeyPass && privateKeyPassword != "" {
+			password = &privateKeyPassword
+		}
 
-	clientOpts = append(clientOpts, baremetal.DisableNotFoundRetries(true))
-	clientWithoutNotFoundRetries, err := baremetal.NewClient(userOCID, tenancyOCID, fingerprint, clientOpts...)
-	clients = &OracleClients{
-		client: client,
-		clientWithoutNotFoundRetries: clientWithoutNotFoundRetries,
+		// keySource describes the private key, should it fail to load.
+		var keySource string
+		var key *rsa.PrivateKey
+		if hasKey && privateKeyBuffer != "" {
+			keySource = settingSource(sources, "private_key")
+			key, err = baremetal.PrivateKeyFromBytes([]byte(privateKeyBuffer), password)
+		} else if hasKeyPath && privateKeyPath != "" {
+			keySource = fmt.Sprintf("%s (%s)", privateKeyPath, settingSource(sources, "private_key_path"))
+			key, err = baremetal.PrivateKeyFromFile(privateKeyPath, password)
+		} else if profile != nil {
+			err = fmt.Errorf("One of private_key or private_key_path is required, and key_file is not set in profile %s of %s", profile.name, profile.path)
+			return
+		} else {
+			err = errors.New("One of private_key or private_key_path is required")
+			return
+		}
+		if err != nil {
+			err = fmt.Errorf("Could not load the private key from %s: %s", keySource, err)
+			return
+		}
+
+		newClient = func(opts ...baremetal.NewClientOptionsFunc) (*sdk.Client, error) {
+			return sdk.NewClient(userOCID, tenancyOCID, fingerprint, key, opts...)
+		}
+		credentials = fmt.Sprintf("tenancy_ocid from %s, user_ocid from %s, fingerprint from %s and the private key from %s",
+			settingOrigin(sources, "tenancy_ocid"), settingOrigin(sources, "user_ocid"), settingOrigin(sources, "fingerprint"), keySource)
 	}
+
+	// Clients for other regions share every setting but the region, and are
+	// only built once a resource or data source asks for them.
+	newClients := func(region string) (*OracleClients, error) {
+		regionOpts := append([]baremetal.NewClientOptionsFunc{}, clientOpts...)
+		if region != "" {
+			regionOpts = append(regionOpts, baremetal.Region(region))
+		}
+
+		client, err := newClient(regionOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		regionOpts = append(regionOpts, baremetal.DisableNotFoundRetries(true))
+		clientWithoutNotFoundRetries, err := newClient(regionOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return &OracleClients{
+			client:                       client,
+			clientWithoutNotFoundRetries: clientWithoutNotFoundRetries,
+			region:                       region,
+			credentials:                  credentials,
+			regionSource:                 "the region argument of the resource or data source",
+		}, nil
+	}
+
+	root, err := newClients(region)
+	if err != nil {
+		return
+	}
+	root.regionSource = settingOrigin(sources, "region")
+	root.newClients = newClients
+	root.regional = map[string]*OracleClients{}
+	clients = root
 	return
 }
 
 type OracleClients struct {
-	client                       *baremetal.Client
-	clientWithoutNotFoundRetries *baremetal.Client
+	client                       *sdk.Client
+	clientWithoutNotFoundRetries *sdk.Client
+	// region is the region the clients connect to.
+	region string
+	// credentials and regionSource name where the settings the clients use
+	// were read from, for errors caused by a wrong setting.
+	credentials  string
+	regionSource string
+
+	// newClients builds the clients for another region. It is only set on
+	// the provider's own clients, which cache the result in regional.
+	newClients func(region string) (*OracleClients, error)
+	mutex      sync.Mutex
+	regional   map[string]*OracleClients
 }
@@ -3,28 +3,104 @@
 package provider
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/oracle/bmcs-go-sdk"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/oracle/terraform-provider-oci/fakeoci"
 )
 
 var testAccClient *baremetal.Client
-var testAccProvider *schema.Provider
+var testAccProvider *ociProvider
 var testAccProviders map[string]terraform.ResourceProvider
 
-func init() {
-	testAccClient = GetTestProvider().client
+// testFakeOCI is the fake API the tests run against when no tenancy_ocid is
+// configured through TF_VAR_* or OCI_* environment variables.
+var testFakeOCI *fakeoci.Server
+
+func TestMain(m *testing.M) {
+	if getEnvSetting("tenancy_ocid", "") == "" {
+		if err := startTestFakeOCI(); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not start the fake OCI API: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	testAccClient = GetTestProvider().client.Client
 
 	testAccProvider = Provider(func(d *schema.ResourceData) (interface{}, error) {
 		return GetTestProvider(), nil
-	}).(*schema.Provider)
+	}).(*ociProvider)
 
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"oci": testAccProvider,
 	}
+
+	code := m.Run()
+	if testFakeOCI != nil {
+		testFakeOCI.Close()
+		os.Remove(getEnvSetting("private_key_path", ""))
+	}
+	os.Exit(code)
+}
+
+// startTestFakeOCI starts testFakeOCI, and points the settings the tests
+// read from the environment, which are all unset, at it. The fake does not
+// check signatures, so requests are signed with a throwaway key in a
+// temporary file.
+func startTestFakeOCI() (e error) {
+	var key *rsa.PrivateKey
+	if key, e = rsa.GenerateKey(rand.Reader, 2048); e != nil {
+		return
+	}
+	var f *os.File
+	if f, e = ioutil.TempFile("", "fakeoci-key"); e != nil {
+		return
+	}
+	defer f.Close()
+	if e = pem.Encode(f, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); e != nil {
+		return
+	}
+
+	testFakeOCI = fakeoci.NewServer()
+	settings := map[string]string{
+		"TF_VAR_tenancy_ocid":     testFakeOCI.TenancyID,
+		"TF_VAR_user_ocid":        "ocid1.user.oc1..fakeuser",
+		"TF_VAR_fingerprint":      "00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00",
+		"TF_VAR_private_key_path": f.Name(),
+		"TF_VAR_compartment_ocid": testFakeOCI.TenancyID,
+		"TF_VAR_compartment_id":   testFakeOCI.TenancyID,
+		"TF_VAR_region":           testFakeOCI.Region,
+		"TF_VAR_namespace":        testFakeOCI.Namespace,
+		"OCI_url_template":        testFakeOCI.URLTemplate(),
+		"OCI_allow_insecure_tls":  "true",
+	}
+	for k, v := range settings {
+		os.Setenv(k, v)
+	}
+	return
+}
+
+// onFakeOCI lets an acceptance test run without TF_ACC when the tests run
+// against testFakeOCI, as nothing real is created. Call it with
+// defer onFakeOCI()() at the start of the test.
+func onFakeOCI() func() {
+	if testFakeOCI == nil {
+		return func() {}
+	}
+	return setEnv(resource.TestEnvVar, "1")
 }
 
 func testProviderConfig() string {
@@ -201,7 +277,7 @@ func TestProvider(t *testing.T) {
 	client := &OracleClients{}
 	if err := Provider(func(d *schema.ResourceData) (interface{}, error) {
 		return client, nil
-	}).(*schema.Provider).InternalValidate(); err != nil {
+	}).(*ociProvider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
@@ -255,6 +331,7 @@ func TestProviderConfig(t *testing.T) {
 	d.Set("private_key", testPrivateKey)
 	//d.Set("private_key_path", "")
 	d.Set("private_key_password", "password")
+	d.Set("region", "us-phoenix-1")
 
 	client, err := ProviderConfig(d)
 	assert.Nil(t, err)
@@ -262,3 +339,196 @@ func TestProviderConfig(t *testing.T) {
 	_, ok := client.(*OracleClients)
 	assert.True(t, ok)
 }
+
+func TestProviderConfigRequiresAPIKeySettings(t *testing.T) {
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("auth", authAPIKey)
+	d.Set("tenancy_ocid", testTenancyOCID)
+	d.Set("private_key", testPrivateKey)
+	d.Set("region", "us-phoenix-1")
+
+	_, err := ProviderConfig(d)
+	assert.EqualError(t, err, "user_ocid is required for ApiKey authentication")
+}
+
+// setEnv sets an environment variable for the duration of a test, and
+// returns a function restoring its previous value.
+func setEnv(key, value string) func() {
+	previous, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestProviderConfigInstancePrincipal(t *testing.T) {
+	srv := fakeoci.NewServer()
+	defer srv.Close()
+	defer setEnv("OCI_url_template", srv.URLTemplate())()
+	defer setEnv("OCI_metadata_url", srv.MetadataURL())()
+	defer setEnv("OCI_allow_insecure_tls", "true")()
+
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("auth", authInstancePrincipal)
+	d.Set("region", srv.Region)
+
+	client, err := ProviderConfig(d)
+	if !assert.Nil(t, err) {
+		return
+	}
+	root := client.(*OracleClients)
+
+	// The tenancy is taken from the instance certificate.
+	group, err := root.client.CreateGroup("instance-principal", "created by an instance", nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, srv.TenancyID, group.CompartmentID)
+
+	// Clients for other regions share the security token.
+	ashburn, err := root.forRegion("us-ashburn-1")
+	assert.Nil(t, err)
+	_, err = ashburn.client.ListGroups(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, srv.RequestCount("POST", "/x509"))
+}
+
+func TestProviderConfigInstancePrincipalRegion(t *testing.T) {
+	srv := fakeoci.NewServer()
+	defer srv.Close()
+	srv.Region = "us-ashburn-1"
+	defer setEnv("OCI_url_template", srv.URLTemplate())()
+	defer setEnv("OCI_metadata_url", srv.MetadataURL())()
+	defer setEnv("OCI_allow_insecure_tls", "true")()
+
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	// The region is taken from the instance metadata.
+	d.Set("auth", authInstancePrincipal)
+
+	client, err := ProviderConfig(d)
+	if !assert.Nil(t, err) {
+		return
+	}
+	root := client.(*OracleClients)
+	assert.Equal(t, "us-ashburn-1", root.region)
+	_, err = root.client.ListGroups(nil)
+	assert.Nil(t, err)
+}
+
+func TestProviderConfigInstancePrincipalUnavailable(t *testing.T) {
+	srv := fakeoci.NewServer()
+	defer srv.Close()
+	defer setEnv("OCI_url_template", srv.URLTemplate())()
+	defer setEnv("OCI_metadata_url", srv.URL+"/missing")()
+	defer setEnv("OCI_allow_insecure_tls", "true")()
+
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("auth", authInstancePrincipal)
+	d.Set("region", srv.Region)
+
+	_, err := ProviderConfig(d)
+	assert.Error(t, err)
+}
+
+func TestProviderConfigInstancePrincipalNotOnInstance(t *testing.T) {
+	// Nothing listens on the port once the listener is closed.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadataURL := "http://" + l.Addr().String() + "/opc/v1"
+	l.Close()
+
+	srv := fakeoci.NewServer()
+	defer srv.Close()
+	defer setEnv("OCI_url_template", srv.URLTemplate())()
+	defer setEnv("OCI_metadata_url", metadataURL)()
+	defer setEnv("OCI_allow_insecure_tls", "true")()
+
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("auth", authInstancePrincipal)
+	d.Set("region", srv.Region)
+
+	_, err = ProviderConfig(d)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "instance principals can only be used when running on an OCI instance")
+	}
+}
+
+func TestProviderConfigRegionalClients(t *testing.T) {
+	r := &schema.Resource{
+		Schema: schemaMap(),
+	}
+	d := r.Data(nil)
+	d.SetId("tenancy_ocid")
+
+	d.Set("tenancy_ocid", testTenancyOCID)
+	d.Set("user_ocid", testUserOCID)
+	d.Set("fingerprint", testKeyFingerPrint)
+	d.Set("private_key", testPrivateKey)
+	d.Set("private_key_password", "password")
+	d.Set("region", "us-phoenix-1")
+
+	client, err := ProviderConfig(d)
+	assert.Nil(t, err)
+	root := client.(*OracleClients)
+
+	same, err := root.forRegion("us-phoenix-1")
+	assert.Nil(t, err)
+	assert.True(t, same == root)
+
+	ashburn, err := root.forRegion("us-ashburn-1")
+	assert.Nil(t, err)
+	assert.False(t, ashburn == root)
+	assert.Equal(t, "us-ashburn-1", ashburn.region)
+
+	cached, err := root.forRegion("us-ashburn-1")
+	assert.Nil(t, err)
+	assert.True(t, cached == ashburn)
+}
+
+func TestParseRegionalID(t *testing.T) {
+	region, id, ok := parseRegionalID("us-ashburn-1/ocid1.vcn.oc1.iad.aaaa")
+	assert.True(t, ok)
+	assert.Equal(t, "us-ashburn-1", region)
+	assert.Equal(t, "ocid1.vcn.oc1.iad.aaaa", id)
+
+	// IDs that merely contain a slash, such as a load balancer ID and
+	// backend set name, are left alone.
+	_, id, ok = parseRegionalID("ocid1.loadbalancer.oc1.phx.aaaa/backendset")
+	assert.False(t, ok)
+	assert.Equal(t, "ocid1.loadbalancer.oc1.phx.aaaa/backendset", id)
+
+	_, id, ok = parseRegionalID("ocid1.vcn.oc1.phx.aaaa")
+	assert.False(t, ok)
+	assert.Equal(t, "ocid1.vcn.oc1.phx.aaaa", id)
+}
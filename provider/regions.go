@@ -0,0 +1,178 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// regionPattern matches region names such as us-phoenix-1.
+var regionPattern = regexp.MustCompile(`^[a-z]{2}-[a-z]+-[0-9]+$`)
+
+// forRegion returns the clients for region, building and caching them on
+// first use. An empty region selects the provider's own region.
+func (c *OracleClients) forRegion(region string) (*OracleClients, error) {
+	if region == "" || region == c.region || c.newClients == nil {
+		return c, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if clients, ok := c.regional[region]; ok {
+		return clients, nil
+	}
+	clients, err := c.newClients(region)
+	if err != nil {
+		return nil, err
+	}
+	c.regional[region] = clients
+	return clients, nil
+}
+
+// explainError adds where the settings were read from to errors which a
+// wrong setting causes: the service rejecting the credentials, or the
+// endpoints of the region not resolving.
+func (c *OracleClients) explainError(e error) error {
+	if e == nil {
+		return nil
+	}
+	if apiErr, ok := e.(*baremetal.Error); ok && apiErr.Status == "401" && c.credentials != "" {
+		return fmt.Errorf("%s; the request was signed with %s", e, c.credentials)
+	}
+	if strings.Contains(e.Error(), "no such host") && c.regionSource != "" {
+		return fmt.Errorf("%s; region %s was read from %s", e, c.region, c.regionSource)
+	}
+	return e
+}
+
+// regionalClients returns the clients for the region d is managed in.
+func regionalClients(d *schema.ResourceData, m interface{}) (*OracleClients, error) {
+	region, _ := d.Get("region").(string)
+	return m.(*OracleClients).forRegion(region)
+}
+
+// setRegion records the region a resource was read from, so later
+// operations go to the same region even if the provider's region changes.
+func setRegion(d *schema.ResourceData, clients *OracleClients) {
+	if d.Id() != "" && clients.region != "" {
+		d.Set("region", clients.region)
+	}
+}
+
+// parseRegionalID splits an import ID of the form {region}/{id}.
+func parseRegionalID(importID string) (region, id string, ok bool) {
+	parts := strings.SplitN(importID, "/", 2)
+	if len(parts) != 2 || !regionPattern.MatchString(parts[0]) || parts[1] == "" {
+		return "", importID, false
+	}
+	return parts[0], parts[1], true
+}
+
+func regionalResources(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range resources {
+		regionalResource(r)
+	}
+	return resources
+}
+
+func regionalDataSources(dataSources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range dataSources {
+		regionalDataSource(r)
+	}
+	return dataSources
+}
+
+// regionalResource adds an optional region argument to r. Each operation is
+// handed the clients for that region in place of the provider's own, so
+// resource implementations are unaware of the override. Moving a resource
+// to another region replaces it.
+func regionalResource(r *schema.Resource) *schema.Resource {
+	r.Schema["region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+
+	create, read, update, del := r.Create, r.Read, r.Update, r.Delete
+	r.Create = func(d *schema.ResourceData, m interface{}) error {
+		clients, e := regionalClients(d, m)
+		if e != nil {
+			return e
+		}
+		e = create(d, clients)
+		setRegion(d, clients)
+		return clients.explainError(e)
+	}
+	r.Read = func(d *schema.ResourceData, m interface{}) error {
+		clients, e := regionalClients(d, m)
+		if e != nil {
+			return e
+		}
+		e = read(d, clients)
+		setRegion(d, clients)
+		return clients.explainError(e)
+	}
+	if update != nil {
+		r.Update = func(d *schema.ResourceData, m interface{}) error {
+			clients, e := regionalClients(d, m)
+			if e != nil {
+				return e
+			}
+			return clients.explainError(update(d, clients))
+		}
+	}
+	r.Delete = func(d *schema.ResourceData, m interface{}) error {
+		clients, e := regionalClients(d, m)
+		if e != nil {
+			return e
+		}
+		return clients.explainError(del(d, clients))
+	}
+
+	// Import IDs may be prefixed with the region, e.g.
+	// us-ashburn-1/ocid1.vcn.oc1.iad.aaaa.
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if region, id, ok := parseRegionalID(d.Id()); ok {
+				d.SetId(id)
+				d.Set("region", region)
+			}
+			clients, e := regionalClients(d, m)
+			if e != nil {
+				return nil, e
+			}
+			states, e := state(d, clients)
+			return states, clients.explainError(e)
+		}
+	}
+	return r
+}
+
+// regionalDataSource adds an optional region argument to r, which reads
+// from that region instead of the provider's.
+func regionalDataSource(r *schema.Resource) *schema.Resource {
+	r.Schema["region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+
+	read := r.Read
+	r.Read = func(d *schema.ResourceData, m interface{}) error {
+		clients, e := regionalClients(d, m)
+		if e != nil {
+			return e
+		}
+		e = read(d, clients)
+		setRegion(d, clients)
+		return clients.explainError(e)
+	}
+	return r
+}
@@ -5,9 +5,11 @@ package provider
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -63,3 +65,37 @@ func fromInstanceState(s *terraform.State, name, key string) (string, error) {
 		return "", fmt.Errorf("%s: Attribute '%s' not found", name, key)
 	}
 }
+
+// custom TestCheckFunc helper, checks that the set at key has an element with all of the given attribute values,
+// ex: testCheckResourceSetAttr(name, "ingress_security_rules", map[string]string{"tcp_options.0.max": "80"})
+func testCheckResourceSetAttr(name, key string, values map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary == nil {
+			return fmt.Errorf("No primary instance: %s", name)
+		}
+		attrs := rs.Primary.Attributes
+
+		prefix := key + "."
+		for k := range attrs {
+			if !strings.HasPrefix(k, prefix) || k == prefix+"#" {
+				continue
+			}
+			code := strings.SplitN(strings.TrimPrefix(k, prefix), ".", 2)[0]
+			matched := true
+			for attr, v := range values {
+				if attrs[prefix+code+"."+attr] != v {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: No element of '%s' has %v", name, key, values)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+/*
+Package sdk has the Oracle Cloud Infrastructure API calls that the vendored
+bmcs-go-sdk does not have yet, and the calls whose fields or options it is
+missing. Its Client embeds a baremetal.Client, so it is used in place of
+one, and makes the calls it adds or replaces to the same endpoints, with the
+same credentials, user agent and retries.
+
+Calls move out of this package once the vendored SDK has them.
+*/
+package sdk
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+var clientCounter int64
+
+// Client is a baremetal.Client with the calls of this package
+type Client struct {
+	*baremetal.Client
+	authInfo         *authenticationInfo
+	identityApi      requestor
+	coreApi          requestor
+	objectStorageApi requestor
+	loadBalancerApi  requestor
+}
+
+// NewClient creates a client that signs requests with the API key of a user,
+// like baremetal.NewClient. The key is given parsed, rather than as a
+// baremetal.NewClientOptionsFunc, as the options of the vendored SDK keep it
+// to themselves.
+func NewClient(userOCID, tenancyOCID, keyFingerprint string, key *rsa.PrivateKey, opts ...baremetal.NewClientOptionsFunc) (*Client, error) {
+	keys := &apiKey{
+		keyID: fmt.Sprintf("%s/%s/%s", tenancyOCID, userOCID, keyFingerprint),
+		key:   key,
+	}
+	return newClient(userOCID, tenancyOCID, keyFingerprint, keys, opts)
+}
+
+// NewKeyProviderClient creates a client that signs every request with the
+// current key of keys, such as an InstancePrincipalKeyProvider. The requests
+// of the embedded baremetal.Client are signed again on their way out.
+func NewKeyProviderClient(tenancyOCID string, keys KeyProvider, opts ...baremetal.NewClientOptionsFunc) (*Client, error) {
+	nco := newClientOptions(opts)
+	opts = append(opts, baremetal.CustomTransport(&signingTransport{
+		transport: nco.Transport,
+		keys:      keys,
+	}))
+	return newClient("", tenancyOCID, "", keys, opts)
+}
+
+func newClient(userOCID, tenancyOCID, keyFingerprint string, keys KeyProvider, opts []baremetal.NewClientOptionsFunc) (c *Client, e error) {
+	// The embedded client signs with a key of its own. It is the API key, or
+	// the current key of a KeyProvider, which signingTransport replaces.
+	var key *rsa.PrivateKey
+	if _, key, e = keys.SigningKey(); e != nil {
+		return
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	sdkOpts := append([]baremetal.NewClientOptionsFunc{baremetal.PrivateKeyBytes(keyPEM)}, opts...)
+
+	var client *baremetal.Client
+	if client, e = baremetal.NewClient(userOCID, tenancyOCID, keyFingerprint, sdkOpts...); e != nil {
+		return
+	}
+
+	nco := newClientOptions(opts)
+	auth := &authenticationInfo{
+		tenancyOCID: tenancyOCID,
+		keys:        keys,
+	}
+	return &Client{
+		Client:           client,
+		authInfo:         auth,
+		identityApi:      newAPIRequestor(auth, nco, buildIdentityURL),
+		coreApi:          newAPIRequestor(auth, nco, buildCoreURL),
+		objectStorageApi: newAPIRequestor(auth, nco, buildObjectStorageURL),
+		loadBalancerApi:  newAPIRequestor(auth, nco, buildLoadBalancerURL),
+	}, nil
+}
+
+// newClientOptions applies opts to the defaults of baremetal.NewClient.
+func newClientOptions(opts []baremetal.NewClientOptionsFunc) *baremetal.NewClientOptions {
+	seed := atomic.AddInt64(&clientCounter, 1) + time.Now().UnixNano()
+	nco := &baremetal.NewClientOptions{
+		Transport:      &http.Transport{},
+		Region:         us_phoenix_1,
+		UrlTemplate:    baseUrlTemplate,
+		ShortRetryTime: shortRetryTime,
+		LongRetryTime:  longRetryTime,
+		RandGen:        rand.New(rand.NewSource(seed)),
+	}
+	for _, opt := range opts {
+		opt(nco)
+	}
+	return nco
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+type resourceName string
+
+const (
+	// Actions that can be applied to compute instances
+	InstanceActionStart baremetal.InstanceActions = "START"
+	InstanceActionStop  baremetal.InstanceActions = "STOP"
+
+	// Lifecycle states of images
+	ResourceExporting = "EXPORTING"
+	ResourceImporting = "IMPORTING"
+
+	// Instance launch source types
+	InstanceSourceTypeImage      = "image"
+	InstanceSourceTypeBootVolume = "bootVolume"
+
+	// Image import sources and export destinations in Object Storage
+	ImageSourceTypeObjectStorageTuple = "objectStorageTuple"
+	ImageSourceTypeObjectStorageURI   = "objectStorageUri"
+
+	// Image file formats for import
+	ImageTypeQCOW2 = "QCOW2"
+	ImageTypeVMDK  = "VMDK"
+
+	// Volume backup types
+	VolumeBackupTypeFull        = "FULL"
+	VolumeBackupTypeIncremental = "INCREMENTAL"
+
+	// Volume backup schedule periods
+	VolumeBackupPeriodOneDay   = "ONE_DAY"
+	VolumeBackupPeriodOneWeek  = "ONE_WEEK"
+	VolumeBackupPeriodOneMonth = "ONE_MONTH"
+	VolumeBackupPeriodOneYear  = "ONE_YEAR"
+
+	us_phoenix_1 = "us-phoenix-1"
+
+	baseUrlTemplate = "https://%s.%s.oraclecloud.com"
+	urlPrefix       = "https://"
+
+	identityServiceAPI        = "identity"
+	identityServiceAPIVersion = "20160918"
+
+	coreServiceAPI        = "iaas"
+	coreServiceAPIVersion = "20160918"
+
+	objectStorageServiceAPI = "objectstorage"
+
+	loadBalancerServiceAPI        = "iaas"
+	loadBalancerServiceAPIVersion = "20170115"
+
+	// Header Keys
+	headerETag               = "ETag"
+	headerOPCClientRequestID = "opc-client-request-id"
+	headerOPCWorkRequestID   = "opc-work-request-id"
+	headerOPCNextPage        = "opc-next-page"
+	headerOPCRequestID       = "opc-request-id"
+	headerContentEncoding    = "Content-Encoding"
+	headerContentLanguage    = "Content-Language"
+	headerContentLength      = "Content-Length"
+	headerContentMD5         = "Content-MD5"
+	headerContentType        = "Content-Type"
+	headerOPCMultipartMD5    = "opc-multipart-md5"
+
+	// Core Resources
+	resourceBootVolumes                   resourceName = "bootVolumes"
+	resourceBootVolumeAttachments         resourceName = "bootVolumeAttachments"
+	resourceImages                        resourceName = "images"
+	resourceInstances                     resourceName = "instances"
+	resourceVolumes                       resourceName = "volumes"
+	resourceVolumeBackupPolicies          resourceName = "volumeBackupPolicies"
+	resourceVolumeBackupPolicyAssignments resourceName = "volumeBackupPolicyAssignments"
+
+	// Identity Resources
+	resourceCompartments  resourceName = "compartments"
+	resourceDynamicGroups resourceName = "dynamicGroups"
+
+	// LoadBalancer Resources
+	resourceLoadBalancers resourceName = "loadBalancers"
+	resourceBackendSets   resourceName = "backendSets"
+	resourceHealthChecker resourceName = "healthChecker"
+
+	// Object Storage Resources
+	resourceNamespaces = "n"
+	resourceBuckets    = "b"
+	resourceObjects    = "o"
+	resourceUploads    = "u"
+
+	retryTokenKey             = "opc-retry-token"
+	shortRetryTime            = time.Duration(2) * time.Minute
+	longRetryTime             = time.Duration(10) * time.Minute
+	generatedRetryTokenLength = 30
+)
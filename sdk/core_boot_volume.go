@@ -0,0 +1,268 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// BootVolume describes the block volume an instance boots from
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/
+type BootVolume struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+	AvailabilityDomain string                         `json:"availabilityDomain"`
+	CompartmentID      string                         `json:"compartmentId"`
+	DisplayName        string                         `json:"displayName"`
+	ID                 string                         `json:"id"`
+	ImageID            string                         `json:"imageId"`
+	SizeInMBs          int                            `json:"sizeInMBs"`
+	SizeInGBs          int                            `json:"sizeInGBs"`
+	SourceDetails      *baremetal.VolumeSourceDetails `json:"sourceDetails,omitempty"`
+	State              string                         `json:"lifecycleState"`
+	TimeCreated        baremetal.Time                 `json:"timeCreated"`
+}
+
+// ListBootVolumes contains a list of boot volumes
+type ListBootVolumes struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	BootVolumes []BootVolume
+}
+
+func (l *ListBootVolumes) GetList() interface{} {
+	return &l.BootVolumes
+}
+
+// BootVolumeAttachment describes a boot volume attached to an instance
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolumeAttachment/
+type BootVolumeAttachment struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+	AvailabilityDomain string         `json:"availabilityDomain"`
+	BootVolumeID       string         `json:"bootVolumeId"`
+	CompartmentID      string         `json:"compartmentId"`
+	DisplayName        string         `json:"displayName"`
+	ID                 string         `json:"id"`
+	InstanceID         string         `json:"instanceId"`
+	State              string         `json:"lifecycleState"`
+	TimeCreated        baremetal.Time `json:"timeCreated"`
+}
+
+// ListBootVolumeAttachments contains a list of boot volume attachments
+type ListBootVolumeAttachments struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	BootVolumeAttachments []BootVolumeAttachment
+}
+
+func (l *ListBootVolumeAttachments) GetList() interface{} {
+	return &l.BootVolumeAttachments
+}
+
+// CreateBootVolume creates a boot volume by cloning an existing boot volume
+// in the same availability domain
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/CreateBootVolume
+func (c *Client) CreateBootVolume(availabilityDomain, compartmentID string, sourceDetails *baremetal.VolumeSourceDetails, opts *CreateBootVolumeOptions) (res *BootVolume, e error) {
+	required := struct {
+		ocidRequirement
+		AvailabilityDomain string                         `header:"-" json:"availabilityDomain" url:"-"`
+		SourceDetails      *baremetal.VolumeSourceDetails `header:"-" json:"sourceDetails" url:"-"`
+	}{
+		AvailabilityDomain: availabilityDomain,
+		SourceDetails:      sourceDetails,
+	}
+	required.CompartmentID = compartmentID
+
+	details := &requestDetails{
+		name:     resourceBootVolumes,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &BootVolume{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// GetBootVolume retrieves information about a boot volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/GetBootVolume
+func (c *Client) GetBootVolume(id string) (res *BootVolume, e error) {
+	details := &requestDetails{
+		name: resourceBootVolumes,
+		ids:  urlParts{id},
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	res = &BootVolume{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// UpdateBootVolume updates a boot volume's display name
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/UpdateBootVolume
+func (c *Client) UpdateBootVolume(id string, opts *baremetal.UpdateOptions) (res *BootVolume, e error) {
+	details := &requestDetails{
+		name:     resourceBootVolumes,
+		ids:      urlParts{id},
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	res = &BootVolume{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// DeleteBootVolume deletes a boot volume. The boot volume must not be
+// attached to an instance.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/DeleteBootVolume
+func (c *Client) DeleteBootVolume(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		name:     resourceBootVolumes,
+		ids:      urlParts{id},
+		optional: opts,
+	}
+	return c.coreApi.deleteRequest(details)
+}
+
+// ListBootVolumes returns the boot volumes in an availability domain and
+// compartment
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolume/ListBootVolumes
+func (c *Client) ListBootVolumes(availabilityDomain, compartmentID string, opts *baremetal.ListOptions) (res *ListBootVolumes, e error) {
+	required := struct {
+		listOCIDRequirement
+		AvailabilityDomain string `header:"-" json:"-" url:"availabilityDomain"`
+	}{
+		AvailabilityDomain: availabilityDomain,
+	}
+	required.CompartmentID = compartmentID
+
+	details := &requestDetails{
+		name:     resourceBootVolumes,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	res = &ListBootVolumes{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// AttachBootVolume attaches a boot volume to a stopped instance that has no
+// boot volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolumeAttachment/AttachBootVolume
+func (c *Client) AttachBootVolume(bootVolumeID, instanceID string, opts *baremetal.CreateOptions) (res *BootVolumeAttachment, e error) {
+	required := struct {
+		BootVolumeID string `header:"-" json:"bootVolumeId" url:"-"`
+		InstanceID   string `header:"-" json:"instanceId" url:"-"`
+	}{
+		BootVolumeID: bootVolumeID,
+		InstanceID:   instanceID,
+	}
+
+	details := &requestDetails{
+		name:     resourceBootVolumeAttachments,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &BootVolumeAttachment{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// GetBootVolumeAttachment gets information about the specified boot volume
+// attachment
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolumeAttachment/GetBootVolumeAttachment
+func (c *Client) GetBootVolumeAttachment(id string) (res *BootVolumeAttachment, e error) {
+	details := &requestDetails{
+		ids:  urlParts{id},
+		name: resourceBootVolumeAttachments,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	res = &BootVolumeAttachment{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// DetachBootVolume detaches a boot volume from a stopped instance
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolumeAttachment/DetachBootVolume
+func (c *Client) DetachBootVolume(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceBootVolumeAttachments,
+		optional: opts,
+	}
+
+	return c.coreApi.deleteRequest(details)
+}
+
+// ListBootVolumeAttachments gets a list of the boot volume attachments in
+// the specified availability domain and compartment
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/BootVolumeAttachment/ListBootVolumeAttachments
+func (c *Client) ListBootVolumeAttachments(availabilityDomain, compartmentID string, opts *ListBootVolumeAttachmentsOptions) (res *ListBootVolumeAttachments, e error) {
+	required := struct {
+		listOCIDRequirement
+		AvailabilityDomain string `header:"-" json:"-" url:"availabilityDomain"`
+	}{
+		AvailabilityDomain: availabilityDomain,
+	}
+	required.CompartmentID = compartmentID
+
+	details := &requestDetails{
+		name:     resourceBootVolumeAttachments,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	res = &ListBootVolumeAttachments{}
+	e = resp.unmarshal(res)
+	return
+}
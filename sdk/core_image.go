@@ -0,0 +1,82 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import "github.com/oracle/bmcs-go-sdk"
+
+// ImageSourceDetails locates an image file in Object Storage to import,
+// either by namespace, bucket and object name or by URI
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/datatypes/ImageSourceDetails
+type ImageSourceDetails struct {
+	BucketName      string `json:"bucketName,omitempty"`
+	NamespaceName   string `json:"namespaceName,omitempty"`
+	ObjectName      string `json:"objectName,omitempty"`
+	SourceImageType string `json:"sourceImageType,omitempty"`
+	SourceType      string `json:"sourceType"`
+	SourceURI       string `json:"sourceUri,omitempty"`
+}
+
+// ExportImageDetails locates the Object Storage object an image is exported
+// to, either by namespace, bucket and object name or by URI
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/datatypes/ExportImageDetails
+type ExportImageDetails struct {
+	BucketName      string `header:"-" json:"bucketName,omitempty" url:"-"`
+	DestinationType string `header:"-" json:"destinationType" url:"-"`
+	DestinationURI  string `header:"-" json:"destinationUri,omitempty" url:"-"`
+	NamespaceName   string `header:"-" json:"namespaceName,omitempty" url:"-"`
+	ObjectName      string `header:"-" json:"objectName,omitempty" url:"-"`
+}
+
+// CreateImage is used to create an image, either from an instance or, when
+// instanceID is empty, by importing the image file named by
+// opts.ImageSourceDetails
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Image/CreateImage
+func (c *Client) CreateImage(compartmentID, instanceID string, opts *CreateImageOptions) (res *baremetal.Image, e error) {
+	required := struct {
+		ocidRequirement
+		InstanceID string `header:"-" json:"instanceId,omitempty" url:"-"`
+	}{
+		InstanceID: instanceID,
+	}
+	required.CompartmentID = compartmentID
+
+	details := &requestDetails{
+		name:     resourceImages,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &baremetal.Image{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// ExportImage exports an image to Object Storage. The image is EXPORTING
+// until the export completes.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Image/ExportImage
+func (c *Client) ExportImage(id string, exportDetails *ExportImageDetails, opts *baremetal.IfMatchOptions) (res *baremetal.Image, e error) {
+	details := &requestDetails{
+		name:     resourceImages,
+		ids:      urlParts{id, "actions", "export"},
+		optional: opts,
+		required: exportDetails,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &baremetal.Image{}
+	e = resp.unmarshal(res)
+	return
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// Instance is a baremetal.Instance with the source it was launched from
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/
+type Instance struct {
+	baremetal.Instance
+	SourceDetails *InstanceSourceDetails `json:"sourceDetails,omitempty"`
+}
+
+// InstanceSourceDetails describes what an instance boots from, either an
+// image or an existing boot volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/datatypes/InstanceSourceDetails
+type InstanceSourceDetails struct {
+	BootVolumeID string `json:"bootVolumeId,omitempty"`
+	ImageID      string `json:"imageId,omitempty"`
+	SourceType   string `json:"sourceType"`
+}
+
+// LaunchInstance initializes and starts a compute instance. Display name is
+// set in the opts parameter.  See Oracle documentation for more information
+// on other arguments. image may be empty when opts.SourceDetails names the
+// image or boot volume to launch from.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/LaunchInstance
+func (c *Client) LaunchInstance(
+	availabilityDomain,
+	compartmentID,
+	image,
+	shape,
+	subnetID string,
+	opts *LaunchInstanceOptions) (inst *Instance, e error) {
+
+	required := struct {
+		ocidRequirement
+		AvailabilityDomain string `header:"-" json:"availabilityDomain" url:"-"`
+		ImageID            string `header:"-" json:"imageId,omitempty" url:"-"`
+		Shape              string `header:"-" json:"shape" url:"-"`
+		SubnetID           string `header:"-" json:"subnetId,omitempty" url:"-"`
+	}{
+		AvailabilityDomain: availabilityDomain,
+		ImageID:            image,
+		Shape:              shape,
+		SubnetID:           subnetID,
+	}
+	required.CompartmentID = compartmentID
+
+	req := &requestDetails{
+		name:     resourceInstances,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(req); e != nil {
+		return
+	}
+
+	inst = &Instance{}
+	e = resp.unmarshal(inst)
+	return
+}
+
+// GetInstance retrieves a compute instance with instanceID
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/GetInstance
+func (c *Client) GetInstance(id string) (inst *Instance, e error) {
+	details := &requestDetails{
+		name: resourceInstances,
+		ids:  urlParts{id},
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	inst = &Instance{}
+	e = resp.unmarshal(inst)
+	return
+}
+
+// UpdateInstance can be used to change the display name of a compute instance
+// by assigning the new name to Options.DisplayName
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/UpdateInstance
+func (c *Client) UpdateInstance(id string, opts *baremetal.UpdateOptions) (inst *Instance, e error) {
+	details := &requestDetails{
+		name:     resourceInstances,
+		ids:      urlParts{id},
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	inst = &Instance{}
+	e = resp.unmarshal(inst)
+	return
+}
+
+// TerminateInstance terminates the compute instance with an ID matching
+// instanceID. The boot volume is deleted with the instance unless
+// opts.PreserveBootVolume is set.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/TerminateInstance
+func (c *Client) TerminateInstance(id string, opts *TerminateInstanceOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceInstances,
+		optional: opts,
+	}
+
+	return c.coreApi.deleteRequest(details)
+}
+
+// InstanceAction starts, stops, or resets a compute instance identified by
+// instanceID.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Instance/InstanceAction
+func (c *Client) InstanceAction(id string, action baremetal.InstanceActions, opts *baremetal.HeaderOptions) (inst *Instance, e error) {
+	required := struct {
+		Action string `header:"-" json:"-" url:"action"`
+	}{
+		Action: string(action),
+	}
+
+	details := &requestDetails{
+		name:     resourceInstances,
+		ids:      urlParts{id},
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	inst = &Instance{}
+	e = resp.unmarshal(inst)
+	return
+}
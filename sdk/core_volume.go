@@ -0,0 +1,30 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// UpdateVolume updates a volume's display name, or grows the volume to a
+// larger size
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/Volume/UpdateVolume
+func (c *Client) UpdateVolume(id string, opts *UpdateVolumeOptions) (res *baremetal.Volume, e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceVolumes,
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	res = &baremetal.Volume{}
+	e = resp.unmarshal(res)
+	return
+}
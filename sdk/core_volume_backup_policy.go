@@ -0,0 +1,262 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// VolumeBackupSchedule describes when a backup policy takes a backup and
+// how long the backup is kept
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupSchedule/
+type VolumeBackupSchedule struct {
+	BackupType       string `json:"backupType"`
+	OffsetSeconds    int    `json:"offsetSeconds"`
+	Period           string `json:"period"`
+	RetentionSeconds int    `json:"retentionSeconds"`
+}
+
+// VolumeBackupPolicy is a set of schedules for backing up the volumes
+// assigned to it
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/
+type VolumeBackupPolicy struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+	CompartmentID string                 `json:"compartmentId"`
+	DisplayName   string                 `json:"displayName"`
+	ID            string                 `json:"id"`
+	Schedules     []VolumeBackupSchedule `json:"schedules"`
+	TimeCreated   baremetal.Time         `json:"timeCreated"`
+}
+
+// ListVolumeBackupPolicies contains a list of volume backup policies
+type ListVolumeBackupPolicies struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	VolumeBackupPolicies []VolumeBackupPolicy
+}
+
+func (l *ListVolumeBackupPolicies) GetList() interface{} {
+	return &l.VolumeBackupPolicies
+}
+
+// VolumeBackupPolicyAssignment assigns a volume backup policy to a volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicyAssignment/
+type VolumeBackupPolicyAssignment struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+	AssetID     string         `json:"assetId"`
+	ID          string         `json:"id"`
+	PolicyID    string         `json:"policyId"`
+	TimeCreated baremetal.Time `json:"timeCreated"`
+}
+
+// ListVolumeBackupPolicyAssignments contains a list of volume backup policy
+// assignments
+type ListVolumeBackupPolicyAssignments struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	VolumeBackupPolicyAssignments []VolumeBackupPolicyAssignment
+}
+
+func (l *ListVolumeBackupPolicyAssignments) GetList() interface{} {
+	return &l.VolumeBackupPolicyAssignments
+}
+
+// CreateVolumeBackupPolicy creates a new volume backup policy in the
+// specified compartment
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/CreateVolumeBackupPolicy
+func (c *Client) CreateVolumeBackupPolicy(compartmentID string, schedules []VolumeBackupSchedule, opts *baremetal.CreateOptions) (policy *VolumeBackupPolicy, e error) {
+	required := struct {
+		ocidRequirement
+		Schedules []VolumeBackupSchedule `header:"-" json:"schedules" url:"-"`
+	}{
+		Schedules: schedules,
+	}
+	required.CompartmentID = compartmentID
+
+	details := &requestDetails{
+		name:     resourceVolumeBackupPolicies,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	policy = &VolumeBackupPolicy{}
+	e = resp.unmarshal(policy)
+	return
+}
+
+// GetVolumeBackupPolicy gets information for the specified volume backup
+// policy
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/GetVolumeBackupPolicy
+func (c *Client) GetVolumeBackupPolicy(id string) (policy *VolumeBackupPolicy, e error) {
+	details := &requestDetails{
+		ids:  urlParts{id},
+		name: resourceVolumeBackupPolicies,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	policy = &VolumeBackupPolicy{}
+	e = resp.unmarshal(policy)
+	return
+}
+
+// UpdateVolumeBackupPolicy updates a volume backup policy's display name and
+// schedules
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/UpdateVolumeBackupPolicy
+func (c *Client) UpdateVolumeBackupPolicy(id string, opts *UpdateVolumeBackupPolicyOptions) (policy *VolumeBackupPolicy, e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceVolumeBackupPolicies,
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	policy = &VolumeBackupPolicy{}
+	e = resp.unmarshal(policy)
+	return
+}
+
+// DeleteVolumeBackupPolicy deletes a volume backup policy
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/DeleteVolumeBackupPolicy
+func (c *Client) DeleteVolumeBackupPolicy(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceVolumeBackupPolicies,
+		optional: opts,
+	}
+
+	return c.coreApi.deleteRequest(details)
+}
+
+// ListVolumeBackupPolicies returns the volume backup policies of a
+// compartment, or the Oracle-defined policies when no compartment is given
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicy/ListVolumeBackupPolicies
+func (c *Client) ListVolumeBackupPolicies(opts *ListVolumeBackupPoliciesOptions) (policies *ListVolumeBackupPolicies, e error) {
+	details := &requestDetails{
+		name:     resourceVolumeBackupPolicies,
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	policies = &ListVolumeBackupPolicies{}
+	e = resp.unmarshal(policies)
+	return
+}
+
+// CreateVolumeBackupPolicyAssignment assigns a volume backup policy to a
+// volume. A volume can have at most one policy assigned.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicyAssignment/CreateVolumeBackupPolicyAssignment
+func (c *Client) CreateVolumeBackupPolicyAssignment(assetID, policyID string, opts *baremetal.RetryTokenOptions) (assignment *VolumeBackupPolicyAssignment, e error) {
+	required := struct {
+		AssetID  string `header:"-" json:"assetId" url:"-"`
+		PolicyID string `header:"-" json:"policyId" url:"-"`
+	}{
+		AssetID:  assetID,
+		PolicyID: policyID,
+	}
+
+	details := &requestDetails{
+		name:     resourceVolumeBackupPolicyAssignments,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.postRequest(details); e != nil {
+		return
+	}
+
+	assignment = &VolumeBackupPolicyAssignment{}
+	e = resp.unmarshal(assignment)
+	return
+}
+
+// GetVolumeBackupPolicyAssignment gets information for the specified volume
+// backup policy assignment
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicyAssignment/GetVolumeBackupPolicyAssignment
+func (c *Client) GetVolumeBackupPolicyAssignment(id string) (assignment *VolumeBackupPolicyAssignment, e error) {
+	details := &requestDetails{
+		ids:  urlParts{id},
+		name: resourceVolumeBackupPolicyAssignments,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	assignment = &VolumeBackupPolicyAssignment{}
+	e = resp.unmarshal(assignment)
+	return
+}
+
+// DeleteVolumeBackupPolicyAssignment removes a volume backup policy from a
+// volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicyAssignment/DeleteVolumeBackupPolicyAssignment
+func (c *Client) DeleteVolumeBackupPolicyAssignment(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceVolumeBackupPolicyAssignments,
+		optional: opts,
+	}
+
+	return c.coreApi.deleteRequest(details)
+}
+
+// ListVolumeBackupPolicyAssignments returns the volume backup policy
+// assignments of a volume
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/iaas/20160918/VolumeBackupPolicyAssignment/GetVolumeBackupPolicyAssetAssignment
+func (c *Client) ListVolumeBackupPolicyAssignments(assetID string, opts *baremetal.ListOptions) (assignments *ListVolumeBackupPolicyAssignments, e error) {
+	required := struct {
+		AssetID string `header:"-" json:"-" url:"assetId"`
+	}{
+		AssetID: assetID,
+	}
+
+	details := &requestDetails{
+		name:     resourceVolumeBackupPolicyAssignments,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.coreApi.getRequest(details); e != nil {
+		return
+	}
+
+	assignments = &ListVolumeBackupPolicyAssignments{}
+	e = resp.unmarshal(assignments)
+	return
+}
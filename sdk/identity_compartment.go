@@ -0,0 +1,97 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import "github.com/oracle/bmcs-go-sdk"
+
+// CreateCompartment create a new compartment. The compartment is created in
+// the tenancy unless opts names another parent compartment.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/Compartment/CreateCompartment
+func (c *Client) CreateCompartment(name, desc string, opts *CreateCompartmentOptions) (res *baremetal.Compartment, e error) {
+	required := identityCreationRequirement{
+		CompartmentID: c.authInfo.tenancyOCID,
+		Description:   desc,
+		Name:          name,
+	}
+	if opts != nil && opts.CompartmentID != "" {
+		required.CompartmentID = opts.CompartmentID
+	}
+
+	details := &requestDetails{
+		name:     resourceCompartments,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.identityApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &baremetal.Compartment{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// DeleteCompartment deletes a compartment, which must be empty. The
+// compartment is DELETING until the deletion completes.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/Compartment/DeleteCompartment
+func (c *Client) DeleteCompartment(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceCompartments,
+		optional: opts,
+	}
+	return c.identityApi.deleteRequest(details)
+}
+
+// MoveCompartment moves a compartment to a new parent compartment. The move
+// completes asynchronously; the compartment reports its new parent once it
+// has moved.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/Compartment/MoveCompartment
+func (c *Client) MoveCompartment(id, targetCompartmentID string, opts *baremetal.IfMatchOptions) (e error) {
+	required := struct {
+		TargetCompartmentID string `header:"-" json:"targetCompartmentId" url:"-"`
+	}{
+		TargetCompartmentID: targetCompartmentID,
+	}
+
+	details := &requestDetails{
+		ids:      urlParts{id, "actions", "moveCompartment"},
+		name:     resourceCompartments,
+		optional: opts,
+		required: required,
+	}
+
+	_, e = c.identityApi.postRequest(details)
+	return
+}
+
+// ListCompartments returns a list of compartments. The request MAY contain optional paging arguments.
+// The compartments of the tenancy are listed unless opts names another parent compartment.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/Compartment/ListCompartments
+func (c *Client) ListCompartments(opts *ListCompartmentsOptions) (resources *baremetal.ListCompartments, e error) {
+	compartmentID := c.authInfo.tenancyOCID
+	if opts != nil && opts.CompartmentID != "" {
+		compartmentID = opts.CompartmentID
+	}
+
+	details := &requestDetails{
+		name:     resourceCompartments,
+		optional: opts,
+		required: listOCIDRequirement{compartmentID},
+	}
+
+	var getResp *response
+	if getResp, e = c.identityApi.getRequest(details); e != nil {
+		return
+	}
+
+	resources = &baremetal.ListCompartments{}
+	e = getResp.unmarshal(resources)
+	return
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// DynamicGroup is a group of instances, matched by a rule rather than by
+// membership, which can be given access through policies.
+type DynamicGroup struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+	CompartmentID  string    `json:"compartmentId"`
+	Description    string    `json:"description"`
+	ID             string    `json:"id"`
+	InactiveStatus uint16    `json:"inactiveStatus"`
+	MatchingRule   string    `json:"matchingRule"`
+	Name           string    `json:"name"`
+	State          string    `json:"lifecycleState"`
+	TimeCreated    time.Time `json:"timeCreated"`
+}
+
+type ListDynamicGroups struct {
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	DynamicGroups []DynamicGroup
+}
+
+func (l *ListDynamicGroups) GetList() interface{} {
+	return &l.DynamicGroups
+}
+
+// CreateDynamicGroup creates a new dynamic group in the tenancy. name MUST
+// be unique, and matchingRule selects the instances in the group.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/DynamicGroup/CreateDynamicGroup
+func (c *Client) CreateDynamicGroup(name, matchingRule, desc string, opts *baremetal.RetryTokenOptions) (res *DynamicGroup, e error) {
+	required := struct {
+		identityCreationRequirement
+		MatchingRule string `header:"-" json:"matchingRule" url:"-"`
+	}{
+		MatchingRule: matchingRule,
+	}
+	required.CompartmentID = c.authInfo.tenancyOCID
+	required.Description = desc
+	required.Name = name
+
+	details := &requestDetails{
+		name:     resourceDynamicGroups,
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.identityApi.postRequest(details); e != nil {
+		return
+	}
+
+	res = &DynamicGroup{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// GetDynamicGroup returns the dynamic group identified by id.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/DynamicGroup/GetDynamicGroup
+func (c *Client) GetDynamicGroup(id string) (res *DynamicGroup, e error) {
+	details := &requestDetails{
+		ids:  urlParts{id},
+		name: resourceDynamicGroups,
+	}
+
+	var resp *response
+	if resp, e = c.identityApi.getRequest(details); e != nil {
+		return
+	}
+
+	res = &DynamicGroup{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// UpdateDynamicGroup updates the description and matching rule of a
+// dynamic group.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/DynamicGroup/UpdateDynamicGroup
+func (c *Client) UpdateDynamicGroup(id string, opts *UpdateDynamicGroupOptions) (res *DynamicGroup, e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceDynamicGroups,
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.identityApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	res = &DynamicGroup{}
+	e = resp.unmarshal(res)
+	return
+}
+
+// DeleteDynamicGroup removes the dynamic group identified by id.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/DynamicGroup/DeleteDynamicGroup
+func (c *Client) DeleteDynamicGroup(id string, opts *baremetal.IfMatchOptions) (e error) {
+	details := &requestDetails{
+		ids:      urlParts{id},
+		name:     resourceDynamicGroups,
+		optional: opts,
+	}
+
+	return c.identityApi.deleteRequest(details)
+}
+
+// ListDynamicGroups returns the dynamic groups in the tenancy.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/identity/20160918/DynamicGroup/ListDynamicGroups
+func (c *Client) ListDynamicGroups(opts *baremetal.ListOptions) (resources *ListDynamicGroups, e error) {
+	details := &requestDetails{
+		name:     resourceDynamicGroups,
+		optional: opts,
+		required: listOCIDRequirement{c.authInfo.tenancyOCID},
+	}
+
+	var resp *response
+	if resp, e = c.identityApi.getRequest(details); e != nil {
+		return
+	}
+
+	resources = &ListDynamicGroups{}
+	e = resp.unmarshal(resources)
+	return
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+const (
+	defaultMetadataURL = "http://169.254.169.254/opc/v1"
+	// metadataTimeout bounds requests to the metadata service, which is
+	// link-local and answers quickly on an instance, but is not reachable
+	// anywhere else.
+	metadataTimeout = 10 * time.Second
+	// sessionKeyBits is the size of the ephemeral keys security tokens are
+	// bound to.
+	sessionKeyBits = 2048
+)
+
+// InstancePrincipalKeyProvider signs requests as the instance the client runs
+// on. The instance's certificate and private key are fetched from the
+// instance metadata service, and exchanged at the federation endpoint for a
+// security token bound to an ephemeral session key. Requests are signed with
+// the session key, and the token is replaced once half of its lifetime has
+// passed.
+type InstancePrincipalKeyProvider struct {
+	metadataURL    string
+	federationURL  string
+	metadataClient *http.Client
+	httpClient     *http.Client
+	userAgent      string
+	tenancyOCID    string
+
+	mutex      sync.Mutex
+	token      string
+	sessionKey *rsa.PrivateKey
+	refreshAt  time.Time
+}
+
+// regionShortNames maps the airport codes the metadata service reports for
+// the first regions to their names.
+var regionShortNames = map[string]string{
+	"fra": "eu-frankfurt-1",
+	"iad": "us-ashburn-1",
+	"lhr": "uk-london-1",
+	"phx": "us-phoenix-1",
+}
+
+type federationRequest struct {
+	Certificate              string   `json:"certificate"`
+	PublicKey                string   `json:"publicKey"`
+	IntermediateCertificates []string `json:"intermediateCertificates"`
+}
+
+// NewInstancePrincipalKeyProvider obtains a security token for the instance
+// the client runs on. The instance metadata service is at metadataURL, or
+// http://169.254.169.254/opc/v1 when it is empty. The Region, UrlTemplate,
+// Transport and UserAgent options are honored; the federation endpoint is the
+// "auth" service of the region.
+func NewInstancePrincipalKeyProvider(metadataURL string, opts ...baremetal.NewClientOptionsFunc) (p *InstancePrincipalKeyProvider, e error) {
+	nco := newClientOptions(opts)
+	if metadataURL == "" {
+		metadataURL = defaultMetadataURL
+	}
+
+	p = &InstancePrincipalKeyProvider{
+		metadataURL:    strings.TrimSuffix(metadataURL, "/"),
+		federationURL:  fmt.Sprintf(nco.UrlTemplate, "auth", nco.Region) + "/v1/x509",
+		metadataClient: &http.Client{Transport: nco.Transport, Timeout: metadataTimeout},
+		httpClient:     &http.Client{Transport: nco.Transport},
+		userAgent:      nco.UserAgent,
+	}
+	if e = p.refresh(); e != nil {
+		return nil, e
+	}
+	return
+}
+
+// InstanceRegion returns the region of the instance the client runs on. The
+// instance metadata service is at metadataURL, or
+// http://169.254.169.254/opc/v1 when it is empty. Only the Transport option
+// is honored.
+func InstanceRegion(metadataURL string, opts ...baremetal.NewClientOptionsFunc) (region string, e error) {
+	nco := newClientOptions(opts)
+	if metadataURL == "" {
+		metadataURL = defaultMetadataURL
+	}
+
+	client := &http.Client{Transport: nco.Transport, Timeout: metadataTimeout}
+	var body []byte
+	if body, e = getMetadata(client, strings.TrimSuffix(metadataURL, "/"), "instance/region"); e != nil {
+		return
+	}
+
+	region = strings.TrimSpace(string(body))
+	if name, ok := regionShortNames[region]; ok {
+		region = name
+	}
+	return
+}
+
+// TenancyOCID returns the tenancy of the instance, taken from its
+// certificate.
+func (p *InstancePrincipalKeyProvider) TenancyOCID() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.tenancyOCID
+}
+
+// SigningKey returns the current security token and session key, obtaining
+// new ones if the token is due to be replaced.
+func (p *InstancePrincipalKeyProvider) SigningKey() (keyID string, key *rsa.PrivateKey, e error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !time.Now().Before(p.refreshAt) {
+		if e = p.refresh(); e != nil {
+			return
+		}
+	}
+	return "ST$" + p.token, p.sessionKey, nil
+}
+
+// refresh fetches the instance's current certificate, which is rotated by
+// the metadata service, and federates with it. Callers must hold the mutex,
+// except during construction.
+func (p *InstancePrincipalKeyProvider) refresh() (e error) {
+	var certPEM, intermediatePEM, keyPEM []byte
+	if certPEM, e = p.getMetadata("identity/cert.pem"); e != nil {
+		return
+	}
+	if intermediatePEM, e = p.getMetadata("identity/intermediate.pem"); e != nil {
+		return
+	}
+	if keyPEM, e = p.getMetadata("identity/key.pem"); e != nil {
+		return
+	}
+
+	var cert, intermediate *x509.Certificate
+	if cert, e = parseCertificate(certPEM); e != nil {
+		return
+	}
+	if intermediate, e = parseCertificate(intermediatePEM); e != nil {
+		return
+	}
+	var certKey *rsa.PrivateKey
+	if certKey, e = baremetal.PrivateKeyFromBytes(keyPEM, nil); e != nil {
+		return
+	}
+
+	tenancyOCID := certificateTenancy(cert)
+	if tenancyOCID == "" {
+		return errors.New("The instance certificate does not name a tenancy")
+	}
+
+	var sessionKey *rsa.PrivateKey
+	if sessionKey, e = rsa.GenerateKey(rand.Reader, sessionKeyBits); e != nil {
+		return
+	}
+	var publicKey []byte
+	if publicKey, e = x509.MarshalPKIXPublicKey(&sessionKey.PublicKey); e != nil {
+		return
+	}
+
+	var body []byte
+	if body, e = json.Marshal(federationRequest{
+		Certificate:              base64.StdEncoding.EncodeToString(cert.Raw),
+		PublicKey:                base64.StdEncoding.EncodeToString(publicKey),
+		IntermediateCertificates: []string{base64.StdEncoding.EncodeToString(intermediate.Raw)},
+	}); e != nil {
+		return
+	}
+
+	var req *http.Request
+	if req, e = http.NewRequest(http.MethodPost, p.federationURL, bytes.NewReader(body)); e != nil {
+		return
+	}
+	certKeyID := fmt.Sprintf("%s/fed-x509/%s", tenancyOCID, certificateFingerprint(cert))
+	if e = createAuthorizationHeader(req, &apiKey{keyID: certKeyID, key: certKey}, p.userAgent, body); e != nil {
+		return
+	}
+
+	var resp *http.Response
+	if resp, e = p.httpClient.Do(req); e != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiError := getErrorFromResponse(resp.Body, resp)
+		return &apiError
+	}
+
+	token := struct {
+		Token string `json:"token"`
+	}{}
+	if e = json.NewDecoder(resp.Body).Decode(&token); e != nil {
+		return
+	}
+	var expires time.Time
+	if expires, e = tokenExpiry(token.Token); e != nil {
+		return
+	}
+
+	now := time.Now()
+	p.tenancyOCID = tenancyOCID
+	p.token = token.Token
+	p.sessionKey = sessionKey
+	p.refreshAt = now.Add(expires.Sub(now) / 2)
+	return
+}
+
+func (p *InstancePrincipalKeyProvider) getMetadata(path string) ([]byte, error) {
+	return getMetadata(p.metadataClient, p.metadataURL, path)
+}
+
+// getMetadata fetches a document from the instance metadata service. The
+// service not answering means the client is not running on an instance.
+func getMetadata(client *http.Client, metadataURL, path string) (body []byte, e error) {
+	var resp *http.Response
+	if resp, e = client.Get(metadataURL + "/" + path); e != nil {
+		return nil, fmt.Errorf("Could not reach the instance metadata service at %s, instance principals can only be used when running on an OCI instance: %s", metadataURL, e)
+	}
+	defer resp.Body.Close()
+
+	if body, e = ioutil.ReadAll(resp.Body); e != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Could not get %s from the instance metadata service, status %d", path, resp.StatusCode)
+	}
+	return
+}
+
+func parseCertificate(pemData []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("PEM data was not found in buffer")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certificateTenancy returns the tenancy named in an instance certificate's
+// subject, as an "opc-tenant:" organizational unit.
+func certificateTenancy(cert *x509.Certificate) string {
+	for _, unit := range cert.Subject.OrganizationalUnit {
+		if strings.HasPrefix(unit, "opc-tenant:") {
+			return strings.TrimPrefix(unit, "opc-tenant:")
+		}
+	}
+	return ""
+}
+
+// certificateFingerprint is the colon separated SHA-1 fingerprint of a
+// certificate, which identifies it in the federation request's key id.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// tokenExpiry reads the expiry of a security token, which is a JWT.
+func tokenExpiry(token string) (expires time.Time, e error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		e = errors.New("The federation endpoint returned a malformed security token")
+		return
+	}
+	var payload []byte
+	if payload, e = base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "=")); e != nil {
+		return
+	}
+	claims := struct {
+		Expires int64 `json:"exp"`
+	}{}
+	if e = json.Unmarshal(payload, &claims); e != nil {
+		return
+	}
+	if claims.Expires == 0 {
+		e = errors.New("The security token does not have an expiry")
+		return
+	}
+	return time.Unix(claims.Expires, 0), nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// UpdateHealthChecker Updates the health check policy for a given load balancer and backend set.
+// The vendored SDK sends this request to the object storage service, at the
+// path of the backend set.
+//
+// See: https://docs.us-phoenix-1.oraclecloud.com/api/#/en/loadbalancer/20170115/HealthChecker/UpdateHealthChecker
+func (c *Client) UpdateHealthChecker(
+	loadBalancerID string,
+	backendSetName string,
+	healthCheckerOptions baremetal.HealthChecker,
+	opts *baremetal.LoadBalancerOptions,
+) (workRequestID string, e error) {
+
+	details := &requestDetails{
+		ids: urlParts{resourceLoadBalancers, loadBalancerID,
+			resourceBackendSets, backendSetName, resourceHealthChecker},
+		required: healthCheckerOptions,
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.loadBalancerApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	healthChecker := &baremetal.HealthChecker{}
+	e = resp.unmarshal(healthChecker)
+	if e == nil {
+		workRequestID = healthChecker.WorkRequestID
+	}
+	return
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// MultipartUpload is an upload of an object in parts that has not yet
+// been committed or aborted.
+type MultipartUpload struct {
+	baremetal.OPCClientRequestIDUnmarshaller
+	baremetal.OPCRequestIDUnmarshaller
+	Namespace   baremetal.Namespace `json:"namespace"`
+	Bucket      string              `json:"bucket"`
+	Object      string              `json:"object"`
+	UploadID    string              `json:"uploadId"`
+	TimeCreated baremetal.Time      `json:"timeCreated"`
+}
+
+type ListMultipartUploads struct {
+	baremetal.OPCClientRequestIDUnmarshaller
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	MultipartUploads []MultipartUpload
+}
+
+func (l *ListMultipartUploads) GetList() interface{} {
+	return &l.MultipartUploads
+}
+
+// MultipartUploadPartSummary describes a part that has been uploaded.
+type MultipartUploadPartSummary struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+	MD5        string `json:"md5"`
+	Size       uint64 `json:"size"`
+}
+
+type ListMultipartUploadParts struct {
+	baremetal.OPCClientRequestIDUnmarshaller
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.NextPageUnmarshaller
+	Parts []MultipartUploadPartSummary
+}
+
+func (l *ListMultipartUploadParts) GetList() interface{} {
+	return &l.Parts
+}
+
+// MultipartUploadPart is the result of uploading one part.
+type MultipartUploadPart struct {
+	baremetal.OPCClientRequestIDUnmarshaller
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+}
+
+// CommitMultipartUploadPartDetails identifies an uploaded part to assemble
+// into the object.
+type CommitMultipartUploadPartDetails struct {
+	PartNum int    `json:"partNum"`
+	ETag    string `json:"etag"`
+}
+
+type CommitMultipartUpload struct {
+	baremetal.OPCClientRequestIDUnmarshaller
+	baremetal.OPCRequestIDUnmarshaller
+	baremetal.ETagUnmarshaller
+}
+
+func buildUploadUrlParts(namespace baremetal.Namespace, bucketName string, rest ...interface{}) urlParts {
+	parts := urlParts{namespace, resourceBuckets, bucketName, resourceUploads}
+	for _, elem := range rest {
+		parts = append(parts, elem)
+	}
+	return parts
+}
+
+// CreateMultipartUpload starts an upload of an object in parts. The
+// object's content type, language, encoding and metadata are set here
+// rather than when the parts are uploaded.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/MultipartUpload/CreateMultipartUpload
+func (c *Client) CreateMultipartUpload(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	opts *CreateMultipartUploadOptions,
+) (upload *MultipartUpload, e error) {
+	required := struct {
+		Object string `header:"-" json:"object" url:"-"`
+	}{
+		Object: objectName,
+	}
+
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName),
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.postRequest(details); e != nil {
+		return
+	}
+
+	upload = &MultipartUpload{}
+	e = resp.unmarshal(upload)
+	return
+}
+
+// ListMultipartUploads lists the uploads in progress in a bucket.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/MultipartUpload/ListMultipartUploads
+func (c *Client) ListMultipartUploads(
+	namespace baremetal.Namespace,
+	bucketName string,
+	opts *ListMultipartUploadsOptions,
+) (uploads *ListMultipartUploads, e error) {
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName),
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.getRequest(details); e != nil {
+		return
+	}
+
+	uploads = &ListMultipartUploads{}
+	e = resp.unmarshal(uploads)
+	return
+}
+
+// ListMultipartUploadParts lists the parts uploaded so far.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/MultipartUploadPartSummary/ListMultipartUploadParts
+func (c *Client) ListMultipartUploadParts(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	uploadID string,
+	opts *ListMultipartUploadsOptions,
+) (parts *ListMultipartUploadParts, e error) {
+	required := struct {
+		UploadID string `header:"-" json:"-" url:"uploadId"`
+	}{
+		UploadID: uploadID,
+	}
+
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName, objectName),
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.getRequest(details); e != nil {
+		return
+	}
+
+	parts = &ListMultipartUploadParts{}
+	e = resp.unmarshal(parts)
+	return
+}
+
+// UploadPart uploads one part of a multipart upload. Part numbers start
+// at 1; uploading a part number again replaces that part.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/Object/UploadPart
+func (c *Client) UploadPart(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	uploadID string,
+	partNum int,
+	content []byte,
+	opts *UploadPartOptions,
+) (part *MultipartUploadPart, e error) {
+	required := struct {
+		bodyRequirement
+		ContentLength uint64 `header:"Content-Length" json:"-" url:"-"`
+		UploadID      string `header:"-" json:"-" url:"uploadId"`
+		PartNum       int    `header:"-" json:"-" url:"uploadPartNum"`
+	}{
+		ContentLength: uint64(len(content)),
+		UploadID:      uploadID,
+		PartNum:       partNum,
+	}
+	required.Body = content
+
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName, objectName),
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.request(http.MethodPut, details); e != nil {
+		return
+	}
+
+	part = &MultipartUploadPart{}
+	e = resp.unmarshal(part)
+	return
+}
+
+// CommitMultipartUpload assembles the given parts into the object and
+// ends the upload.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/Object/CommitMultipartUpload
+func (c *Client) CommitMultipartUpload(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	uploadID string,
+	parts []CommitMultipartUploadPartDetails,
+	opts *CommitMultipartUploadOptions,
+) (commit *CommitMultipartUpload, e error) {
+	required := struct {
+		UploadID      string                             `header:"-" json:"-" url:"uploadId"`
+		PartsToCommit []CommitMultipartUploadPartDetails `header:"-" json:"partsToCommit" url:"-"`
+	}{
+		UploadID:      uploadID,
+		PartsToCommit: parts,
+	}
+
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName, objectName),
+		optional: opts,
+		required: required,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.postRequest(details); e != nil {
+		return
+	}
+
+	commit = &CommitMultipartUpload{}
+	e = resp.unmarshal(commit)
+	return
+}
+
+// AbortMultipartUpload ends an upload and discards its parts.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/MultipartUpload/AbortMultipartUpload
+func (c *Client) AbortMultipartUpload(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	uploadID string,
+	opts *baremetal.ClientRequestOptions,
+) (e error) {
+	required := struct {
+		UploadID string `header:"-" json:"-" url:"uploadId"`
+	}{
+		UploadID: uploadID,
+	}
+
+	details := &requestDetails{
+		ids:      buildUploadUrlParts(namespace, bucketName, objectName),
+		optional: opts,
+		required: required,
+	}
+
+	return c.objectStorageApi.deleteRequest(details)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// GetObject is baremetal.Client.GetObject, except that the ContentMD5 of an
+// object uploaded in parts is its multipart digest rather than empty.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/methods/GetObject
+func (c *Client) GetObject(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	opts *baremetal.GetObjectOptions,
+) (object *baremetal.Object, e error) {
+	details := &requestDetails{
+		ids: urlParts{
+			namespace,
+			resourceBuckets,
+			bucketName,
+			resourceObjects,
+			objectName,
+		},
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.getRequest(details); e != nil {
+		return
+	}
+
+	object = &baremetal.Object{}
+	e = resp.unmarshal(object)
+	object.Namespace = namespace
+	object.Bucket = bucketName
+	object.ID = objectName
+	return
+}
+
+// HeadObject is baremetal.Client.HeadObject, except that the ContentMD5 of
+// an object uploaded in parts is its multipart digest rather than empty.
+//
+// See https://docs.us-phoenix-1.oraclecloud.com/api/#/en/objectstorage/20160918/methods/HeadObject
+func (c *Client) HeadObject(
+	namespace baremetal.Namespace,
+	bucketName string,
+	objectName string,
+	opts *baremetal.HeadObjectOptions,
+) (headObject *baremetal.HeadObject, e error) {
+	details := &requestDetails{
+		ids: urlParts{
+			namespace,
+			resourceBuckets,
+			bucketName,
+			resourceObjects,
+			objectName,
+		},
+		optional: opts,
+	}
+
+	var resp *response
+	if resp, e = c.objectStorageApi.request(http.MethodHead, details); e != nil {
+		return
+	}
+
+	headObject = &baremetal.HeadObject{}
+	e = resp.unmarshal(headObject)
+	headObject.Namespace = namespace
+	headObject.Bucket = bucketName
+	headObject.ID = objectName
+	return
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+type urlParts []interface{}
+
+// requestDetails describes a request the way the vendored SDK does. optional
+// is a struct of options, required an anonymous struct, and the fields of
+// both are sent as the header, url or json named by their tags.
+type requestDetails struct {
+	ids      urlParts
+	name     resourceName
+	optional interface{}
+	required interface{}
+}
+
+// Body is sent as is rather than marshalled to JSON
+type bodyMarshaller interface {
+	body() []byte
+}
+
+type bodyRequirement struct {
+	Body []byte `header:"-" json:"-" url:"-"`
+}
+
+func (b bodyRequirement) body() []byte {
+	return b.Body
+}
+
+type listOCIDRequirement struct {
+	CompartmentID string `header:"-" json:"-" url:"compartmentId"`
+}
+
+type ocidRequirement struct {
+	CompartmentID string `header:"-" json:"compartmentId" url:"compartmentId"`
+}
+
+type identityCreationRequirement struct {
+	CompartmentID string `header:"-" json:"compartmentId" url:"-"`
+	Description   string `header:"-" json:"description" url:"-"`
+	Name          string `header:"-" json:"name" url:"-"`
+}
+
+func objToJSONMap(val interface{}) (map[string]interface{}, error) {
+	marshaled, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	jsonMap := make(map[string]interface{})
+	if string(marshaled) == "null" {
+		return jsonMap, nil
+	}
+	err = json.Unmarshal(marshaled, &jsonMap)
+	return jsonMap, err
+}
+
+func (r *requestDetails) marshalBody() (marshaled []byte, e error) {
+	if bm, ok := r.required.(bodyMarshaller); ok {
+		return bm.body(), nil
+	}
+
+	required := r.required
+	if required == nil {
+		required = struct{}{}
+	}
+	requiredMap, err := objToJSONMap(required)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := r.optional
+	if opts == nil {
+		opts = struct{}{}
+	}
+	optMap, err := objToJSONMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Override options with required in case of overlap
+	for k, v := range requiredMap {
+		optMap[k] = v
+	}
+
+	return json.Marshal(optMap)
+}
+
+func (r *requestDetails) marshalHeader() (header http.Header, e error) {
+	var rHeader http.Header
+	if rHeader, e = baremetal.NewHeaderFromStruct(r.required); e != nil {
+		return
+	}
+	if header, e = baremetal.NewHeaderFromStruct(r.optional); e != nil {
+		return
+	}
+	for k, v := range rHeader {
+		header[k] = v
+	}
+	return
+}
+
+func (r *requestDetails) marshalURL(urlTemplate string, region string, urlFn urlBuilderFn) (val string, e error) {
+	var rVals, q url.Values
+	if rVals, e = query.Values(r.required); e != nil {
+		return
+	}
+	if q, e = query.Values(r.optional); e != nil {
+		return
+	}
+	for k, v := range rVals {
+		q[k] = v
+	}
+	return urlFn(urlTemplate, region, r.name, q, r.ids...)
+}
+
+type urlBuilderFn func(string, string, resourceName, url.Values, ...interface{}) (string, error)
+
+func buildCoreURL(urlTemplate string, region string, resource resourceName, query url.Values, ids ...interface{}) (string, error) {
+	baseUrl := fmt.Sprintf(urlTemplate, coreServiceAPI, region)
+	urlStr := fmt.Sprintf("%s/%s/%s", baseUrl, coreServiceAPIVersion, resource)
+	return buildURL(urlStr, query, ids...)
+}
+
+func buildIdentityURL(urlTemplate string, region string, resource resourceName, query url.Values, ids ...interface{}) (string, error) {
+	baseUrl := fmt.Sprintf(urlTemplate, identityServiceAPI, region)
+	urlStr := fmt.Sprintf("%s/%s/%s", baseUrl, identityServiceAPIVersion, resource)
+	return buildURL(urlStr, query, ids...)
+}
+
+func buildObjectStorageURL(urlTemplate string, region string, resource resourceName, query url.Values, ids ...interface{}) (string, error) {
+	baseUrl := fmt.Sprintf(urlTemplate, objectStorageServiceAPI, region)
+	urlStr := fmt.Sprintf("%s/%s", baseUrl, resourceNamespaces)
+	return buildURL(urlStr, query, ids...)
+}
+
+func buildLoadBalancerURL(urlTemplate string, region string, resource resourceName, query url.Values, ids ...interface{}) (string, error) {
+	baseUrl := fmt.Sprintf(urlTemplate, loadBalancerServiceAPI, region)
+	urlStr := fmt.Sprintf("%s/%s/%s", baseUrl, loadBalancerServiceAPIVersion, resource)
+	return buildURL(urlStr, query, ids...)
+}
+
+func buildURL(urlStr string, query url.Values, ids ...interface{}) (string, error) {
+	const separator = "/"
+	for _, id := range ids {
+		var strVal string
+
+		switch id := id.(type) {
+		default:
+			return "", errors.New("Unsupported type")
+		case bool:
+			strVal = strconv.FormatBool(id)
+		case int:
+			strVal = strconv.Itoa(id)
+		case uint64:
+			strVal = strconv.FormatUint(id, 10)
+		case string:
+			strVal = id
+		case resourceName:
+			strVal = string(id)
+		case baremetal.Namespace:
+			strVal = string(id)
+		}
+
+		if strVal != separator {
+			urlStr += separator
+		}
+
+		urlStr += strVal
+	}
+
+	u, e := url.Parse(urlStr)
+	if e != nil {
+		return "", e
+	}
+	if query != nil {
+		q := u.Query()
+		for key, vals := range query {
+			for _, val := range vals {
+				q.Add(key, val)
+			}
+		}
+
+		u.RawQuery += q.Encode()
+	}
+
+	return u.String(), nil
+}
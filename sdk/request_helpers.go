@@ -0,0 +1,176 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+// KeyProvider supplies the key requests are signed with.
+type KeyProvider interface {
+	// SigningKey returns the key id sent in the authorization header, and
+	// the private key to sign with.
+	SigningKey() (keyID string, key *rsa.PrivateKey, e error)
+}
+
+// apiKey signs requests as a user with one of their API keys.
+type apiKey struct {
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+func (k *apiKey) SigningKey() (string, *rsa.PrivateKey, error) {
+	return k.keyID, k.key, nil
+}
+
+type authenticationInfo struct {
+	tenancyOCID string
+	keys        KeyProvider
+}
+
+var signerVersion = "1"
+
+// signingTransport signs each request with the current key of keys, in place
+// of any signature it already has.
+type signingTransport struct {
+	transport http.RoundTripper
+	keys      KeyProvider
+}
+
+func (t *signingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	signed := request.Clone(request.Context())
+	signed.Header.Del("authorization")
+	if e := signRequest(signed, t.keys); e != nil {
+		return nil, e
+	}
+	return t.transport.RoundTrip(signed)
+}
+
+func getErrorFromResponse(body io.Reader, resp *http.Response) (apiError baremetal.Error) {
+	if opcRequestID := resp.Header.Get(headerOPCRequestID); opcRequestID != "" {
+		apiError.OPCRequestID = opcRequestID
+	}
+
+	if resp.Header.Get("content-type") != "application/json" {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(body)
+		apiError.Message = buf.String()
+	} else {
+		decoder := json.NewDecoder(body)
+		if e := decoder.Decode(&apiError); e != nil {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(decoder.Buffered())
+			apiError.Message = buf.String()
+		}
+	}
+	apiError.Status = strconv.Itoa(resp.StatusCode)
+
+	return
+}
+
+func createAuthorizationHeader(request *http.Request, keys KeyProvider, userAgent string, body []byte) (e error) {
+	addRequiredRequestHeaders(request, userAgent, body)
+	return signRequest(request, keys)
+}
+
+func signRequest(request *http.Request, keys KeyProvider) (e error) {
+	var keyID string
+	var key *rsa.PrivateKey
+	if keyID, key, e = keys.SigningKey(); e != nil {
+		return
+	}
+
+	var sig string
+	if sig, e = computeSignature(request, key); e != nil {
+		return
+	}
+
+	headers := strings.Join(getSigningHeaders(request.Method), " ")
+	authValue := fmt.Sprintf("Signature version=\"%s\",headers=\"%s\",keyId=\"%s\",algorithm=\"rsa-sha256\",signature=\"%s\"", signerVersion, headers, keyID, sig)
+
+	request.Header.Add("authorization", authValue)
+
+	return
+}
+
+func getSigningHeaders(method string) []string {
+	result := []string{
+		"date",
+		"(request-target)",
+		"host",
+	}
+
+	if method == http.MethodPost || method == http.MethodPut {
+		result = append(result, "content-length", "content-type", "x-content-sha256")
+	}
+
+	return result
+}
+
+func computeSignature(request *http.Request, privateKey *rsa.PrivateKey) (sig string, e error) {
+	hashed := sha256.Sum256([]byte(getSigningString(request)))
+	var unencodedSig []byte
+	if unencodedSig, e = rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:]); e != nil {
+		return
+	}
+
+	sig = base64.StdEncoding.EncodeToString(unencodedSig)
+	return
+}
+
+func getSigningString(request *http.Request) string {
+	lines := []string{}
+	for _, header := range getSigningHeaders(request.Method) {
+		if header == "(request-target)" {
+			lines = append(lines, fmt.Sprintf("%s: %s %s", header, strings.ToLower(request.Method), request.URL.RequestURI()))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %s", header, request.Header.Get(header)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func addIfNotPresent(dest *http.Header, key, value string) {
+	if dest.Get(key) == "" {
+		dest.Set(key, value)
+	}
+}
+
+func getBodyHash(body []byte) string {
+	hash := sha256.Sum256(body)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func addRequiredRequestHeaders(request *http.Request, userAgent string, body []byte) {
+	addIfNotPresent(&request.Header, "content-type", "application/json")
+	addIfNotPresent(&request.Header, "date", time.Now().UTC().Format(http.TimeFormat))
+	addIfNotPresent(&request.Header, "host", request.URL.Host)
+	if userAgent == "" {
+		addIfNotPresent(&request.Header, "User-Agent", fmt.Sprintf("baremetal-sdk-go-v%s", baremetal.SDKVersion))
+	} else {
+		addIfNotPresent(&request.Header, "User-Agent", userAgent)
+	}
+	addIfNotPresent(&request.Header, "accept", "*/*")
+
+	if request.Method == http.MethodPost || request.Method == http.MethodPut {
+		addIfNotPresent(&request.Header, "content-length", strconv.FormatInt(request.ContentLength, 10))
+
+		if request.ContentLength > 0 {
+			addIfNotPresent(&request.Header, "x-content-sha256", getBodyHash(body))
+		}
+	}
+}
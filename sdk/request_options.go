@@ -0,0 +1,93 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import "github.com/oracle/bmcs-go-sdk"
+
+type CreateCompartmentOptions struct {
+	baremetal.RetryTokenOptions
+	// CompartmentID is the parent compartment, the tenancy by default.
+	CompartmentID string `header:"-" json:"-" url:"-"`
+}
+
+type ListCompartmentsOptions struct {
+	baremetal.ListOptions
+	// CompartmentID is the parent compartment, the tenancy by default.
+	CompartmentID string `header:"-" json:"-" url:"-"`
+}
+
+type UpdateDynamicGroupOptions struct {
+	baremetal.UpdateIdentityOptions
+	MatchingRule string `header:"-" json:"matchingRule,omitempty" url:"-"`
+}
+
+type CreateImageOptions struct {
+	baremetal.CreateOptions
+	ImageSourceDetails *ImageSourceDetails `header:"-" json:"imageSourceDetails,omitempty" url:"-"`
+}
+
+type LaunchInstanceOptions struct {
+	baremetal.LaunchInstanceOptions
+	SourceDetails *InstanceSourceDetails `header:"-" json:"sourceDetails,omitempty" url:"-"`
+}
+
+type TerminateInstanceOptions struct {
+	baremetal.IfMatchOptions
+	PreserveBootVolume bool `header:"-" json:"-" url:"preserveBootVolume,omitempty"`
+}
+
+type CreateBootVolumeOptions struct {
+	baremetal.CreateOptions
+	SizeInGBs int `header:"-" json:"sizeInGBs,omitempty" url:"-"`
+}
+
+type ListBootVolumeAttachmentsOptions struct {
+	baremetal.InstanceIDListOptions
+	baremetal.ListOptions
+	BootVolumeID string `header:"-" json:"-" url:"bootVolumeId,omitempty"`
+}
+
+type UpdateVolumeOptions struct {
+	baremetal.UpdateOptions
+	SizeInGBs int `header:"-" json:"sizeInGBs,omitempty" url:"-"`
+}
+
+type UpdateVolumeBackupPolicyOptions struct {
+	baremetal.IfMatchDisplayNameOptions
+	Schedules []VolumeBackupSchedule `header:"-" json:"schedules,omitempty" url:"-"`
+}
+
+type ListVolumeBackupPoliciesOptions struct {
+	baremetal.ListOptions
+	CompartmentID string `header:"-" json:"-" url:"compartmentId,omitempty"`
+}
+
+type CreateMultipartUploadOptions struct {
+	baremetal.IfMatchOptions
+	baremetal.IfNoneMatchOptions
+	baremetal.ClientRequestOptions
+	ContentType     string            `header:"-" json:"contentType,omitempty" url:"-"`
+	ContentLanguage string            `header:"-" json:"contentLanguage,omitempty" url:"-"`
+	ContentEncoding string            `header:"-" json:"contentEncoding,omitempty" url:"-"`
+	Metadata        map[string]string `header:"-" json:"metadata,omitempty" url:"-"`
+}
+
+type UploadPartOptions struct {
+	baremetal.IfMatchOptions
+	baremetal.IfNoneMatchOptions
+	baremetal.ClientRequestOptions
+	Expect     string `header:"Expect,omitempty" json:"-" url:"-"`
+	ContentMD5 string `header:"Content-MD5,omitempty" json:"-" url:"-"`
+}
+
+type CommitMultipartUploadOptions struct {
+	baremetal.IfMatchOptions
+	baremetal.IfNoneMatchOptions
+	baremetal.ClientRequestOptions
+	PartsToExclude []int `header:"-" json:"partsToExclude,omitempty" url:"-"`
+}
+
+type ListMultipartUploadsOptions struct {
+	baremetal.ListOptions
+	baremetal.ClientRequestOptions
+}
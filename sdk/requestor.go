@@ -0,0 +1,237 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+type requestor interface {
+	request(method string, reqOpts *requestDetails) (r *response, e error)
+	getRequest(reqOpts *requestDetails) (resp *response, e error)
+	postRequest(reqOpts *requestDetails) (resp *response, e error)
+	deleteRequest(reqOpts *requestDetails) (e error)
+}
+
+// apiRequestor sends requests to one service, and retries them like the
+// requestors of the vendored SDK do.
+type apiRequestor struct {
+	httpClient             *http.Client
+	authInfo               *authenticationInfo
+	urlBuilder             urlBuilderFn
+	urlTemplate            string
+	userAgent              string
+	region                 string
+	shortRetryTime         time.Duration
+	longRetryTime          time.Duration
+	randGen                *rand.Rand
+	disableAutoRetries     bool
+	disableNotFoundRetries bool
+}
+
+func newAPIRequestor(authInfo *authenticationInfo, nco *baremetal.NewClientOptions, urlBuilder urlBuilderFn) (r *apiRequestor) {
+	return &apiRequestor{
+		httpClient: &http.Client{
+			Transport: nco.Transport,
+		},
+		authInfo:               authInfo,
+		urlBuilder:             urlBuilder,
+		urlTemplate:            nco.UrlTemplate,
+		userAgent:              nco.UserAgent,
+		region:                 nco.Region,
+		shortRetryTime:         nco.ShortRetryTime,
+		longRetryTime:          nco.LongRetryTime,
+		randGen:                nco.RandGen,
+		disableAutoRetries:     nco.DisableAutoRetries,
+		disableNotFoundRetries: nco.DisableNotFoundRetries,
+	}
+}
+
+func (api *apiRequestor) deleteRequest(reqOpts *requestDetails) (e error) {
+	_, e = api.request(http.MethodDelete, reqOpts)
+	return
+}
+
+func (api *apiRequestor) getRequest(reqOpts *requestDetails) (*response, error) {
+	return api.request(http.MethodGet, reqOpts)
+}
+
+func (api *apiRequestor) postRequest(reqOpts *requestDetails) (*response, error) {
+	return api.request(http.MethodPost, reqOpts)
+}
+
+// request sends a request until it succeeds, fails with an error that is not
+// retried, or the retry time for its error runs out. The retry token stays
+// the same, so a retried create does not create twice.
+func (api *apiRequestor) request(method string, reqOpts *requestDetails) (r *response, e error) {
+	var body []byte
+	if method != http.MethodDelete && method != http.MethodGet {
+		if body, e = reqOpts.marshalBody(); e != nil {
+			return
+		}
+	}
+
+	var urlStr string
+	if urlStr, e = reqOpts.marshalURL(api.urlTemplate, api.region, api.urlBuilder); e != nil {
+		return
+	}
+
+	var header http.Header
+	if header, e = reqOpts.marshalHeader(); e != nil {
+		return
+	}
+	if _, present := header[retryTokenKey]; !api.disableAutoRetries &&
+		!present &&
+		method != http.MethodDelete &&
+		method != http.MethodGet {
+		header[retryTokenKey] = []string{generateRetryToken(api.randGen)}
+	}
+
+	var errorCode string
+	var retryTimeRemaining, timeWaited time.Duration
+	for retryNum := uint(1); ; retryNum++ {
+		var apiError *baremetal.Error
+		if r, apiError, e = api.send(method, urlStr, header, body); e != nil || apiError == nil {
+			return
+		}
+		if api.disableAutoRetries {
+			return nil, apiError
+		}
+
+		// The retry time starts over when the error changes
+		if code := fmt.Sprintf("%s:%s", apiError.Status, apiError.Code); code != errorCode {
+			retryTimeRemaining = getMaxRetryTime(api, apiError, urlStr, method) - timeWaited
+			errorCode = code
+		}
+		if retryTimeRemaining <= 0 {
+			return nil, apiError
+		}
+		timeSlept := polynomialBackoffSleep(retryNum, retryTimeRemaining)
+		retryTimeRemaining -= timeSlept
+		timeWaited += timeSlept
+	}
+}
+
+// send makes one attempt at a request. A response with an error status is
+// returned as apiError.
+func (api *apiRequestor) send(method, urlStr string, header http.Header, body []byte) (r *response, apiError *baremetal.Error, e error) {
+	var req *http.Request
+	if req, e = http.NewRequest(method, urlStr, bytes.NewReader(body)); e != nil {
+		return
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if e = createAuthorizationHeader(req, api.authInfo.keys, api.userAgent, body); e != nil {
+		log.Printf("[WARN] Could not get HTTP authorization header, error: %#v\n", e)
+		return
+	}
+
+	if os.Getenv("DEBUG") != "" {
+		if reqdump, err := httputil.DumpRequestOut(req, true); err == nil {
+			log.Printf("[DEBUG] HTTP Request: %v\n", string(reqdump))
+		}
+	}
+
+	var resp *http.Response
+	if resp, e = api.httpClient.Do(req); e != nil {
+		log.Printf("[WARN] Could not get HTTP Response, error: %#v\n", e)
+		return
+	}
+	defer resp.Body.Close()
+
+	if os.Getenv("DEBUG") != "" {
+		if respdump, err := httputil.DumpResponse(resp, true); err == nil {
+			log.Printf("[DEBUG] HTTP Response: %v\n", string(respdump))
+		}
+	}
+
+	var reader bytes.Buffer
+	if _, e = reader.ReadFrom(resp.Body); e != nil {
+		return
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := getErrorFromResponse(&reader, resp)
+		return nil, &err, nil
+	}
+
+	r = &response{
+		header: resp.Header,
+		body:   reader.Bytes(),
+	}
+	return
+}
+
+var sleep = time.Sleep
+
+func polynomialBackoffSleep(retryNum uint, retryTimeRemaining time.Duration) time.Duration {
+	secondsToSleep := time.Duration(retryNum*retryNum) * time.Second
+	if retryTimeRemaining < secondsToSleep {
+		secondsToSleep = retryTimeRemaining
+	}
+	if os.Getenv("DEBUG") != "" {
+		log.Printf("[DEBUG] Got a retriable error. Waiting %d seconds and trying again...", int(secondsToSleep.Seconds()))
+	}
+	if os.Getenv("TEST") != "true" {
+		sleep(secondsToSleep)
+	}
+	return secondsToSleep
+}
+
+// getMaxRetryTime is how long the vendored SDK retries an error for
+func getMaxRetryTime(api *apiRequestor, e *baremetal.Error, requestURL string, method string) time.Duration {
+	switch e.Status {
+	case "400", "401", "403", "412":
+		return 0
+	case "404":
+		if api.disableNotFoundRetries || method == http.MethodDelete {
+			return 0
+		}
+		if requestServiceCheck(requestURL, identityServiceAPI) ||
+			requestServiceCheck(requestURL, objectStorageServiceAPI) {
+			return api.longRetryTime
+		}
+	case "409":
+		if e.Code == "InvalidatedRetryToken" || e.Code == "CompartmentAlreadyExists" {
+			return 0
+		} else if e.Code == "NotAuthorizedOrResourceAlreadyExists" {
+			if requestServiceCheck(requestURL, identityServiceAPI) ||
+				requestServiceCheck(requestURL, objectStorageServiceAPI) {
+				return api.longRetryTime
+			}
+		}
+	case "429":
+		return api.longRetryTime
+	case "500":
+		if requestServiceCheck(requestURL, objectStorageServiceAPI) {
+			return api.longRetryTime
+		}
+	}
+	return api.shortRetryTime
+}
+
+func requestServiceCheck(requestURL string, service string) bool {
+	return strings.HasPrefix(requestURL, urlPrefix+service)
+}
+
+// generateRetryToken returns a random alphanumeric string, so that requests
+// can be retried safely.
+func generateRetryToken(randGen *rand.Rand) string {
+	alphanumericChars := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	retryToken := make([]rune, generatedRetryTokenLength)
+	for i := range retryToken {
+		retryToken[i] = alphanumericChars[randGen.Intn(len(alphanumericChars))]
+	}
+	return string(retryToken)
+}
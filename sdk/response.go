@@ -0,0 +1,96 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/oracle/bmcs-go-sdk"
+)
+
+type response struct {
+	header http.Header
+	body   []byte
+}
+
+// unmarshal fills resource from the body and headers of the response, using
+// the unmarshallers of the vendored SDK that resource embeds.
+func (r *response) unmarshal(resource interface{}) (e error) {
+	var val interface{}
+
+	if c, ok := resource.(baremetal.Container); ok {
+		val = c.GetList()
+	} else {
+		val = resource
+	}
+
+	if pc, ok := resource.(baremetal.NextPageUnmarshallable); ok {
+		pc.SetNextPage(r.header.Get(headerOPCNextPage))
+	}
+
+	if cs, ok := resource.(baremetal.BodyUnmarshallable); ok {
+		if e = cs.SetBody(r.body, val); e != nil {
+			return
+		}
+	} else if len(r.body) == 0 {
+		// Continue without error. This is usually caused by a 204 response
+	} else if e = json.Unmarshal(r.body, val); e != nil {
+		return
+	}
+
+	if rr, ok := resource.(baremetal.OPCRequestIDUnmarshallable); ok {
+		rr.SetRequestID(r.header.Get(headerOPCRequestID))
+	}
+
+	if crr, ok := resource.(baremetal.OPCClientRequestIDUnmarshallable); ok {
+		crr.SetClientRequestID(r.header.Get(headerOPCClientRequestID))
+	}
+
+	if wrr, ok := resource.(baremetal.OPCWorkRequestIDUnmarshallable); ok {
+		wrr.SetWorkRequestID(r.header.Get(headerOPCWorkRequestID))
+	}
+
+	if et, ok := resource.(baremetal.ETagUnmarshallable); ok {
+		et.SetETag(r.header.Get(headerETag))
+	}
+
+	if cr, ok := resource.(baremetal.ContentUnmarshallable); ok {
+		cr.SetContentEncoding(r.header.Get(headerContentEncoding))
+		cr.SetContentLanguage(r.header.Get(headerContentLanguage))
+		// Objects uploaded in parts have no Content-MD5, only a digest of
+		// their parts' digests.
+		if md5 := r.header.Get(headerContentMD5); md5 != "" {
+			cr.SetContentMD5(md5)
+		} else {
+			cr.SetContentMD5(r.header.Get(headerOPCMultipartMD5))
+		}
+		cr.SetContentType(r.header.Get(headerContentType))
+
+		if lengthStr := r.header.Get(headerContentLength); lengthStr != "" {
+			var length int
+			if length, e = strconv.Atoi(lengthStr); e != nil {
+				return
+			}
+			cr.SetContentLength(uint64(length))
+		}
+	}
+
+	if md, ok := resource.(baremetal.MetadataUnmarshallable); ok {
+		prefix := "opc-meta-"
+		meta := make(map[string]string)
+		for name, headers := range r.header {
+			name = strings.ToLower(name)
+			if strings.HasPrefix(name, prefix) {
+				for _, h := range headers {
+					meta[strings.Replace(name, prefix, "", 1)] = h
+				}
+			}
+		}
+		md.SetMetadata(meta)
+	}
+
+	return
+}